@@ -5,12 +5,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 	"github.com/universal-console/console/internal/app"
 	"github.com/universal-console/console/internal/auth"
 	"github.com/universal-console/console/internal/config"
@@ -36,6 +43,40 @@ type CommandLineArgs struct {
 	Theme       string
 	ShowHelp    bool
 	ShowVersion bool
+
+	// Offline configuration validation
+	ValidateProfile string
+	ValidateConfig  bool
+	JSONOutput      bool
+
+	// Diagnostic logging to a rotating file instead of stdout
+	LogFile       string
+	LogMaxSizeMB  int
+	LogMaxAgeDays int
+
+	// Import a base16 color scheme as a theme
+	ImportTheme string
+
+	// Plain output without the alternate screen or mouse capture, for pipelines and captures
+	Plain bool
+
+	// Demo mode answers from a built-in stub application instead of a backend
+	Demo bool
+
+	// Seconds to wait for command responses when neither the command nor the profile sets a timeout
+	Timeout int
+
+	// Attempts at the initial direct connection before showing the error
+	ConnectAttempts int
+
+	// Append a JSON line for every executed command and action to this file
+	AuditLog string
+
+	// Print the application's handshake specification as JSON and exit
+	DumpSpec bool
+
+	// Create a profile with the interactive wizard and exit
+	Setup bool
 }
 
 // Dependencies holds all injected application dependencies
@@ -48,6 +89,9 @@ type Dependencies struct {
 	Logger          *logging.Logger
 }
 
+// ShutdownTimeout bounds the clean disconnect performed on exit
+const ShutdownTimeout = 5 * time.Second
+
 // ConsoleApp represents the main application with all injected dependencies
 type ConsoleApp struct {
 	deps Dependencies
@@ -63,9 +107,33 @@ func main() {
 		return
 	}
 
+	// Handle offline validation without launching the TUI
+	if isValidationRequested(args) {
+		os.Exit(runValidation(args))
+	}
+
+	// Handle theme import without launching the TUI
+	if isThemeImportRequested(args) {
+		os.Exit(runThemeImport(args))
+	}
+
+	// Handle the spec dump without launching the TUI
+	if isSpecDumpRequested(args) {
+		os.Exit(runSpecDump(args))
+	}
+
+	// Handle the profile wizard without launching the console
+	if isSetupRequested(args) {
+		os.Exit(runSetup(args))
+	}
+
 	// Initialize logging system
 	logger := initializeLogging(args)
 
+	// Cancel the root context on SIGINT or SIGTERM so the interface exits and the session is closed cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Validate command-line arguments
 	if err := validateArguments(args); err != nil {
 		logger.Error("Invalid arguments", "error", err.Error())
@@ -74,6 +142,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Apply the global command timeout; profiles and inline overrides can still change it per command
+	if args.Timeout > 0 {
+		app_ui.DefaultCommandTimeout = time.Duration(args.Timeout) * time.Second
+	}
+
+	// Record executed operations to the audit log file in every session
+	app_ui.AuditLogPath = args.AuditLog
+
+	// Error reports name the console version
+	app_ui.ConsoleVersion = Version
+
+	// Drop colors before any styles are rendered when output is not going to a terminal
+	if !stdoutIsTerminal() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
 	// Initialize all application dependencies
 	deps, err := initializeDependencies(logger)
 	if err != nil {
@@ -82,20 +166,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Demo mode replaces the network client with canned responses
+	if args.Demo {
+		logger.Info("Running in demo mode")
+		deps.ProtocolClient = protocol.NewDemoClient()
+	}
+
 	// Create and run the console application
 	consoleApp := &ConsoleApp{
 		deps: deps,
 		args: args,
 	}
 
-	if err := consoleApp.Run(); err != nil {
-		logger.Error("Application terminated with error", "error", err.Error())
-		fmt.Fprintf(os.Stderr, "Application error: %v\n", err)
+	runErr := consoleApp.Run(ctx)
+	consoleApp.Shutdown()
+
+	if runErr != nil {
+		logger.Error("Application terminated with error", "error", runErr.Error())
+		logger.Close()
+		fmt.Fprintf(os.Stderr, "Application error: %v\n", runErr)
 		os.Exit(1)
 	}
 
 	// Graceful shutdown
-	logger.Info("Application shutdown completed successfully")
+	if ctx.Err() != nil {
+		logger.Info("Application shutdown completed after signal")
+	} else {
+		logger.Info("Application shutdown completed successfully")
+	}
+	logger.Close()
 	fmt.Println("Universal Application Console terminated successfully.")
 }
 
@@ -108,6 +207,22 @@ func parseCommandLineArgs() CommandLineArgs {
 	flag.StringVar(&args.Theme, "theme", "", "Visual theme name for syntax highlighting and UI elements")
 	flag.BoolVar(&args.ShowHelp, "help", false, "Display usage information and exit")
 	flag.BoolVar(&args.ShowVersion, "version", false, "Display version information and exit")
+	flag.StringVar(&args.ValidateProfile, "validate-profile", "", "Validate the named profile and exit without launching the interface")
+	flag.BoolVar(&args.ValidateConfig, "validate-config", false, "Validate the entire configuration file and exit without launching the interface")
+	flag.BoolVar(&args.JSONOutput, "json", false, "Emit validation results as JSON (used with --validate-profile or --validate-config)")
+	flag.StringVar(&args.LogFile, "log-file", "", "Write logs to this file with automatic rotation instead of stdout")
+	flag.IntVar(&args.LogMaxSizeMB, "log-max-size", logging.DefaultConfig().MaxSizeMB, "Rotate the log file when it reaches this many megabytes (0 disables)")
+	flag.IntVar(&args.LogMaxAgeDays, "log-max-age", logging.DefaultConfig().MaxAgeDays, "Rotate the log file and remove backups older than this many days (0 disables)")
+
+	flag.StringVar(&args.ImportTheme, "import-theme", "", "Import a base16 color scheme file as a theme and exit")
+	flag.BoolVar(&args.Plain, "plain", false, "Run without the alternate screen or mouse capture, keeping output in the scrollback")
+	flag.BoolVar(&args.Plain, "no-altscreen", false, "Alias for --plain")
+	flag.BoolVar(&args.Demo, "demo", false, "Run against a built-in demo application that needs no backend")
+	flag.IntVar(&args.ConnectAttempts, "connect-attempts", protocol.DefaultConnectPolicy.Attempts, "Attempts at the initial connection, with increasing delays, before giving up")
+	flag.IntVar(&args.Timeout, "timeout", 0, "Seconds to wait for a command response (default 30); a profile's commandTimeout and an inline !timeout= take precedence")
+	flag.StringVar(&args.AuditLog, "audit-log", "", "Append a JSON line for every executed command and action to this file")
+	flag.BoolVar(&args.DumpSpec, "dump-spec", false, "Connect, print the application's specification as JSON, and exit (used with --host, --profile, or --demo)")
+	flag.BoolVar(&args.Setup, "setup", false, "Create a connection profile with an interactive wizard and exit")
 
 	// Custom usage function to match the design specification
 	flag.Usage = func() {
@@ -120,9 +235,26 @@ func parseCommandLineArgs() CommandLineArgs {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s                           # Launch Console Menu Mode with default profile\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --host localhost:8080     # Connect directly to specified host\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --host ws://localhost:8080 # Connect over a persistent WebSocket\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --profile pokemon         # Connect using 'pokemon' profile\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --theme monokai           # Use monokai color theme\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "\nConfiguration file location: ~/.config/console/profiles.yaml\n")
+		fmt.Fprintf(os.Stderr, "  %s --validate-config --json  # Check the configuration file and print JSON results\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --log-file console.log    # Write diagnostic logs to a rotating file\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --import-theme nord.yaml  # Save a base16 color scheme as the 'nord' theme\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --plain | tee session.txt # Keep output in the scrollback for capture\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --demo                    # Try the interface with canned responses, no backend needed\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --timeout 120             # Wait up to two minutes for each command response\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --audit-log audit.jsonl   # Keep an audit trail of executed operations\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --host localhost:8080 --connect-attempts 10 # Wait for a backend that is still starting\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --host localhost:8080 --dump-spec # Print the application's specification as JSON\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --setup                   # Create a connection profile step by step\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nCommand timeouts, highest precedence first: an inline '!timeout=120 <command>',\n")
+		fmt.Fprintf(os.Stderr, "the profile's commandTimeout, --timeout, and the 30 second default.\n")
+		fmt.Fprintf(os.Stderr, "\nPlain output is used automatically, without colors, when stdout is not a terminal.\n")
+		fmt.Fprintf(os.Stderr, "Tables, trees, and progress bars are drawn in ASCII when the locale is not UTF-8;\n")
+		fmt.Fprintf(os.Stderr, "set CONSOLE_UNICODE=1 or CONSOLE_UNICODE=0 to override.\n")
+		fmt.Fprintf(os.Stderr, "\nConfiguration file location: console/profiles.yaml under $XDG_CONFIG_HOME when set, otherwise\n")
+		fmt.Fprintf(os.Stderr, "under ~/.config (Linux), ~/Library/Application Support (macOS), or %%AppData%% (Windows)\n")
 	}
 
 	flag.Parse()
@@ -157,6 +289,13 @@ func initializeLogging(args CommandLineArgs) *logging.Logger {
 		logConfig.Format = "json"
 	}
 
+	// Send logs to a rotating file when requested
+	if args.LogFile != "" {
+		logConfig.Output = args.LogFile
+		logConfig.MaxSizeMB = args.LogMaxSizeMB
+		logConfig.MaxAgeDays = args.LogMaxAgeDays
+	}
+
 	if err := logging.InitGlobalLogger(logConfig); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
 		os.Exit(1)
@@ -177,6 +316,11 @@ func validateArguments(args CommandLineArgs) error {
 		return fmt.Errorf("cannot specify both --host and --profile options simultaneously")
 	}
 
+	// Demo mode connects to the built-in demo application only
+	if args.Demo && (args.Host != "" || args.Profile != "") {
+		return fmt.Errorf("--demo cannot be combined with --host or --profile")
+	}
+
 	// Validate host format if provided
 	if args.Host != "" {
 		if !strings.Contains(args.Host, ":") {
@@ -184,6 +328,18 @@ func validateArguments(args CommandLineArgs) error {
 		}
 	}
 
+	if args.LogMaxSizeMB < 0 || args.LogMaxAgeDays < 0 {
+		return fmt.Errorf("--log-max-size and --log-max-age cannot be negative")
+	}
+
+	if args.Timeout < 0 {
+		return fmt.Errorf("--timeout cannot be negative")
+	}
+
+	if args.ConnectAttempts < 1 {
+		return fmt.Errorf("--connect-attempts must be at least 1")
+	}
+
 	return nil
 }
 
@@ -223,7 +379,11 @@ func initializeDependencies(logger *logging.Logger) (Dependencies, error) {
 	deps.ContentRenderer = contentRenderer
 
 	// Initialize registry manager
-	registryManager, err := registry.NewManager(configManager, protocolClient)
+	// Health checks connect with clients of their own, leaving the session's connection alone
+	newHealthClient := func(pool protocol.PoolConfig) (interfaces.ProtocolClient, error) {
+		return protocol.NewPooledClient(configManager, authManager, pool)
+	}
+	registryManager, err := registry.NewManager(configManager, newHealthClient)
 	if err != nil {
 		return deps, fmt.Errorf("failed to initialize registry manager: %w", err)
 	}
@@ -233,11 +393,12 @@ func initializeDependencies(logger *logging.Logger) (Dependencies, error) {
 	return deps, nil
 }
 
-// Run starts the console application with the appropriate mode
-func (ca *ConsoleApp) Run() error {
+// Run starts the console application with the appropriate mode. Cancelling ctx stops the
+// interface and restores the terminal; this is reported as a clean exit, not an error.
+func (ca *ConsoleApp) Run(ctx context.Context) error {
 	ca.deps.Logger.Debug("Creating Bubble Tea program")
 
-	program, err := ca.createBubbleTeaProgram()
+	program, err := ca.createBubbleTeaProgram(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create application interface: %w", err)
 	}
@@ -245,25 +406,76 @@ func (ca *ConsoleApp) Run() error {
 	ca.deps.Logger.Info("Starting TUI application")
 
 	_, err = program.Run()
+	if err != nil && ctx.Err() != nil && errors.Is(err, tea.ErrProgramKilled) {
+		ca.deps.Logger.Info("Received termination signal, shutting down")
+		return nil
+	}
 	return err
 }
 
+// Shutdown disconnects from the application and stops health monitoring. It is safe to call
+// whether or not a connection or monitoring is active.
+func (ca *ConsoleApp) Shutdown() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if ca.deps.RegistryManager != nil {
+			// An error here only means monitoring was never started
+			ca.deps.RegistryManager.StopHealthMonitoring()
+		}
+
+		if ca.deps.ProtocolClient != nil {
+			if err := ca.deps.ProtocolClient.Disconnect(); err != nil {
+				ca.deps.Logger.Warn("Disconnect during shutdown failed", "error", err.Error())
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		ca.deps.Logger.Debug("Shutdown cleanup completed")
+	case <-time.After(ShutdownTimeout):
+		ca.deps.Logger.Warn("Shutdown cleanup timed out", "timeout", ShutdownTimeout)
+	}
+}
+
+// usePlainOutput reports whether to run without the alternate screen and mouse capture, either
+// because --plain was given or because stdout is not a terminal
+func (ca *ConsoleApp) usePlainOutput() bool {
+	return ca.args.Plain || !stdoutIsTerminal()
+}
+
+// stdoutIsTerminal reports whether standard output is an interactive terminal
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(os.Stdout.Fd())
+}
+
 // shouldLaunchDirectConnection determines if the application should connect directly
 // to an application instead of showing the Console Menu
 func (ca *ConsoleApp) shouldLaunchDirectConnection() bool {
-	return ca.args.Host != "" || ca.args.Profile != ""
+	return ca.args.Host != "" || ca.args.Profile != "" || ca.args.Demo
 }
 
 // createBubbleTeaProgram instantiates the appropriate Bubble Tea model based on mode
-func (ca *ConsoleApp) createBubbleTeaProgram() (*tea.Program, error) {
-	// Configure program options for Claude Code-like experience
+func (ca *ConsoleApp) createBubbleTeaProgram(ctx context.Context) (*tea.Program, error) {
 	programOptions := []tea.ProgramOption{
-		tea.WithAltScreen(),       // Full-screen alternate buffer like Claude Code
-		tea.WithMouseCellMotion(), // Enable mouse support
+		tea.WithContext(ctx),       // Exit when the root context is cancelled
+		tea.WithoutSignalHandler(), // Signals are handled by main, which also disconnects
+	}
+
+	// Configure program options for Claude Code-like experience, unless plain output is wanted
+	if ca.usePlainOutput() {
+		ca.deps.Logger.Info("Using plain output", "requested", ca.args.Plain, "stdout_terminal", stdoutIsTerminal())
+	} else {
+		programOptions = append(programOptions,
+			tea.WithAltScreen(),       // Full-screen alternate buffer like Claude Code
+			tea.WithMouseCellMotion(), // Enable mouse support
+		)
 	}
 
 	if ca.shouldLaunchDirectConnection() {
-		model, err := ca.createDirectConnectionModel()
+		model, err := ca.createDirectConnectionModel(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -275,14 +487,25 @@ func (ca *ConsoleApp) createBubbleTeaProgram() (*tea.Program, error) {
 }
 
 // createDirectConnectionModel creates the Application Mode model for direct connections
-func (ca *ConsoleApp) createDirectConnectionModel() (tea.Model, error) {
+func (ca *ConsoleApp) createDirectConnectionModel(ctx context.Context) (tea.Model, error) {
 	profile, err := ca.determineProfile()
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine connection profile: %w", err)
 	}
 
-	// Attempt immediate connection
-	_, err = ca.deps.ProtocolClient.Connect(context.Background(), profile.Host, &profile.Auth)
+	ca.deps.ProtocolClient.SetRateLimit(profile.RateLimit, profile.RateBurst)
+	ca.deps.ProtocolClient.SetCircuitBreaker(profile.CircuitThreshold, time.Duration(profile.CircuitCooldown)*time.Second)
+	ca.deps.ProtocolClient.SetIdlePolicy(time.Duration(profile.KeepAlive)*time.Second, time.Duration(profile.IdleTimeout)*time.Minute)
+	ca.deps.ProtocolClient.SetConnectionPool(profile.PoolSize, profile.MaxConnections, profile.DisableHTTP2)
+
+	// Attempt immediate connection, retrying while the application may still be starting
+	policy := protocol.DefaultConnectPolicy
+	policy.Attempts = ca.args.ConnectAttempts
+	_, err = protocol.ConnectWithRetry(ctx, ca.deps.ProtocolClient, profile.Host, &profile.Auth, policy,
+		func(attempt, attempts int, err error, wait time.Duration) {
+			ca.deps.Logger.Info("Connection attempt failed, retrying", "attempt", attempt, "attempts", attempts, "wait", wait, "error", err.Error())
+			fmt.Fprintf(os.Stderr, "Connecting to %s… attempt %d/%d (retrying in %v)\n", profile.Host, attempt+1, attempts, wait)
+		})
 	if err != nil {
 		// Log the error but continue, the app model will handle showing the error
 		ca.deps.Logger.Warn("Direct connection failed, will show error in UI", "error", err.Error())
@@ -295,6 +518,7 @@ func (ca *ConsoleApp) createDirectConnectionModel() (tea.Model, error) {
 		ca.deps.ContentRenderer,
 		ca.deps.ConfigManager,
 		ca.deps.AuthManager,
+		ca.deps.RegistryManager,
 	)
 
 	return model, nil
@@ -318,6 +542,13 @@ func (ca *ConsoleApp) determineProfile() (*interfaces.Profile, error) {
 		return ca.createTemporaryProfile(), nil
 	}
 
+	if ca.args.Demo {
+		profile := ca.createTemporaryProfile()
+		profile.Name = "demo"
+		profile.Host = protocol.DemoHost
+		return profile, nil
+	}
+
 	// Use specified profile or default to "default"
 	profileName := ca.args.Profile
 	if profileName == "" {
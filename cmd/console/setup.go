@@ -0,0 +1,69 @@
+// Package main implements the setup command.
+// This file handles --setup, which runs the connection profile wizard instead of the console, so that a first
+// profile can be created without editing the configuration file. --host and --theme, when given, become the
+// wizard's default answers.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/logging"
+	"github.com/universal-console/console/internal/ui/setup"
+)
+
+// isSetupRequested reports whether the profile wizard was requested
+func isSetupRequested(args CommandLineArgs) bool {
+	return args.Setup
+}
+
+// initializeSetupLogging writes logs to --log-file when given and discards them otherwise, since log lines
+// written to the terminal would break up the wizard, which reports connection problems itself
+func initializeSetupLogging(args CommandLineArgs) error {
+	logConfig := logging.DefaultConfig()
+	logConfig.Output = "none"
+	if args.LogFile != "" {
+		logConfig.Output = args.LogFile
+		logConfig.MaxSizeMB = args.LogMaxSizeMB
+		logConfig.MaxAgeDays = args.LogMaxAgeDays
+	}
+	if os.Getenv("CONSOLE_DEBUG") == "true" {
+		logConfig.Level = logging.DebugLevel
+	}
+	return logging.InitGlobalLogger(logConfig)
+}
+
+// runSetup runs the profile wizard and returns the process exit code: 0 when a profile was saved or the
+// wizard was cancelled, and 2 when the console could not be set up
+func runSetup(args CommandLineArgs) int {
+	if err := initializeSetupLogging(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
+		return validationExitFailure
+	}
+
+	deps, err := initializeDependencies(logging.GetGlobalLogger())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing application: %v\n", err)
+		return validationExitFailure
+	}
+
+	wizard := setup.New(deps.ConfigManager, deps.ProtocolClient, args.Host, args.Theme)
+	if _, err := tea.NewProgram(wizard).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+
+	profile := wizard.Profile()
+	if profile == nil {
+		fmt.Println("Setup cancelled; no profile was saved.")
+		return validationExitOK
+	}
+
+	if app := wizard.ConnectedApp(); app != "" {
+		fmt.Printf("✓ Connected to %s at %s\n", app, profile.Host)
+	}
+	fmt.Printf("✓ Saved profile '%s' to %s. Connect with --profile %s.\n",
+		profile.Name, deps.ConfigManager.GetConfigPath(), profile.Name)
+	return validationExitOK
+}
@@ -0,0 +1,72 @@
+// Package main implements the spec dump command.
+// This file handles --dump-spec, which performs the handshake with the application named by --host,
+// --profile, or --demo, prints the application's specification as JSON, and exits without launching the
+// TUI. CI jobs use it to check protocol compliance and editor plugins to learn a backend's capabilities.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/universal-console/console/internal/logging"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// isSpecDumpRequested reports whether a spec dump was requested
+func isSpecDumpRequested(args CommandLineArgs) bool {
+	return args.DumpSpec
+}
+
+// runSpecDump connects to the application, prints its specification as JSON to stdout, and returns the
+// process exit code: 0 after a successful handshake, 1 when the handshake fails, and 2 when the console
+// could not be set up
+func runSpecDump(args CommandLineArgs) int {
+	if err := initializeOfflineLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
+		return validationExitFailure
+	}
+
+	if err := validateArguments(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+
+	deps, err := initializeDependencies(logging.GetGlobalLogger())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing application: %v\n", err)
+		return validationExitFailure
+	}
+	if args.Demo {
+		deps.ProtocolClient = protocol.NewDemoClient()
+	}
+
+	consoleApp := &ConsoleApp{deps: deps, args: args}
+	profile, err := consoleApp.determineProfile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+
+	policy := protocol.DefaultConnectPolicy
+	policy.Attempts = args.ConnectAttempts
+	spec, err := protocol.ConnectWithRetry(context.Background(), deps.ProtocolClient, profile.Host, &profile.Auth, policy,
+		func(attempt, attempts int, err error, wait time.Duration) {
+			fmt.Fprintf(os.Stderr, "Connecting to %s… attempt %d/%d (retrying in %v)\n", profile.Host, attempt+1, attempts, wait)
+		})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: handshake with %s failed: %v\n", profile.Host, err)
+		return validationExitInvalid
+	}
+	defer deps.ProtocolClient.Disconnect()
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+	fmt.Println(string(data))
+	return validationExitOK
+}
@@ -0,0 +1,62 @@
+// Package main implements the offline theme import command.
+// This file handles --import-theme, which converts a base16 color scheme into a console theme,
+// reports which base16 slots supplied each theme role, and saves the theme to the configuration file.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/universal-console/console/internal/config"
+)
+
+// isThemeImportRequested reports whether a theme import was requested
+func isThemeImportRequested(args CommandLineArgs) bool {
+	return args.ImportTheme != ""
+}
+
+// runThemeImport imports and saves a base16 scheme, printing the slot mapping, and returns the
+// process exit code
+func runThemeImport(args CommandLineArgs) int {
+	if err := initializeOfflineLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
+		return validationExitFailure
+	}
+
+	data, err := os.ReadFile(args.ImportTheme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+
+	theme, mappings, err := config.ImportBase16Theme(args.ImportTheme, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a usable base16 scheme: %v\n", args.ImportTheme, err)
+		return validationExitInvalid
+	}
+
+	fmt.Printf("Importing base16 scheme %s as theme '%s'\n", args.ImportTheme, theme.Name)
+	for _, mapping := range mappings {
+		source := "chosen by name"
+		if mapping.Slot != "" {
+			source = mapping.Slot
+		}
+		fmt.Printf("  %-10s ← %-15s %s\n", mapping.Role, source, mapping.Value)
+	}
+
+	configManager, err := config.NewManager()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitFailure
+	}
+
+	// SaveTheme validates the theme before writing it
+	if err := configManager.SaveTheme(theme); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return validationExitInvalid
+	}
+
+	fmt.Printf("  ✓ Saved to %s. Use it with --theme %s or /theme %s.\n",
+		configManager.GetConfigPath(), theme.Name, theme.Name)
+	return validationExitOK
+}
@@ -0,0 +1,150 @@
+// Package main implements the offline configuration validation commands.
+// This file handles --validate-profile and --validate-config, which check the
+// configuration file and report problems without launching the TUI.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/universal-console/console/internal/config"
+	"github.com/universal-console/console/internal/logging"
+)
+
+// Exit codes for validation runs
+const (
+	validationExitOK      = 0
+	validationExitInvalid = 1
+	validationExitFailure = 2
+)
+
+// ValidationReport is the result of a validation run, suitable for JSON output
+type ValidationReport struct {
+	ConfigPath string                   `json:"configPath"`
+	Target     string                   `json:"target"` // "config" or "profile:<name>"
+	Valid      bool                     `json:"valid"`
+	Issues     []config.ValidationIssue `json:"issues"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// isValidationRequested reports whether a validation flag was provided
+func isValidationRequested(args CommandLineArgs) bool {
+	return args.ValidateProfile != "" || args.ValidateConfig
+}
+
+// runValidation validates the requested profile or configuration file, prints
+// the results, and returns the process exit code
+func runValidation(args CommandLineArgs) int {
+	if err := initializeOfflineLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
+		return validationExitFailure
+	}
+
+	report := ValidationReport{Target: "config", Issues: []config.ValidationIssue{}}
+	if args.ValidateProfile != "" {
+		report.Target = "profile:" + args.ValidateProfile
+	}
+
+	configManager, err := config.NewManager()
+	if err != nil {
+		report.Error = err.Error()
+		printValidationReport(report, args.JSONOutput)
+		return validationExitFailure
+	}
+	report.ConfigPath = configManager.GetConfigPath()
+
+	var issues []config.ValidationIssue
+	if args.ValidateProfile != "" {
+		issues, err = configManager.ValidateProfileByName(args.ValidateProfile)
+	} else {
+		issues, err = configManager.ValidateConfigFile()
+	}
+	if err != nil {
+		report.Error = err.Error()
+		printValidationReport(report, args.JSONOutput)
+		return validationExitFailure
+	}
+
+	if len(issues) > 0 {
+		report.Issues = issues
+	}
+	report.Valid = countErrors(report.Issues) == 0
+	printValidationReport(report, args.JSONOutput)
+
+	if !report.Valid {
+		return validationExitInvalid
+	}
+	return validationExitOK
+}
+
+// initializeOfflineLogging keeps diagnostic logging on stderr, so that reports written to
+// stdout by offline commands can be piped or parsed
+func initializeOfflineLogging() error {
+	logConfig := logging.DefaultConfig()
+	logConfig.Level = logging.WarnLevel
+	logConfig.Output = "stderr"
+	if os.Getenv("CONSOLE_DEBUG") == "true" {
+		logConfig.Level = logging.DebugLevel
+	}
+	return logging.InitGlobalLogger(logConfig)
+}
+
+// printValidationReport writes the report to stdout as JSON or as a human-readable summary
+func printValidationReport(report ValidationReport, asJSON bool) {
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode validation report: %v\n", err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	subject := "configuration"
+	if report.Target != "config" {
+		subject = fmt.Sprintf("profile '%s'", report.Target[len("profile:"):])
+	}
+
+	if report.ConfigPath != "" {
+		fmt.Printf("Validating %s (%s)\n", subject, report.ConfigPath)
+	} else {
+		fmt.Printf("Validating %s\n", subject)
+	}
+
+	if report.Error != "" {
+		fmt.Printf("  ✗ %s\n", report.Error)
+		fmt.Println("Validation could not be completed.")
+		return
+	}
+
+	for _, issue := range report.Issues {
+		marker := "✗"
+		if issue.IsWarning() {
+			marker = "!"
+		}
+		fmt.Printf("  %s %s\n", marker, issue.String())
+	}
+
+	if report.Valid {
+		if len(report.Issues) > 0 {
+			fmt.Printf("  ✓ No problems found (%d warning(s)).\n", len(report.Issues))
+			return
+		}
+		fmt.Println("  ✓ No problems found.")
+		return
+	}
+	fmt.Printf("Validation failed: %d problem(s) found.\n", countErrors(report.Issues))
+}
+
+// countErrors returns the number of issues that are not warnings
+func countErrors(issues []config.ValidationIssue) int {
+	count := 0
+	for _, issue := range issues {
+		if !issue.IsWarning() {
+			count++
+		}
+	}
+	return count
+}
@@ -74,6 +74,7 @@ func (c *ConsoleController) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
+			c.cancelAppRequests()
 			return c, tea.Quit
 		}
 
@@ -106,6 +107,7 @@ func (c *ConsoleController) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case app.ConnectionStatusMsg:
 		// This message signals a switch from app back to menu.
 		if !msg.Connected {
+			c.cancelAppRequests()
 			c.appModel = nil
 			c.currentView = menuView
 			// Optionally, tell the menu to reload its state
@@ -151,3 +153,10 @@ func (c *ConsoleController) View() string {
 		return "Error: Unknown view state."
 	}
 }
+
+// cancelAppRequests aborts the Application Mode model's in-flight requests, if it is active
+func (c *ConsoleController) cancelAppRequests() {
+	if appModel, ok := c.appModel.(*app.AppModel); ok {
+		appModel.CancelRequests()
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/logging"
 )
 
 // TokenMetadata contains metadata about authentication tokens for management purposes
@@ -115,7 +116,11 @@ func (m *Manager) ValidateToken(token string, tokenType string) error {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	return m.validator.ValidateToken(token, tokenType)
+	// Validation errors can surface in logs and the UI, so never echo the token itself
+	if err := m.validator.ValidateToken(token, tokenType); err != nil {
+		return fmt.Errorf("%s", logging.RedactSecret(err.Error(), token))
+	}
+	return nil
 }
 
 // CreateAuthHeader constructs the appropriate authentication header value
@@ -0,0 +1,158 @@
+// Package config implements base16 color scheme import for the Universal Application Console.
+// This file converts a base16 scheme, in either the classic flat layout (scheme, base00..base0F) or
+// the newer layout with a palette section, into a console theme. Fixed base16 slots are mapped to
+// the theme's status colors, and the syntax highlighting style is chosen to match the scheme.
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/universal-console/console/internal/content"
+	"github.com/universal-console/console/internal/contrast"
+	"github.com/universal-console/console/internal/interfaces"
+	"gopkg.in/yaml.v3"
+)
+
+// base16RoleSlots maps theme roles to the base16 slots that conventionally hold those colors
+var base16RoleSlots = []struct {
+	role string
+	slot string
+}{
+	{"success", "base0B"}, // Green
+	{"error", "base08"},   // Red
+	{"warning", "base0A"}, // Yellow
+	{"info", "base0D"},    // Blue
+}
+
+// base16BackgroundSlot holds the default background, used to choose a light or dark code theme
+const base16BackgroundSlot = "base00"
+
+// Fallback syntax highlighting styles when no style is named after the scheme
+const (
+	base16DarkCodeTheme  = "monokai"
+	base16LightCodeTheme = "github"
+)
+
+var (
+	hexColorPattern  = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+	themeNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Base16Mapping records which base16 slot supplied a theme role
+type Base16Mapping struct {
+	Role  string // Theme field, e.g. "success" or "codeTheme"
+	Slot  string // Base16 slot, e.g. "base0B"; empty when the role was not taken from a slot
+	Value string // Resulting theme value
+}
+
+// ImportBase16Theme converts a base16 scheme file into a theme named after the scheme, or after the
+// file when the scheme has no name. The returned mappings describe where each theme value came from.
+// The theme is not saved; SaveTheme validates and persists it.
+func ImportBase16Theme(path string, data []byte) (*interfaces.Theme, []Base16Mapping, error) {
+	// Values are read as raw text, since unquoted colors such as 000000 would otherwise decode as numbers
+	var document map[string]yaml.Node
+	data = normalizeYAML(data)
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse base16 scheme: %s", describeYAMLError(err, data))
+	}
+
+	slots := base16Scalars(document)
+	if palette, exists := document["palette"]; exists {
+		var entries map[string]yaml.Node
+		if err := palette.Decode(&entries); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse base16 palette: %w", err)
+		}
+		for slot, value := range base16Scalars(entries) {
+			slots[slot] = value
+		}
+	}
+
+	displayName := slots["scheme"]
+	if displayName == "" {
+		displayName = slots["name"]
+	}
+	name := base16ThemeName(displayName)
+	if name == "" {
+		name = base16ThemeName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+
+	theme := &interfaces.Theme{Name: name}
+	roles := map[string]*string{
+		"success": &theme.Success,
+		"error":   &theme.Error,
+		"warning": &theme.Warning,
+		"info":    &theme.Info,
+	}
+
+	var mappings []Base16Mapping
+	for _, mapping := range base16RoleSlots {
+		color, err := base16Color(slots, mapping.slot)
+		if err != nil {
+			return nil, nil, err
+		}
+		*roles[mapping.role] = color
+		mappings = append(mappings, Base16Mapping{Role: mapping.role, Slot: mapping.slot, Value: color})
+	}
+
+	codeTheme, slot := base16CodeTheme(name, slots)
+	theme.CodeTheme = codeTheme
+	mappings = append(mappings, Base16Mapping{Role: "codeTheme", Slot: slot, Value: codeTheme})
+
+	return theme, mappings, nil
+}
+
+// base16Scalars returns the text of the scalar values in a YAML mapping, keyed by lowercase name
+func base16Scalars(entries map[string]yaml.Node) map[string]string {
+	scalars := make(map[string]string)
+	for key, node := range entries {
+		if node.Kind == yaml.ScalarNode {
+			scalars[strings.ToLower(key)] = node.Value
+		}
+	}
+	return scalars
+}
+
+// base16Color reads a slot as a "#rrggbb" color
+func base16Color(slots map[string]string, slot string) (string, error) {
+	value, exists := slots[strings.ToLower(slot)]
+	if !exists {
+		return "", newFieldError(slot, fmt.Sprintf("base16 scheme is missing %s", slot))
+	}
+
+	value = strings.TrimSpace(value)
+	if !hexColorPattern.MatchString(value) {
+		return "", newFieldError(slot, fmt.Sprintf("base16 slot %s is not a hex color: '%s'", slot, value))
+	}
+	return "#" + strings.ToLower(strings.TrimPrefix(value, "#")), nil
+}
+
+// base16CodeTheme picks the syntax highlighting style named after the scheme if there is one, and
+// otherwise a dark or light style depending on the scheme's background. It also returns the slot
+// the choice was based on, if any.
+func base16CodeTheme(name string, slots map[string]string) (string, string) {
+	for _, candidate := range []string{name, "base16-" + name} {
+		if content.HasCodeTheme(candidate) {
+			return candidate, ""
+		}
+	}
+
+	background, err := base16Color(slots, base16BackgroundSlot)
+	if err != nil {
+		return base16DarkCodeTheme, ""
+	}
+
+	onWhite, errWhite := contrast.Ratio(background, "#ffffff")
+	onBlack, errBlack := contrast.Ratio(background, "#000000")
+	if errWhite == nil && errBlack == nil && onBlack > onWhite {
+		return base16LightCodeTheme, base16BackgroundSlot
+	}
+	return base16DarkCodeTheme, base16BackgroundSlot
+}
+
+// base16ThemeName turns a scheme name like "Solarized Dark" into a theme name like "solarized-dark"
+func base16ThemeName(name string) string {
+	return strings.Trim(themeNamePattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
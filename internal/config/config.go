@@ -7,14 +7,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/universal-console/console/internal/content"
 	"github.com/universal-console/console/internal/errors"
 	"github.com/universal-console/console/internal/interfaces"
 	"github.com/universal-console/console/internal/logging"
 	"gopkg.in/yaml.v3"
 )
 
+// builtinThemes lists the themes shipped with the default configuration, which cannot be deleted
+var builtinThemes = map[string]bool{
+	"github":  true,
+	"monokai": true,
+}
+
 // Config represents the complete configuration file structure
 type Config struct {
 	Profiles       map[string]interfaces.Profile `yaml:"profiles"`
@@ -85,22 +93,34 @@ func NewManager() (*Manager, error) {
 	return manager, nil
 }
 
-// getConfigPath determines the OS-appropriate configuration file path
+// getConfigPath determines the OS-appropriate configuration file path: under XDG_CONFIG_HOME when it is
+// set on any platform, and otherwise under the user configuration directory, which is ~/.config on Linux,
+// ~/Library/Application Support on macOS, and %AppData% on Windows. A file already kept in ~/.config/console
+// on macOS or Windows, where earlier versions put it, is used until the new location has one.
 func getConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		return filepath.Join(xdgConfigHome, "console", "profiles.yaml"), nil
+	}
+
+	userConfigDir, err := os.UserConfigDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to determine home directory: %w", err)
+		return "", fmt.Errorf("failed to determine user configuration directory: %w", err)
 	}
+	configPath := filepath.Join(userConfigDir, "console", "profiles.yaml")
 
-	// Use OS-appropriate configuration directory
-	var configDir string
-	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
-		configDir = filepath.Join(xdgConfigHome, "console")
-	} else {
-		configDir = filepath.Join(homeDir, ".config", "console")
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		legacyPath := filepath.Join(homeDir, ".config", "console", "profiles.yaml")
+		if legacyPath != configPath && fileExists(legacyPath) && !fileExists(configPath) {
+			return legacyPath, nil
+		}
 	}
+	return configPath, nil
+}
 
-	return filepath.Join(configDir, "profiles.yaml"), nil
+// fileExists reports whether a file exists at the path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 // ensureConfigDirectory creates the configuration directory with secure permissions
@@ -108,7 +128,7 @@ func (m *Manager) ensureConfigDirectory() error {
 	configDir := filepath.Dir(m.configPath)
 
 	// Create directory with restrictive permissions (readable/writable by owner only)
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+	if err := MakePrivateDir(configDir); err != nil {
 		return fmt.Errorf("failed to create config directory %s: %w", configDir, err)
 	}
 
@@ -145,6 +165,27 @@ func (m *Manager) loadConfig() (*Config, error) {
 		return config, nil
 	}
 
+	config, err := m.readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate configuration
+	if err := m.validateConfig(config); err != nil {
+		m.logger.Error("Configuration validation failed", "error", err.Error())
+		return nil, err
+	}
+
+	m.cachedConfig = config
+	m.logger.Info("Configuration loaded successfully", 
+		"profiles", len(config.Profiles),
+		"themes", len(config.Themes),
+		"apps", len(config.RegisteredApps))
+	return config, nil
+}
+
+// readConfigFile reads, parses, and decrypts the configuration file without validating it
+func (m *Manager) readConfigFile() (*Config, error) {
 	// Read existing configuration file
 	m.logger.Debug("Reading configuration file", "path", m.configPath)
 	data, err := os.ReadFile(m.configPath)
@@ -160,19 +201,20 @@ func (m *Manager) loadConfig() (*Config, error) {
 	}
 
 	m.logger.Debug("Parsing configuration file", "size_bytes", len(data))
+	data = normalizeYAML(data)
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		m.logger.Error("Failed to parse configuration file", "error", err.Error())
 		return nil, errors.NewConfigurationError("config").
-			WithMessage("Failed to parse configuration file").
-			WithUserMessage("Configuration file format is invalid. Please check YAML syntax.").
+			WithMessage(fmt.Sprintf("Failed to parse configuration file: %s", describeYAMLError(err, data))).
+			WithUserMessage(fmt.Sprintf("Configuration file format is invalid: %s", describeYAMLError(err, data))).
 			WithOperation("parse_config_yaml").
 			WithCause(err).
 			WithContext("config_path", m.configPath).
 			Build()
 	}
 
-	// Validate and decrypt sensitive fields in profiles
+	// Decrypt sensitive fields in profiles
 	m.logger.Debug("Processing profiles", "profile_count", len(config.Profiles))
 	for name, profile := range config.Profiles {
 		if profile.Auth.Type == "bearer" && profile.Auth.Token != "" {
@@ -193,17 +235,6 @@ func (m *Manager) loadConfig() (*Config, error) {
 		}
 	}
 
-	// Validate configuration
-	if err := m.validateConfig(&config); err != nil {
-		m.logger.Error("Configuration validation failed", "error", err.Error())
-		return nil, err
-	}
-
-	m.cachedConfig = &config
-	m.logger.Info("Configuration loaded successfully", 
-		"profiles", len(config.Profiles),
-		"themes", len(config.Themes),
-		"apps", len(config.RegisteredApps))
 	return &config, nil
 }
 
@@ -233,7 +264,7 @@ func (m *Manager) saveConfig(config *Config) error {
 	}
 
 	// Write with secure file permissions (readable/writable by owner only)
-	if err := os.WriteFile(m.configPath, data, 0600); err != nil {
+	if err := WritePrivateFile(m.configPath, data); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
 	}
 
@@ -256,18 +287,20 @@ func (m *Manager) createDefaultConfig() *Config {
 		},
 		Themes: map[string]interfaces.Theme{
 			"github": {
-				Name:    "github",
-				Success: "#28a745",
-				Error:   "#dc3545",
-				Warning: "#ffc107",
-				Info:    "#17a2b8",
+				Name:      "github",
+				Success:   "#28a745",
+				Error:     "#dc3545",
+				Warning:   "#ffc107",
+				Info:      "#17a2b8",
+				CodeTheme: "github",
 			},
 			"monokai": {
-				Name:    "monokai",
-				Success: "#a6e22e",
-				Error:   "#f92672",
-				Warning: "#fd971f",
-				Info:    "#66d9ef",
+				Name:      "monokai",
+				Success:   "#a6e22e",
+				Error:     "#f92672",
+				Warning:   "#fd971f",
+				Info:      "#66d9ef",
+				CodeTheme: "monokai",
 			},
 		},
 		RegisteredApps: []interfaces.RegisteredApp{},
@@ -360,6 +393,80 @@ func (m *Manager) LoadTheme(name string) (*interfaces.Theme, error) {
 	return &theme, nil
 }
 
+// SaveTheme persists a theme to the configuration file
+func (m *Manager) SaveTheme(theme *interfaces.Theme) error {
+	if theme == nil {
+		return fmt.Errorf("theme cannot be nil")
+	}
+
+	if err := m.validateTheme(theme.Name, theme); err != nil {
+		return fmt.Errorf("cannot save invalid theme: %w", err)
+	}
+
+	config, err := m.loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Initialize themes map if it doesn't exist
+	if config.Themes == nil {
+		config.Themes = make(map[string]interfaces.Theme)
+	}
+
+	// Add or update the theme
+	config.Themes[theme.Name] = *theme
+
+	if err := m.saveConfig(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	m.cachedConfig = config
+	return nil
+}
+
+// DeleteTheme removes a theme from the configuration
+func (m *Manager) DeleteTheme(name string) error {
+	config, err := m.loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if _, exists := config.Themes[name]; !exists {
+		return fmt.Errorf("theme '%s' does not exist", name)
+	}
+
+	// Prevent deletion of the built-in themes
+	if builtinThemes[name] {
+		return fmt.Errorf("cannot delete the built-in theme '%s'", name)
+	}
+
+	// Prevent deletion of a theme that a profile still references
+	for _, profileName := range sortedKeys(config.Profiles) {
+		if config.Profiles[profileName].Theme == name {
+			return fmt.Errorf("theme '%s' is in use by profile '%s'", name, profileName)
+		}
+	}
+
+	delete(config.Themes, name)
+
+	if err := m.saveConfig(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	m.cachedConfig = config
+	return nil
+}
+
+// ListThemes returns all available theme names in lexical order
+func (m *Manager) ListThemes() ([]string, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return sortedKeys(config.Themes), nil
+}
+
 // GetRegisteredApps returns all registered applications
 func (m *Manager) GetRegisteredApps() ([]interfaces.RegisteredApp, error) {
 	config, err := m.loadConfig()
@@ -404,20 +511,20 @@ func (m *Manager) RegisterApp(app interfaces.RegisteredApp) error {
 // ValidateProfile ensures profile has all required fields
 func (m *Manager) ValidateProfile(profile *interfaces.Profile) error {
 	if profile == nil {
-		return fmt.Errorf("profile cannot be nil")
+		return newFieldError("", "profile cannot be nil")
 	}
 
 	if strings.TrimSpace(profile.Name) == "" {
-		return fmt.Errorf("profile name cannot be empty")
+		return newFieldError("name", "profile name cannot be empty")
 	}
 
 	if strings.TrimSpace(profile.Host) == "" {
-		return fmt.Errorf("profile host cannot be empty")
+		return newFieldError("host", "profile host cannot be empty")
 	}
 
 	// Validate host format (should contain port)
 	if !strings.Contains(profile.Host, ":") {
-		return fmt.Errorf("host must include port (e.g., localhost:8080)")
+		return newFieldError("host", "host must include port (e.g., localhost:8080)")
 	}
 
 	// Validate authentication configuration
@@ -426,14 +533,125 @@ func (m *Manager) ValidateProfile(profile *interfaces.Profile) error {
 		// No additional validation needed
 	case "bearer":
 		if strings.TrimSpace(profile.Auth.Token) == "" {
-			return fmt.Errorf("bearer token cannot be empty when auth type is 'bearer'")
+			return newFieldError("auth.token", "bearer token cannot be empty when auth type is 'bearer'")
 		}
 		// Validate token format
 		if err := m.validateBearerToken(profile.Auth.Token); err != nil {
-			return fmt.Errorf("invalid bearer token: %w", err)
+			return newFieldError("auth.token", fmt.Sprintf("invalid bearer token: %v", err))
 		}
 	default:
-		return fmt.Errorf("unsupported authentication type: %s", profile.Auth.Type)
+		return newFieldError("auth.type", fmt.Sprintf("unsupported authentication type: %s", profile.Auth.Type))
+	}
+
+	if profile.MaxHistory < 0 {
+		return newFieldError("maxHistory", "maximum history size cannot be negative")
+	}
+
+	if profile.RateLimit < 0 {
+		return newFieldError("rateLimit", "rate limit cannot be negative")
+	}
+
+	if profile.RateBurst < 0 {
+		return newFieldError("rateBurst", "rate burst cannot be negative")
+	}
+
+	if profile.ProgressPollRate < 0 {
+		return newFieldError("progressPollRate", "progress poll rate cannot be negative")
+	}
+
+	if profile.CircuitThreshold < 0 {
+		return newFieldError("circuitThreshold", "circuit breaker threshold cannot be negative")
+	}
+
+	if profile.CircuitCooldown < 0 {
+		return newFieldError("circuitCooldown", "circuit breaker cooldown cannot be negative")
+	}
+
+	if profile.KeepAlive < 0 {
+		return newFieldError("keepAlive", "keep-alive interval cannot be negative")
+	}
+
+	if profile.IdleTimeout < 0 {
+		return newFieldError("idleTimeout", "idle timeout cannot be negative")
+	}
+
+	if profile.CommandTimeout < 0 {
+		return newFieldError("commandTimeout", "command timeout cannot be negative")
+	}
+
+	if profile.PoolSize < 0 {
+		return newFieldError("poolSize", "connection pool size cannot be negative")
+	}
+
+	if profile.MaxConnections < 0 {
+		return newFieldError("maxConnections", "connection limit cannot be negative")
+	}
+
+	if profile.MaxInFlight < 0 {
+		return newFieldError("maxInFlight", "in-flight command limit cannot be negative")
+	}
+
+	if profile.ComplexityBudget < 0 {
+		return newFieldError("complexityBudget", "complexity budget cannot be negative")
+	}
+
+	if profile.MaxContentWidth < 0 {
+		return newFieldError("maxContentWidth", "maximum content width cannot be negative")
+	}
+	if profile.MaxContentWidth > 0 && profile.MaxContentWidth < minContentWidth {
+		return newFieldError("maxContentWidth", fmt.Sprintf("maximum content width must be at least %d columns", minContentWidth))
+	}
+
+	if profile.CacheTTL < 0 {
+		return newFieldError("cacheTTL", "cache lifetime cannot be negative")
+	}
+
+	for i, command := range profile.CacheCommands {
+		if strings.TrimSpace(command) == "" {
+			return newFieldError(fmt.Sprintf("cacheCommands[%d]", i), "command cannot be empty")
+		}
+	}
+
+	if profile.CodeLanguage != "" && !content.HasLanguage(profile.CodeLanguage) {
+		return newFieldError("codeLanguage", fmt.Sprintf("unknown code language '%s'", profile.CodeLanguage))
+	}
+
+	labels := []struct{ field, value string }{
+		{"userLabel", profile.UserLabel},
+		{"appLabel", profile.AppLabel},
+		{"userLabelColor", profile.UserLabelColor},
+		{"appLabelColor", profile.AppLabelColor},
+	}
+	for _, label := range labels {
+		if strings.ContainsAny(label.value, "\r\n") {
+			return newFieldError(label.field, "must be a single line")
+		}
+	}
+
+	for _, name := range sortedKeys(profile.Variables) {
+		if err := ValidateVariableName(name); err != nil {
+			return newFieldError("variables."+name, err.Error())
+		}
+	}
+
+	for i, blockType := range profile.HideBlocks {
+		if strings.TrimSpace(blockType) == "" {
+			return newFieldError(fmt.Sprintf("hideBlocks[%d]", i), "block type cannot be empty")
+		}
+	}
+
+	for i, status := range profile.HideStatuses {
+		if !slices.Contains(interfaces.StatusLevels, strings.ToLower(status)) {
+			return newFieldError(fmt.Sprintf("hideStatuses[%d]", i), fmt.Sprintf("unknown status level '%s' (expected one of: %s)",
+				status, strings.Join(interfaces.StatusLevels, ", ")))
+		}
+	}
+
+	for _, actionType := range sortedKeys(profile.Confirm) {
+		if !slices.Contains(interfaces.ActionTypes, actionType) {
+			return newFieldError("confirm."+actionType, fmt.Sprintf("unknown action type '%s' (expected one of: %s)",
+				actionType, strings.Join(interfaces.ActionTypes, ", ")))
+		}
 	}
 
 	return nil
@@ -518,19 +736,36 @@ func (m *Manager) validateConfig(config *Config) error {
 // validateTheme validates a theme configuration
 func (m *Manager) validateTheme(name string, theme *interfaces.Theme) error {
 	if strings.TrimSpace(name) == "" {
-		return fmt.Errorf("theme name cannot be empty")
+		return newFieldError("name", "theme name cannot be empty")
 	}
 	
 	if theme.Name == "" {
 		theme.Name = name // Set name if not provided
 	}
 	
-	// Basic color validation - ensure they're not empty
-	if strings.TrimSpace(theme.Success) == "" ||
-		strings.TrimSpace(theme.Error) == "" ||
-		strings.TrimSpace(theme.Warning) == "" ||
-		strings.TrimSpace(theme.Info) == "" {
-		return fmt.Errorf("theme colors cannot be empty")
+	// Colors must be set and in a form the renderer understands
+	colors := []struct{ field, value string }{
+		{"success", theme.Success},
+		{"error", theme.Error},
+		{"warning", theme.Warning},
+		{"info", theme.Info},
+	}
+	for _, color := range colors {
+		if strings.TrimSpace(color.value) == "" {
+			return newFieldError(color.field, "theme colors cannot be empty")
+		}
+		if _, err := content.ResolveColor(color.value); err != nil {
+			return newFieldError(color.field, fmt.Sprintf("%s color: %v", color.field, err))
+		}
+	}
+	
+	if theme.CodeTheme != "" && !content.HasCodeTheme(theme.CodeTheme) {
+		return newFieldError("codeTheme", fmt.Sprintf("unknown syntax highlighting style '%s'", theme.CodeTheme))
+	}
+	
+	// Contrast problems do not invalidate a theme, but are worth surfacing
+	for _, warning := range themeContrastWarnings(theme) {
+		m.logger.Warn("Low contrast theme color", "theme", name, "field", warning.Field, "warning", warning.Message)
 	}
 	
 	return nil
@@ -539,11 +774,11 @@ func (m *Manager) validateTheme(name string, theme *interfaces.Theme) error {
 // validateRegisteredApp validates a registered application configuration
 func (m *Manager) validateRegisteredApp(app *interfaces.RegisteredApp) error {
 	if strings.TrimSpace(app.Name) == "" {
-		return fmt.Errorf("application name cannot be empty")
+		return newFieldError("name", "application name cannot be empty")
 	}
 	
 	if strings.TrimSpace(app.Profile) == "" {
-		return fmt.Errorf("application profile cannot be empty")
+		return newFieldError("profile", "application profile cannot be empty")
 	}
 	
 	return nil
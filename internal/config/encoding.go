@@ -0,0 +1,70 @@
+// Package config implements encoding tolerance for hand-edited configuration files.
+// Editors on Windows often save YAML with a byte order mark, CRLF line endings, or as UTF-16, which the
+// YAML parser either rejects or reports with a cryptic message. Files are normalized to UTF-8 with LF line
+// endings before they are parsed, and parse errors name the offending line and quote it.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Byte order marks recognized at the start of a configuration file
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// yamlErrorLine matches the line number yaml.v3 puts in its error messages
+var yamlErrorLine = regexp.MustCompile(`line (\d+):`)
+
+// normalizeYAML converts configuration file data to UTF-8 without a byte order mark and with LF line endings
+func normalizeYAML(data []byte) []byte {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		data = data[len(utf8BOM):]
+	case bytes.HasPrefix(data, utf16LEBOM):
+		data = decodeUTF16(data[len(utf16LEBOM):], false)
+	case bytes.HasPrefix(data, utf16BEBOM):
+		data = decodeUTF16(data[len(utf16BEBOM):], true)
+	}
+
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+}
+
+// decodeUTF16 converts UTF-16 text in the given byte order to UTF-8, ignoring a trailing odd byte
+func decodeUTF16(data []byte, bigEndian bool) []byte {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return []byte(string(utf16.Decode(units)))
+}
+
+// describeYAMLError explains a YAML parse error with the line it points at, as
+// "line 4: did not find expected key\n    4 | host localhost:8080", or returns the error message unchanged
+// when it names no line of the data
+func describeYAMLError(err error, data []byte) string {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	match := yamlErrorLine.FindStringSubmatch(message)
+	if match == nil {
+		return message
+	}
+
+	number, _ := strconv.Atoi(match[1])
+	lines := strings.Split(string(data), "\n")
+	if number < 1 || number > len(lines) {
+		return message
+	}
+	return fmt.Sprintf("%s\n%5d | %s", message, number, lines[number-1])
+}
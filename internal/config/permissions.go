@@ -0,0 +1,64 @@
+// Package config implements owner-only storage for configuration and credential files.
+// The configuration file holds encrypted tokens and the security directory holds the key material, so both
+// must be private to the user on every platform, as must the command history and session logs kept beside
+// the configuration. Directories are created and files are written through the helpers here, which restrict
+// access with permission bits on Unix and with an access control list on Windows, where permission bits are
+// ignored. Files are written to a private temporary file first and then renamed into place, so a file is
+// never readable by others, not even briefly, and never left half written; logs that only grow are opened
+// for appending and restricted before anything is written to them.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MakePrivateDir creates a directory, and any missing parents, accessible by the owner only
+func MakePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return restrictToOwner(dir, true)
+}
+
+// WritePrivateFile replaces a file with the given data, accessible by the owner only
+func WritePrivateFile(path string, data []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath) // Fails harmlessly once the file has been renamed into place
+
+	if err := restrictToOwner(tempPath, false); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to restrict access to %s: %w", tempPath, err)
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+// OpenPrivateFile opens a file for appending, creating it if needed, accessible by the owner only
+func OpenPrivateFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := restrictToOwner(path, false); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to restrict access to %s: %w", path, err)
+	}
+	return file, nil
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+// Package config implements owner-only permissions on Unix-like systems, using permission bits.
+package config
+
+import "os"
+
+// restrictToOwner makes a file readable and writable, or a directory usable, by its owner only
+func restrictToOwner(path string, isDir bool) error {
+	if isDir {
+		return os.Chmod(path, 0700)
+	}
+	return os.Chmod(path, 0600)
+}
@@ -0,0 +1,40 @@
+// Package config implements owner-only permissions on Windows, where permission bits are ignored.
+// The file or directory gets a protected access control list granting full control to the current user
+// alone, so it no longer inherits entries that let other users of the machine read it.
+package config
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// restrictToOwner replaces the access control list of a file or directory with one granting access to the
+// current user only; directories pass the entry on to the files created in them
+func restrictToOwner(path string, isDir bool) error {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return fmt.Errorf("failed to identify the current user: %w", err)
+	}
+
+	inheritance := uint32(windows.NO_INHERITANCE)
+	if isDir {
+		inheritance = windows.SUB_CONTAINERS_AND_OBJECTS_INHERIT
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: windows.GENERIC_ALL,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       inheritance,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_USER,
+			TrusteeValue: windows.TrusteeValueFromSID(user.User.Sid),
+		},
+	}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build access control list: %w", err)
+	}
+
+	return windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, acl, nil)
+}
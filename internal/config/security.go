@@ -14,6 +14,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
@@ -68,21 +69,33 @@ func NewSecurityManager() (SecurityManager, error) {
 	return manager, nil
 }
 
-// getSecurityKeyPath determines the OS-appropriate path for storing encryption keys
+// getSecurityKeyPath determines the OS-appropriate path for storing encryption keys: under XDG_DATA_HOME when
+// it is set on any platform, under %LocalAppData% on Windows, and under ~/.local/share elsewhere. A key already
+// kept in ~/.local/share on Windows, where earlier versions put it, is used until the new location has one, so
+// that tokens encrypted with it can still be read.
 func getSecurityKeyPath() (string, error) {
+	if xdgDataHome := os.Getenv("XDG_DATA_HOME"); xdgDataHome != "" {
+		return filepath.Join(xdgDataHome, "console", "security", "master.key"), nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to determine home directory: %w", err)
 	}
-
-	var securityDir string
-	if xdgDataHome := os.Getenv("XDG_DATA_HOME"); xdgDataHome != "" {
-		securityDir = filepath.Join(xdgDataHome, "console", "security")
-	} else {
-		securityDir = filepath.Join(homeDir, ".local", "share", "console", "security")
+	legacyPath := filepath.Join(homeDir, ".local", "share", "console", "security", "master.key")
+	if runtime.GOOS != "windows" {
+		return legacyPath, nil
 	}
 
-	return filepath.Join(securityDir, "master.key"), nil
+	localAppData := os.Getenv("LocalAppData")
+	if localAppData == "" {
+		return "", fmt.Errorf("failed to determine local application data directory: %%LocalAppData%% is not set")
+	}
+	keyPath := filepath.Join(localAppData, "console", "security", "master.key")
+	if fileExists(legacyPath) && !fileExists(keyPath) {
+		return legacyPath, nil
+	}
+	return keyPath, nil
 }
 
 // ensureSecurityDirectory creates the security directory with highly restrictive permissions
@@ -90,7 +103,7 @@ func (s *AESSecurityManager) ensureSecurityDirectory() error {
 	securityDir := filepath.Dir(s.keyPath)
 
 	// Create directory with maximum security permissions (accessible by owner only)
-	if err := os.MkdirAll(securityDir, 0700); err != nil {
+	if err := MakePrivateDir(securityDir); err != nil {
 		return fmt.Errorf("failed to create security directory %s: %w", securityDir, err)
 	}
 
@@ -154,7 +167,7 @@ func (s *AESSecurityManager) GenerateSecureKey() error {
 
 	// Store the salt as hex-encoded key material
 	saltHex := hex.EncodeToString(salt)
-	if err := os.WriteFile(s.keyPath, []byte(saltHex), 0600); err != nil {
+	if err := WritePrivateFile(s.keyPath, []byte(saltHex)); err != nil {
 		return fmt.Errorf("failed to write key material: %w", err)
 	}
 
@@ -0,0 +1,235 @@
+// Package config implements offline validation of the configuration file for the Universal Application Console.
+// This file collects every problem found in profiles, themes, and registered applications so that
+// they can be reported together, rather than stopping at the first invalid entry as loading does.
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/universal-console/console/internal/contrast"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Validation issue severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// minContentWidth is the narrowest maxContentWidth a profile may set, leaving room for wrapped content
+const minContentWidth = 40
+
+// FieldError describes a validation failure tied to a specific configuration field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface for FieldError
+func (fe *FieldError) Error() string {
+	return fe.Message
+}
+
+// newFieldError creates a validation error for the named field
+func newFieldError(field, message string) error {
+	return &FieldError{Field: field, Message: message}
+}
+
+// ValidationIssue represents a single problem found while validating the configuration
+type ValidationIssue struct {
+	Kind     string `json:"kind"` // "profile", "theme", "app", "config"
+	Name     string `json:"name"`
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Message  string `json:"message"`
+}
+
+// IsWarning reports whether the issue is advisory rather than a validation failure
+func (vi ValidationIssue) IsWarning() bool {
+	return vi.Severity == SeverityWarning
+}
+
+// String returns a human-readable description of the issue
+func (vi ValidationIssue) String() string {
+	if vi.Field != "" {
+		return fmt.Sprintf("%s '%s' field '%s': %s", vi.Kind, vi.Name, vi.Field, vi.Message)
+	}
+	return fmt.Sprintf("%s '%s': %s", vi.Kind, vi.Name, vi.Message)
+}
+
+// ValidateConfigFile reads the configuration file and returns every validation issue found.
+// The returned error is non-nil only when the file cannot be read, parsed, or decrypted.
+func (m *Manager) ValidateConfigFile() ([]ValidationIssue, error) {
+	config, err := m.readConfigForValidation()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ValidationIssue
+
+	for _, name := range sortedKeys(config.Profiles) {
+		profile := config.Profiles[name]
+		profile.Name = name
+		issues = append(issues, m.profileIssues(config, &profile)...)
+	}
+
+	for _, name := range sortedKeys(config.Themes) {
+		theme := config.Themes[name]
+		if err := m.validateTheme(name, &theme); err != nil {
+			issues = append(issues, newValidationIssue("theme", name, err))
+			continue
+		}
+		for _, warning := range themeContrastWarnings(&theme) {
+			issues = append(issues, newValidationWarning("theme", name, warning))
+		}
+	}
+
+	for i, app := range config.RegisteredApps {
+		name := app.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if err := m.validateRegisteredApp(&app); err != nil {
+			issues = append(issues, newValidationIssue("app", name, err))
+			continue
+		}
+		if _, exists := config.Profiles[app.Profile]; !exists {
+			issues = append(issues, ValidationIssue{
+				Kind:     "app",
+				Name:     name,
+				Field:    "profile",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("profile '%s' not found", app.Profile),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// ValidateProfileByName validates a single profile and the theme it references.
+// The returned error is non-nil only when the file cannot be read, parsed, or decrypted.
+func (m *Manager) ValidateProfileByName(name string) ([]ValidationIssue, error) {
+	config, err := m.readConfigForValidation()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, exists := config.Profiles[name]
+	if !exists {
+		return []ValidationIssue{{
+			Kind:     "profile",
+			Name:     name,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("profile '%s' not found", name),
+		}}, nil
+	}
+
+	profile.Name = name
+	return m.profileIssues(config, &profile), nil
+}
+
+// profileIssues validates a profile and checks that its theme is defined
+func (m *Manager) profileIssues(config *Config, profile *interfaces.Profile) []ValidationIssue {
+	var issues []ValidationIssue
+
+	if err := m.ValidateProfile(profile); err != nil {
+		issues = append(issues, newValidationIssue("profile", profile.Name, err))
+	}
+
+	if profile.Theme != "" {
+		if _, exists := config.Themes[profile.Theme]; !exists {
+			issues = append(issues, ValidationIssue{
+				Kind:     "profile",
+				Name:     profile.Name,
+				Field:    "theme",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("theme '%s' not found", profile.Theme),
+			})
+		}
+	}
+
+	return issues
+}
+
+// readConfigForValidation loads the configuration from disk, falling back to the
+// defaults that would be written on first use when no file exists yet
+func (m *Manager) readConfigForValidation() (*Config, error) {
+	if _, err := os.Stat(m.configPath); os.IsNotExist(err) {
+		return m.createDefaultConfig(), nil
+	}
+	return m.readConfigFile()
+}
+
+// newValidationIssue converts a validation error into an issue, preserving the field when known
+func newValidationIssue(kind, name string, err error) ValidationIssue {
+	issue := ValidationIssue{Kind: kind, Name: name, Severity: SeverityError, Message: err.Error()}
+	if fieldErr, ok := err.(*FieldError); ok {
+		issue.Field = fieldErr.Field
+	}
+	return issue
+}
+
+// newValidationWarning converts an advisory field problem into a warning issue
+func newValidationWarning(kind, name string, warning *FieldError) ValidationIssue {
+	return ValidationIssue{
+		Kind:     kind,
+		Name:     name,
+		Field:    warning.Field,
+		Severity: SeverityWarning,
+		Message:  warning.Message,
+	}
+}
+
+// themeContrastWarnings checks each status color against a typical dark terminal
+// background and reports those below the minimum readable contrast ratio
+func themeContrastWarnings(theme *interfaces.Theme) []*FieldError {
+	colors := []struct{ field, value string }{
+		{"success", theme.Success},
+		{"error", theme.Error},
+		{"warning", theme.Warning},
+		{"info", theme.Info},
+	}
+
+	var warnings []*FieldError
+	for _, color := range colors {
+		// Named and ANSI colors depend on the terminal palette and cannot be measured
+		ratio, err := contrast.Ratio(color.value, contrast.TypicalDarkBackground)
+		if err != nil {
+			continue
+		}
+		if ratio < contrast.MinimumRatio {
+			warnings = append(warnings, &FieldError{
+				Field: color.field,
+				Message: fmt.Sprintf("contrast ratio %.1f:1 against %s is below the recommended %.1f:1",
+					ratio, contrast.TypicalDarkBackground, contrast.MinimumRatio),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// ValidateVariableName checks that a context variable name can be written as name=value
+func ValidateVariableName(name string) error {
+	if name == "" {
+		return fmt.Errorf("variable name cannot be empty")
+	}
+	if strings.ContainsAny(name, "= \t\r\n") {
+		return fmt.Errorf("variable name '%s' cannot contain '=' or whitespace", name)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in lexical order for stable reporting
+func sortedKeys[V any](items map[string]V) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+// Package content implements action styling for the Universal Application Console.
+// Each action type has a default icon and color, and an application may override either for a single
+// action with the action's "icon" and "color" fields, for example to show a "Delete" action in red even
+// though it is a primary action. Action colors are theme colors: hex, ANSI numbers, or ANSI color names.
+// A color that cannot be resolved, or that is unreadable in high-contrast mode, falls back to the type's.
+// Actions are labeled with the keys that run them, which the actions pane and rendered responses share.
+package content
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/contrast"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// DefaultActionIcon returns the icon shown for an action type when the action has none
+func DefaultActionIcon(actionType string) string {
+	switch actionType {
+	case "confirmation":
+		return "✅"
+	case "cancel":
+		return "❌"
+	case "info":
+		return "📋"
+	case "alternative":
+		return "🔄"
+	default:
+		return "▶️"
+	}
+}
+
+// ActionIcon returns the action's own icon, or its type's default
+func ActionIcon(action interfaces.Action) string {
+	if action.Icon != "" {
+		return action.Icon
+	}
+	return DefaultActionIcon(action.Type)
+}
+
+// ActionColor resolves the action's own color, reporting false when it has none or it cannot be resolved
+func ActionColor(action interfaces.Action) (lipgloss.Color, bool) {
+	if action.Color == "" {
+		return "", false
+	}
+	color, err := ResolveColor(action.Color)
+	if err != nil {
+		return "", false
+	}
+	return lipgloss.Color(color), true
+}
+
+// ReadableActionColor resolves the action's own color like ActionColor, and in high-contrast mode also
+// reports false when the color is too dim to read on a dark background
+func ReadableActionColor(action interfaces.Action, highContrast bool) (lipgloss.Color, bool) {
+	color, ok := ActionColor(action)
+	if !ok || !highContrast {
+		return color, ok
+	}
+	ratio, err := contrast.Ratio(string(color), contrast.TypicalDarkBackground)
+	if err != nil || ratio < contrast.HighRatio {
+		return "", false
+	}
+	return color, true
+}
+
+// shortcutKeys are the keys that run actions, in action order: the digits 1-9 and 0 for the first ten, then
+// the letters that are not already bound while the actions pane has focus (e edits, j and k move)
+var shortcutKeys = []string{
+	"1", "2", "3", "4", "5", "6", "7", "8", "9", "0",
+	"a", "b", "c", "d", "f", "g", "h", "i", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z",
+}
+
+// ShortcutKey returns the key that runs the action at the given index, or "" if it has none
+func ShortcutKey(index int) string {
+	if index < 0 || index >= len(shortcutKeys) {
+		return ""
+	}
+	return shortcutKeys[index]
+}
+
+// ShortcutIndex returns the index of the action that a key runs
+func ShortcutIndex(key string) (int, bool) {
+	for i, shortcut := range shortcutKeys {
+		if shortcut == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// GetActionStyle returns the style of an action: its type's style, in the action's own color when it has one
+func (tm *ThemeManager) GetActionStyle(action interfaces.Action) lipgloss.Style {
+	var style lipgloss.Style
+	switch action.Type {
+	case "confirmation":
+		style = tm.GetConfirmationStyle()
+	case "cancel":
+		style = tm.GetCancelStyle()
+	case "info":
+		style = tm.GetInfoStyle()
+	case "alternative":
+		style = tm.GetAlternativeStyle()
+	default:
+		style = tm.GetPrimaryStyle()
+	}
+
+	if color, ok := ReadableActionColor(action, tm.highContrast); ok {
+		return style.Foreground(color)
+	}
+	return style
+}
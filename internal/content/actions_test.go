@@ -0,0 +1,66 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestReadableActionColorInHighContrast(t *testing.T) {
+	dim := interfaces.Action{Name: "Delete", Color: "#303030"}
+	bright := interfaces.Action{Name: "Delete", Color: "#F38BA8"}
+
+	if color, ok := ReadableActionColor(dim, false); !ok || color != "#303030" {
+		t.Errorf("ReadableActionColor(dim, false) = %q, %v, want the action's color", color, ok)
+	}
+	if color, ok := ReadableActionColor(dim, true); ok {
+		t.Errorf("ReadableActionColor(dim, true) = %q, want the type's color in high contrast", color)
+	}
+	if color, ok := ReadableActionColor(bright, true); !ok || color != "#F38BA8" {
+		t.Errorf("ReadableActionColor(bright, true) = %q, %v, want the action's color", color, ok)
+	}
+	if _, ok := ReadableActionColor(interfaces.Action{Name: "Run"}, true); ok {
+		t.Error("ReadableActionColor reported a color for an action without one")
+	}
+}
+
+func TestRenderActionsLabelsShortcutKeys(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	actions := make([]interfaces.Action, 12)
+	for i := range actions {
+		actions[i] = interfaces.Action{Name: "Action " + ShortcutKey(i), Type: "primary"}
+	}
+	rendered, err := r.RenderActions(actions, nil)
+	if err != nil {
+		t.Fatalf("RenderActions failed: %v", err)
+	}
+
+	text := ansi.Strip(rendered)
+	for _, label := range []string{"[1]", "[9]", "[0]", "[a]", "[b]"} {
+		if !strings.Contains(text, label) {
+			t.Errorf("rendered actions have no %s label:\n%s", label, text)
+		}
+	}
+	if strings.Contains(text, "[10]") || strings.Contains(text, "[11]") {
+		t.Errorf("rendered actions are numbered past 9 instead of labeled with their keys:\n%s", text)
+	}
+}
+
+func TestShortcutIndexMatchesShortcutKey(t *testing.T) {
+	for i := 0; ShortcutKey(i) != ""; i++ {
+		if index, ok := ShortcutIndex(ShortcutKey(i)); !ok || index != i {
+			t.Errorf("ShortcutIndex(%q) = %d, %v, want %d", ShortcutKey(i), index, ok, i)
+		}
+	}
+	for _, key := range []string{"e", "j", "k"} {
+		if _, ok := ShortcutIndex(key); ok {
+			t.Errorf("%q runs an action although the actions pane binds it", key)
+		}
+	}
+}
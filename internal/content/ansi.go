@@ -0,0 +1,82 @@
+// Package content implements ANSI passthrough rendering for the Universal Application Console.
+// This file renders "ansi" content blocks, which carry text the application has already colored,
+// such as forwarded program output. Escape sequences are kept as-is, and lines are wrapped to
+// the content width by their visible width only. Sequences are removed when the block asks for
+// it or when the terminal has no color support.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// defaultContentWidth is the wrapping width used until the application reports its layout
+const defaultContentWidth = 80
+
+// ansiTabWidth is the number of spaces a tab expands to, since tabs have no fixed visible width
+const ansiTabWidth = 4
+
+// ansiReset clears all graphic attributes so colors cannot bleed into the lines that follow
+const ansiReset = "\x1b[0m"
+
+// SetContentWidth sets the column width that pre-formatted content is wrapped to
+func (r *Renderer) SetContentWidth(width int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.contentWidth = width
+}
+
+// getContentWidth returns the wrapping width, falling back to the default before a layout is known.
+// It is called while rendering, so the caller must hold the lock.
+func (r *Renderer) getContentWidth() int {
+	if r.contentWidth <= 0 {
+		return defaultContentWidth
+	}
+	return r.contentWidth
+}
+
+// renderAnsiContent handles pre-formatted text whose ANSI escape sequences are passed through
+func (r *Renderer) renderAnsiContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	var ansiContent AnsiContent
+
+	if text, ok := block.Content.(string); ok {
+		ansiContent.Text = text
+	} else if err := r.parseBlockContent(block.Content, &ansiContent); err != nil {
+		return nil, fmt.Errorf("failed to parse ANSI content: %w", err)
+	}
+
+	strip := ansiContent.Strip || lipgloss.ColorProfile() == termenv.Ascii
+
+	content := interfaces.RenderedContent{
+		Text:      formatAnsiText(ansiContent.Text, r.getContentWidth(), strip),
+		Focusable: false,
+	}
+
+	return []interfaces.RenderedContent{content}, nil
+}
+
+// formatAnsiText normalizes line endings and tabs, optionally strips escape sequences, and wraps
+// each line to width measured in visible cells
+func formatAnsiText(text string, width int, strip bool) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "")
+	text = strings.ReplaceAll(text, "\t", strings.Repeat(" ", ansiTabWidth))
+	text = strings.TrimRight(text, "\n")
+
+	if strip {
+		text = ansi.Strip(text)
+	}
+
+	text = ansi.Wrap(text, width, "")
+
+	if !strip && strings.Contains(text, "\x1b[") {
+		text += ansiReset
+	}
+	return text
+}
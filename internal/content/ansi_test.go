@@ -0,0 +1,63 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFormatAnsiTextKeepsColors(t *testing.T) {
+	text := "\x1b[32mPASS\x1b[0m build\n\x1b[31mFAIL\x1b[0m lint"
+
+	got := formatAnsiText(text, 80, false)
+	if !strings.Contains(got, "\x1b[32mPASS") || !strings.Contains(got, "\x1b[31mFAIL") {
+		t.Errorf("formatAnsiText dropped the colors: %q", got)
+	}
+	if !strings.HasSuffix(got, ansiReset) {
+		t.Errorf("formatAnsiText(%q) does not end with a reset: %q", text, got)
+	}
+}
+
+func TestFormatAnsiTextWrapsByVisibleWidth(t *testing.T) {
+	// Twenty visible cells, each colored, so the text holds far more than twenty bytes
+	var b strings.Builder
+	for i := 0; i < 20; i++ {
+		b.WriteString("\x1b[3" + string(rune('1'+i%6)) + "mx\x1b[0m")
+	}
+
+	got := formatAnsiText(b.String(), 10, false)
+	lines := strings.Split(strings.TrimSuffix(got, ansiReset), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("twenty colored cells at width 10 wrapped to %d lines, want 2: %q", len(lines), got)
+	}
+	for i, line := range lines {
+		if width := ansi.StringWidth(line); width != 10 {
+			t.Errorf("line %d is %d cells wide, want 10: %q", i+1, width, line)
+		}
+	}
+}
+
+func TestFormatAnsiTextStripsWhenAsked(t *testing.T) {
+	got := formatAnsiText("\x1b[1;33mwarning\x1b[0m:\tdisk\r\n", 80, true)
+	if want := "warning:" + strings.Repeat(" ", ansiTabWidth) + "disk"; got != want {
+		t.Errorf("formatAnsiText with strip = %q, want %q", got, want)
+	}
+}
+
+func TestAnsiBlockRendersColoredInput(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	rendered, err := r.RenderContent([]interface{}{
+		map[string]interface{}{"type": "ansi", "content": map[string]interface{}{"text": "\x1b[36mready\x1b[0m", "strip": true}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	if len(rendered) != 1 || rendered[0].Text != "ready" {
+		t.Errorf("rendered ansi block = %+v, want the stripped text", rendered)
+	}
+}
@@ -0,0 +1,141 @@
+// Package content implements the response complexity budget for the Universal Application Console.
+// A pathological response, such as a table with a hundred thousand rows or deeply nested structured data,
+// can take long enough to render and lay out that the interface appears frozen. Each content block is scored
+// before rendering: one point for the block, one per table cell, list item, and line of text, and for nested
+// structured content each element costs its nesting depth. When a profile sets a budget and a response scores
+// above it, blocks are rendered in order until the budget is spent, the block that crosses it is cut short
+// where possible, and a notice marked Truncated takes the place of the rest. RenderFullContent ignores the budget.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// truncatedNoticeStyle keeps the truncation notice as subtle as the other placeholder notices
+var truncatedNoticeStyle = lipgloss.NewStyle().Faint(true).Italic(true)
+
+// blockComplexity scores how expensive a content block is to render and display
+func blockComplexity(block interfaces.ContentBlock) int {
+	score := 1 + len(block.Items)
+	for _, row := range block.Rows {
+		score += len(row)
+	}
+	return score + valueComplexity(block.Content, 1)
+}
+
+// valueComplexity scores structured content: one per line of text, and the nesting depth of every
+// array and object along with the values they contain
+func valueComplexity(value interface{}, depth int) int {
+	switch typed := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return strings.Count(typed, "\n") + 1
+	case []interface{}:
+		score := depth
+		for _, element := range typed {
+			score += valueComplexity(element, depth+1)
+		}
+		return score
+	case map[string]interface{}:
+		score := depth
+		for _, element := range typed {
+			score += valueComplexity(element, depth+1)
+		}
+		return score
+	default:
+		return 1
+	}
+}
+
+// contentComplexity scores a whole response
+func contentComplexity(blocks []interfaces.ContentBlock) int {
+	score := 0
+	for _, block := range blocks {
+		score += blockComplexity(block)
+	}
+	return score
+}
+
+// applyBudget returns the blocks that fit within the budget, cutting short the block that crosses it when
+// its text, rows, or items can be shortened, and reports whether anything was left out
+func applyBudget(blocks []interfaces.ContentBlock, budget int) ([]interfaces.ContentBlock, bool) {
+	remaining := budget
+	for i, block := range blocks {
+		score := blockComplexity(block)
+		if score <= remaining {
+			remaining -= score
+			continue
+		}
+
+		kept := blocks[:i:i]
+		if shortened, ok := shortenBlock(block, remaining); ok {
+			kept = append(kept, shortened)
+		}
+		return kept, true
+	}
+	return blocks, false
+}
+
+// shortenBlock cuts a block's text lines, table rows, or list items down to fit the remaining budget.
+// The original block is left unchanged.
+func shortenBlock(block interfaces.ContentBlock, remaining int) (interfaces.ContentBlock, bool) {
+	room := remaining - 1 // The block itself
+	if room <= 0 {
+		return block, false
+	}
+
+	switch {
+	case len(block.Rows) > 0:
+		rows := 0
+		for rows < len(block.Rows) && len(block.Rows[rows]) <= room {
+			room -= len(block.Rows[rows])
+			rows++
+		}
+		if rows == 0 {
+			return block, false
+		}
+		block.Rows = block.Rows[:rows]
+		return block, true
+
+	case len(block.Items) > 0:
+		if room > len(block.Items) {
+			room = len(block.Items)
+		}
+		block.Items = block.Items[:room]
+		return block, true
+	}
+
+	if text, ok := block.Content.(string); ok {
+		lines := strings.Split(text, "\n")
+		if room < len(lines) {
+			lines = lines[:room]
+		}
+		block.Content = strings.Join(lines, "\n")
+		return block, true
+	}
+	return block, false
+}
+
+// truncatedNotice renders the line that replaces content left out by the budget
+func truncatedNotice(score, budget int) interfaces.RenderedContent {
+	return interfaces.RenderedContent{
+		Text: truncatedNoticeStyle.Render(fmt.Sprintf(
+			"Response truncated: its complexity of %d exceeds the budget of %d — select \"Expand full (heavy)\" to render all of it",
+			score, budget)),
+		Truncated: true,
+	}
+}
+
+// SetComplexityBudget truncates content rendered afterwards whose complexity score exceeds the budget.
+// Zero renders all content.
+func (r *Renderer) SetComplexityBudget(budget int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.complexityBudget = budget
+}
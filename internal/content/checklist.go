@@ -0,0 +1,165 @@
+// Package content implements checklist blocks for the Universal Application Console.
+// A "checklist" block lists the steps of a multi-step operation in order, each marked done, current,
+// pending, or failed and colored to match, which says more about where an operation stands than a row of
+// dots. A checklist may name a workflow, in which case the step that workflow has reached decides which
+// steps are done, current, and pending, and only failed steps keep their own status. Workflows that label
+// their steps are shown as a checklist in place of the dot breadcrumb.
+package content
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Checklist step statuses
+const (
+	StepDone    = "done"
+	StepCurrent = "current"
+	StepPending = "pending"
+	StepFailed  = "failed"
+)
+
+var (
+	// checklistTitleStyle sets a checklist's title apart from its steps
+	checklistTitleStyle = lipgloss.NewStyle().Bold(true)
+
+	// checklistDimStyle dims pending steps and step details
+	checklistDimStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// parseChecklistContent reads a checklist block's content, accepting a bare array of steps
+func parseChecklistContent(r *Renderer, content interface{}, target *ChecklistContent) error {
+	if steps, ok := content.([]interface{}); ok {
+		content = map[string]interface{}{"steps": steps}
+	}
+	if err := r.parseBlockContent(content, target); err != nil {
+		return fmt.Errorf("failed to parse checklist content: %w", err)
+	}
+	return nil
+}
+
+// workflowStepStatus returns the status of the step at position (from 1) in a workflow at step current
+func workflowStepStatus(position, current int) string {
+	switch {
+	case position < current:
+		return StepDone
+	case position == current:
+		return StepCurrent
+	default:
+		return StepPending
+	}
+}
+
+// syncChecklist sets the statuses of a checklist's steps from the step its workflow has reached, when the
+// checklist names a workflow the renderer has seen
+func (r *Renderer) syncChecklist(checklist *ChecklistContent) {
+	if checklist.Workflow == "" {
+		return
+	}
+	current, tracked := r.workflowSteps[checklist.Workflow]
+	if !tracked {
+		return
+	}
+	for i := range checklist.Steps {
+		if checklist.Steps[i].Status != StepFailed {
+			checklist.Steps[i].Status = workflowStepStatus(i+1, current)
+		}
+	}
+}
+
+// renderChecklistSteps renders one line per step: the step's marker and label in its status color,
+// followed by its detail
+func (r *Renderer) renderChecklistSteps(steps []ChecklistStep) []string {
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		var marker string
+		var style lipgloss.Style
+		switch step.Status {
+		case StepDone:
+			marker, style = r.glyphs.checkDone, r.themeManager.GetStatusStyle("success")
+		case StepCurrent:
+			marker, style = r.glyphs.checkCurrent, r.themeManager.GetStatusStyle("info").Bold(true)
+		case StepFailed:
+			marker, style = r.glyphs.checkFailed, r.themeManager.GetStatusStyle("error")
+		default:
+			marker, style = r.glyphs.checkPending, checklistDimStyle
+		}
+
+		line := style.Render(marker + " " + step.Label)
+		if step.Detail != "" {
+			line += "  " + checklistDimStyle.Render(step.Detail)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderChecklistContent handles the steps of an operation marked with their statuses
+func (r *Renderer) renderChecklistContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	var checklist ChecklistContent
+	if err := parseChecklistContent(r, block.Content, &checklist); err != nil {
+		return nil, err
+	}
+	r.syncChecklist(&checklist)
+
+	var lines []string
+	if checklist.Title != "" {
+		lines = append(lines, checklistTitleStyle.Render(checklist.Title))
+	}
+	lines = append(lines, r.renderChecklistSteps(checklist.Steps)...)
+
+	content := interfaces.RenderedContent{
+		Text:      strings.Join(lines, "\n"),
+		Focusable: false,
+		ID:        generateContentID(),
+	}
+
+	return []interfaces.RenderedContent{content}, nil
+}
+
+// workflowChecklist returns the labeled steps of a workflow with their statuses at the workflow's step
+func workflowChecklist(workflow *interfaces.Workflow) []ChecklistStep {
+	steps := make([]ChecklistStep, len(workflow.Steps))
+	for i, label := range workflow.Steps {
+		steps[i] = ChecklistStep{Label: label, Status: workflowStepStatus(i+1, workflow.Step)}
+	}
+	return steps
+}
+
+// TrackWorkflow records the step a workflow has reached, which checklists naming the workflow show
+func (r *Renderer) TrackWorkflow(workflow *interfaces.Workflow) {
+	if workflow == nil || workflow.ID == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.workflowSteps[workflow.ID] = workflow.Step
+}
+
+// writeHTMLChecklist exports a checklist as an ordered list with a class per step status
+func (r *Renderer) writeHTMLChecklist(b *strings.Builder, checklist *ChecklistContent) {
+	r.syncChecklist(checklist)
+
+	b.WriteString("<div class=\"checklist\">\n")
+	if checklist.Title != "" {
+		fmt.Fprintf(b, "<div class=\"title\">%s</div>\n", html.EscapeString(checklist.Title))
+	}
+	b.WriteString("<ol>\n")
+	for _, step := range checklist.Steps {
+		status := step.Status
+		if status == "" {
+			status = StepPending
+		}
+		fmt.Fprintf(b, "<li class=\"%s\">%s", htmlClasses("step", status), html.EscapeString(step.Label))
+		if step.Detail != "" {
+			fmt.Fprintf(b, " <span class=\"detail\">%s</span>", html.EscapeString(step.Detail))
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ol>\n</div>\n")
+}
@@ -0,0 +1,47 @@
+// Package content implements theme color parsing for the Universal Application Console.
+// A theme color may be a hex color ("#rgb" or "#rrggbb"), an ANSI color number from 0 to 255, or the name of
+// one of the 16 ANSI colors, such as "red" or "bright-blue". Names are resolved to their ANSI numbers before
+// they reach lipgloss, which would otherwise render them as no color at all, so that a theme mistake is
+// caught when the theme is loaded rather than showing up as invisible text.
+package content
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/universal-console/console/internal/contrast"
+)
+
+// ansiColorNames maps the 16 ANSI color names, without separators, to their color numbers
+var ansiColorNames = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+	"brightblack": 8, "brightred": 9, "brightgreen": 10, "brightyellow": 11,
+	"brightblue": 12, "brightmagenta": 13, "brightcyan": 14, "brightwhite": 15,
+	"gray": 8, "grey": 8,
+}
+
+// ResolveColor converts a theme color into the form lipgloss understands: hex colors and ANSI numbers as they
+// are, and color names as their ANSI numbers
+func ResolveColor(color string) (string, error) {
+	color = strings.TrimSpace(color)
+	if strings.HasPrefix(color, "#") {
+		if _, _, _, err := contrast.ParseHex(color); err != nil {
+			return "", fmt.Errorf("invalid hex color '%s': use #rgb or #rrggbb", color)
+		}
+		return color, nil
+	}
+
+	if number, err := strconv.Atoi(color); err == nil {
+		if number < 0 || number > 255 {
+			return "", fmt.Errorf("ANSI color number %d is out of range: use 0 to 255", number)
+		}
+		return color, nil
+	}
+
+	name := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(color))
+	if number, ok := ansiColorNames[name]; ok {
+		return strconv.Itoa(number), nil
+	}
+	return "", fmt.Errorf("unknown color '%s': use #rgb, #rrggbb, an ANSI color number, or a name such as red or bright-blue", color)
+}
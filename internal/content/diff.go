@@ -0,0 +1,153 @@
+// Package content implements line diffs of rendered output for the Universal Application Console.
+// Running a read command again, such as "status", can show what changed since its previous output rather
+// than the whole output anew. Both outputs are compared as plain text, line by line, and the new output is
+// shown in full with added lines marked "+" in the success color, removed lines marked "-" in the error
+// color, and unchanged lines dimmed, below a summary of the changes. Lines are matched by their longest
+// common subsequence after trimming the common head and tail; when the remaining middle is too large to
+// compare cheaply, all of it is shown as replaced.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// maxDiffCells bounds the comparison table of a line diff, keeping diffs of huge outputs fast
+const maxDiffCells = 4_000_000
+
+// Line diff types, as used by DiffLine
+const (
+	diffContext = "context"
+	diffAdd     = "add"
+	diffRemove  = "remove"
+)
+
+// unchangedLineStyle dims the lines a diff leaves as they were
+var unchangedLineStyle = lipgloss.NewStyle().Faint(true)
+
+// renderedLines returns the plain text lines of rendered content
+func renderedLines(content []interfaces.RenderedContent) []string {
+	var lines []string
+	for _, item := range content {
+		lines = append(lines, strings.Split(ansi.Strip(item.Text), "\n")...)
+	}
+	return lines
+}
+
+// diffLines compares two sequences of lines and returns the new lines interleaved with the removed ones
+func diffLines(before, after []string) ([]DiffLine, DiffStatistics) {
+	// Trim the common head and tail
+	head := 0
+	for head < len(before) && head < len(after) && before[head] == after[head] {
+		head++
+	}
+	tail := 0
+	for tail < len(before)-head && tail < len(after)-head && before[len(before)-1-tail] == after[len(after)-1-tail] {
+		tail++
+	}
+	oldMiddle, newMiddle := before[head:len(before)-tail], after[head:len(after)-tail]
+
+	lines := make([]DiffLine, 0, len(after)+len(oldMiddle))
+	for _, line := range after[:head] {
+		lines = append(lines, DiffLine{Type: diffContext, Content: line})
+	}
+	lines = append(lines, diffMiddle(oldMiddle, newMiddle)...)
+	for _, line := range after[len(after)-tail:] {
+		lines = append(lines, DiffLine{Type: diffContext, Content: line})
+	}
+
+	var stats DiffStatistics
+	for i := range lines {
+		lines[i].LineNo = i + 1
+		switch lines[i].Type {
+		case diffAdd:
+			stats.Additions++
+		case diffRemove:
+			stats.Deletions++
+		}
+	}
+	stats.Changes = stats.Additions + stats.Deletions
+	return lines, stats
+}
+
+// diffMiddle matches lines by their longest common subsequence, or replaces them all when there are too many
+func diffMiddle(before, after []string) []DiffLine {
+	var lines []DiffLine
+	if len(before)*len(after) > maxDiffCells {
+		for _, line := range before {
+			lines = append(lines, DiffLine{Type: diffRemove, Content: line})
+		}
+		for _, line := range after {
+			lines = append(lines, DiffLine{Type: diffAdd, Content: line})
+		}
+		return lines
+	}
+
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			lines = append(lines, DiffLine{Type: diffContext, Content: after[j]})
+			i++
+			j++
+		case j < len(after) && (i == len(before) || common[i][j+1] >= common[i+1][j]):
+			lines = append(lines, DiffLine{Type: diffAdd, Content: after[j]})
+			j++
+		default:
+			lines = append(lines, DiffLine{Type: diffRemove, Content: before[i]})
+			i++
+		}
+	}
+	return lines
+}
+
+// RenderDiff shows current rendered content as a line diff against previous rendered content
+func (r *Renderer) RenderDiff(previous, current []interfaces.RenderedContent, theme *interfaces.Theme) []interfaces.RenderedContent {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if theme != nil {
+		r.themeManager.SetTheme(theme)
+	}
+
+	lines, stats := diffLines(renderedLines(previous), renderedLines(current))
+
+	summary := "No changes since the previous output"
+	if stats.Changes > 0 {
+		summary = fmt.Sprintf("Changes since the previous output: %d added, %d removed", stats.Additions, stats.Deletions)
+	}
+
+	text := make([]string, 0, len(lines)+1)
+	text = append(text, r.themeManager.GetInfoStyle().Render(summary))
+	for _, line := range lines {
+		switch line.Type {
+		case diffAdd:
+			text = append(text, r.themeManager.GetStatusStyle("success").Render("+ "+line.Content))
+		case diffRemove:
+			text = append(text, r.themeManager.GetStatusStyle("error").Render("- "+line.Content))
+		default:
+			text = append(text, unchangedLineStyle.Render("  "+line.Content))
+		}
+	}
+
+	return []interfaces.RenderedContent{{Text: strings.Join(text, "\n"), ID: generateContentID()}}
+}
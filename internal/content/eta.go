@@ -0,0 +1,116 @@
+// Package content implements the rate and time estimate shown after progress bars for the Universal
+// Application Console. When a progress block sets showETA, its bar is followed by the transfer or processing
+// rate and the estimated time remaining, e.g. "[████░░] 60% · 1.2MB/s · ETA 0:42". The backend's throughput
+// and ETA are used when it supplies them. Otherwise the rate is the work done over the elapsed time, and the
+// ETA is the remaining work over the rate, or the elapsed time scaled by the remaining percentage. Operations
+// of indeterminate length show their rate and elapsed time instead of an estimate.
+package content
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// progressDetails returns the rate and time estimate shown after a progress bar, or "" when the
+// block does not ask for them or none are known
+func (r *Renderer) progressDetails(progress *ProgressContent) string {
+	if !progress.ShowETA {
+		return ""
+	}
+
+	var parts []string
+	if rate := progressRate(&progress.Details); rate != "" {
+		parts = append(parts, rate)
+	}
+
+	switch {
+	case progress.Indeterminate:
+		if elapsed := progress.Details.Elapsed; elapsed > 0 {
+			parts = append(parts, formatClock(elapsed)+" elapsed")
+		}
+	case progress.Progress >= 100:
+		// Finished work needs no estimate
+	default:
+		if eta, known := progressETA(progress); known {
+			parts = append(parts, "ETA "+formatClock(eta))
+		}
+	}
+
+	return strings.Join(parts, r.glyphs.progressDetail)
+}
+
+// progressRate describes how fast work is done: the backend's throughput, its rate, or the work done
+// over the elapsed time, in the block's units
+func progressRate(details *ProgressDetails) string {
+	if details.Throughput != "" {
+		return details.Throughput
+	}
+
+	rate := details.Rate
+	if rate <= 0 && details.Current > 0 && details.Elapsed > 0 {
+		rate = float64(details.Current) / details.Elapsed.Seconds()
+	}
+	if rate <= 0 {
+		return ""
+	}
+
+	switch strings.ToLower(details.Units) {
+	case "bytes", "byte", "b":
+		return formatBytes(rate) + "/s"
+	case "":
+		return fmt.Sprintf("%.1f/s", rate)
+	default:
+		return fmt.Sprintf("%.1f %s/s", rate, details.Units)
+	}
+}
+
+// progressETA estimates the time remaining, reporting false when there is not enough to go on
+func progressETA(progress *ProgressContent) (time.Duration, bool) {
+	details := &progress.Details
+	if details.ETA > 0 {
+		return details.ETA, true
+	}
+
+	// Remaining work at the current rate
+	rate := details.Rate
+	if rate <= 0 && details.Current > 0 && details.Elapsed > 0 {
+		rate = float64(details.Current) / details.Elapsed.Seconds()
+	}
+	if rate > 0 && details.Total > 0 && details.Current <= details.Total {
+		return time.Duration(float64(details.Total-details.Current) / rate * float64(time.Second)), true
+	}
+
+	// Elapsed time scaled by the share of the work still to do
+	if details.Elapsed > 0 && progress.Progress > 0 && progress.Progress < 100 {
+		return details.Elapsed * time.Duration(100-progress.Progress) / time.Duration(progress.Progress), true
+	}
+
+	return 0, false
+}
+
+// formatBytes formats a byte count with decimal units, e.g. 1.2MB
+func formatBytes(bytes float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	unit := 0
+	for bytes >= 1000 && unit < len(units)-1 {
+		bytes /= 1000
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f%s", bytes, units[unit])
+	}
+	return fmt.Sprintf("%.1f%s", bytes, units[unit])
+}
+
+// formatClock formats a duration as m:ss, or h:mm:ss from an hour, rounding up to whole seconds
+func formatClock(duration time.Duration) string {
+	seconds := int64(math.Ceil(duration.Seconds()))
+	hours, minutes := seconds/3600, seconds/60%60
+	seconds %= 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
@@ -0,0 +1,113 @@
+package content
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestProgressDetailsForFiniteWork(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	r.SetUnicodeSupport(true)
+
+	tests := []struct {
+		name     string
+		progress ProgressContent
+		want     string
+	}{
+		{
+			"backend throughput and ETA",
+			ProgressContent{Progress: 60, ShowETA: true, Details: ProgressDetails{Throughput: "1.2MB/s", ETA: 42 * time.Second}},
+			"1.2MB/s · ETA 0:42",
+		},
+		{
+			"remaining bytes at the measured rate",
+			ProgressContent{Progress: 25, ShowETA: true, Details: ProgressDetails{Current: 250_000, Total: 1_000_000, Elapsed: 10 * time.Second, Units: "bytes"}},
+			"25.0KB/s · ETA 0:30",
+		},
+		{
+			"remaining items at the backend rate",
+			ProgressContent{Progress: 50, ShowETA: true, Details: ProgressDetails{Current: 50, Total: 100, Rate: 2, Units: "files"}},
+			"2.0 files/s · ETA 0:25",
+		},
+		{
+			"elapsed time scaled by the remaining percentage",
+			ProgressContent{Progress: 20, ShowETA: true, Details: ProgressDetails{Elapsed: time.Minute}},
+			"ETA 4:00",
+		},
+		{
+			"hours",
+			ProgressContent{Progress: 10, ShowETA: true, Details: ProgressDetails{ETA: 2*time.Hour + 5*time.Minute + 3*time.Second}},
+			"ETA 2:05:03",
+		},
+		{
+			"finished work has no estimate",
+			ProgressContent{Progress: 100, ShowETA: true, Details: ProgressDetails{Rate: 4}},
+			"4.0/s",
+		},
+		{
+			"nothing to estimate from",
+			ProgressContent{Progress: 40, ShowETA: true},
+			"",
+		},
+		{
+			"estimate not asked for",
+			ProgressContent{Progress: 40, Details: ProgressDetails{ETA: time.Minute}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		if got := r.progressDetails(&tt.progress); got != tt.want {
+			t.Errorf("%s: progressDetails = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProgressDetailsForIndeterminateWork(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	r.SetUnicodeSupport(true)
+
+	progress := ProgressContent{Indeterminate: true, ShowETA: true, Details: ProgressDetails{Current: 300, Elapsed: time.Minute + 15*time.Second, Units: "items"}}
+	if got, want := r.progressDetails(&progress), "4.0 items/s · 1:15 elapsed"; got != want {
+		t.Errorf("progressDetails = %q, want %q", got, want)
+	}
+
+	// An indeterminate operation is never given an estimate, even when the backend sends one
+	progress = ProgressContent{Indeterminate: true, ShowETA: true, Details: ProgressDetails{ETA: time.Minute}}
+	if got := r.progressDetails(&progress); strings.Contains(got, "ETA") {
+		t.Errorf("progressDetails = %q, want no ETA for indeterminate work", got)
+	}
+}
+
+func TestProgressBlockShowsETA(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	r.SetUnicodeSupport(false)
+
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "progress",
+			"content": map[string]interface{}{
+				"label": "Upload", "progress": 50, "showETA": true,
+				"details": map[string]interface{}{"current": 500, "total": 1000, "elapsed": int64(5 * time.Second), "units": "bytes"},
+			},
+		},
+	}
+	rendered, err := r.RenderContent(blocks, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	if text := ansi.Strip(rendered[0].Text); !strings.Contains(text, "100B/s | ETA 0:05") {
+		t.Errorf("rendered progress = %q, want its rate and ETA", text)
+	}
+}
@@ -0,0 +1,88 @@
+// Package content implements filtering of content blocks for the Universal Application Console.
+// Some applications attach blocks that users rarely need, such as verbose metadata or informational
+// status lines. A profile can hide blocks by type or by status level; hidden blocks are left out of the
+// rendered content and counted, and a notice in their place says how many were hidden and how to show them.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// hiddenBlocksStyle keeps the notice of hidden blocks as subtle as the empty content placeholder
+var hiddenBlocksStyle = lipgloss.NewStyle().Faint(true).Italic(true)
+
+// blockFilter holds the block types and status levels left out of rendered content
+type blockFilter struct {
+	types    map[string]bool
+	statuses map[string]bool
+}
+
+// newBlockFilter creates a filter hiding the given block types and status levels, ignoring case
+func newBlockFilter(hiddenTypes, hiddenStatuses []string) blockFilter {
+	filter := blockFilter{types: make(map[string]bool), statuses: make(map[string]bool)}
+	for _, blockType := range hiddenTypes {
+		filter.types[strings.ToLower(strings.TrimSpace(blockType))] = true
+	}
+	for _, status := range hiddenStatuses {
+		filter.statuses[strings.ToLower(strings.TrimSpace(status))] = true
+	}
+	return filter
+}
+
+// hides returns why a block is hidden, its type or its status level, or "" when it is shown.
+// The type is checked first, so a hidden "text" block is counted as text whatever its status.
+func (f blockFilter) hides(block interfaces.ContentBlock) string {
+	if blockType := strings.ToLower(block.Type); f.types[blockType] {
+		return blockType
+	}
+	if status := strings.ToLower(block.Status); status != "" && f.statuses[status] {
+		return status
+	}
+	return ""
+}
+
+// hiddenBlockCounts counts hidden blocks by the reason they were hidden, in the order first seen
+type hiddenBlockCounts struct {
+	reasons []string
+	counts  map[string]int
+}
+
+// add counts a block hidden for the given reason
+func (h *hiddenBlockCounts) add(reason string) {
+	if h.counts == nil {
+		h.counts = make(map[string]int)
+	}
+	if h.counts[reason] == 0 {
+		h.reasons = append(h.reasons, reason)
+	}
+	h.counts[reason]++
+}
+
+// notice renders the line that replaces hidden blocks, such as "3 info blocks hidden — /show-all"
+func (h *hiddenBlockCounts) notice() interfaces.RenderedContent {
+	parts := make([]string, 0, len(h.reasons))
+	for _, reason := range h.reasons {
+		count := h.counts[reason]
+		noun := "blocks"
+		if count == 1 {
+			noun = "block"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s %s", count, reason, noun))
+	}
+	return interfaces.RenderedContent{
+		Text: hiddenBlocksStyle.Render(strings.Join(parts, ", ") + " hidden — /show-all"),
+	}
+}
+
+// SetBlockFilter hides content blocks of the given types or status levels from content rendered afterwards.
+// Empty lists show every block.
+func (r *Renderer) SetBlockFilter(hiddenTypes, hiddenStatuses []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.filter = newBlockFilter(hiddenTypes, hiddenStatuses)
+}
@@ -0,0 +1,158 @@
+// Package content implements form content for the Universal Application Console.
+// A "form" block describes text, select, and checkbox fields that the console presents as an
+// interactive form; submitting it sends the field values as the context of the form's action.
+// The renderer checks the field definitions and resolves their initial values, and renders a
+// read-only summary as the content text, which is what exports and non-interactive views show.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Form field types
+const (
+	FormFieldText     = "text"
+	FormFieldSelect   = "select"
+	FormFieldCheckbox = "checkbox"
+)
+
+// defaultSubmitLabel is the submit button label of forms that do not name one
+const defaultSubmitLabel = "Submit"
+
+// renderFormContent handles interactive forms
+func (r *Renderer) renderFormContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	var formContent FormContent
+	if err := r.parseBlockContent(block.Content, &formContent); err != nil {
+		return nil, fmt.Errorf("failed to parse form content: %w", err)
+	}
+	if formContent.Title == "" {
+		formContent.Title = block.Title
+	}
+
+	form, err := buildRenderedForm(&formContent)
+	if err != nil {
+		return nil, err
+	}
+
+	content := interfaces.RenderedContent{
+		Text:      r.formatFormSummary(form),
+		Focusable: true,
+		ID:        generateContentID(),
+		Form:      form,
+	}
+
+	return []interfaces.RenderedContent{content}, nil
+}
+
+// buildRenderedForm checks a form's fields and resolves their labels and initial values
+func buildRenderedForm(formContent *FormContent) (*interfaces.RenderedForm, error) {
+	if formContent.Command == "" {
+		return nil, fmt.Errorf("form has no command to submit to")
+	}
+	if len(formContent.Fields) == 0 {
+		return nil, fmt.Errorf("form has no fields")
+	}
+
+	form := &interfaces.RenderedForm{
+		Title:       formContent.Title,
+		Command:     formContent.Command,
+		SubmitLabel: formContent.SubmitLabel,
+		Fields:      make([]interfaces.RenderedFormField, 0, len(formContent.Fields)),
+	}
+	if form.SubmitLabel == "" {
+		form.SubmitLabel = defaultSubmitLabel
+	}
+
+	names := make(map[string]bool, len(formContent.Fields))
+	for _, field := range formContent.Fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("form field has no name")
+		}
+		if names[field.Name] {
+			return nil, fmt.Errorf("form field '%s' is defined more than once", field.Name)
+		}
+		names[field.Name] = true
+
+		rendered := interfaces.RenderedFormField{
+			Name:        field.Name,
+			Label:       field.Label,
+			Type:        field.Type,
+			Options:     field.Options,
+			Placeholder: field.Placeholder,
+			Required:    field.Required,
+		}
+		if rendered.Label == "" {
+			rendered.Label = field.Name
+		}
+		if rendered.Type == "" {
+			rendered.Type = FormFieldText
+		}
+
+		switch rendered.Type {
+		case FormFieldText:
+			if field.Default != nil {
+				rendered.Value = fmt.Sprintf("%v", field.Default)
+			}
+		case FormFieldSelect:
+			if len(field.Options) == 0 {
+				return nil, fmt.Errorf("select field '%s' has no options", field.Name)
+			}
+			// A default that is not one of the options falls back to the first option
+			rendered.Value = field.Options[0]
+			if field.Default != nil {
+				for _, option := range field.Options {
+					if option == fmt.Sprintf("%v", field.Default) {
+						rendered.Value = option
+					}
+				}
+			}
+		case FormFieldCheckbox:
+			switch value := field.Default.(type) {
+			case bool:
+				rendered.Checked = value
+			case string:
+				rendered.Checked = value == "true"
+			}
+		default:
+			return nil, fmt.Errorf("form field '%s' has unknown type '%s'", field.Name, field.Type)
+		}
+
+		form.Fields = append(form.Fields, rendered)
+	}
+
+	return form, nil
+}
+
+// formatFormSummary renders a form's fields and initial values as read-only text.
+// It is called while rendering, so the caller must hold the lock.
+func (r *Renderer) formatFormSummary(form *interfaces.RenderedForm) string {
+	var lines []string
+	if form.Title != "" {
+		lines = append(lines, r.themeManager.GetCollapsibleHeaderStyle().Render("📝 "+form.Title))
+	}
+
+	for _, field := range form.Fields {
+		label := field.Label
+		if field.Required {
+			label += " *"
+		}
+		switch field.Type {
+		case FormFieldCheckbox:
+			box := "[ ]"
+			if field.Checked {
+				box = "[x]"
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s", box, label))
+		case FormFieldSelect:
+			lines = append(lines, fmt.Sprintf("  %s: %s (%s)", label, field.Value, strings.Join(field.Options, " | ")))
+		default:
+			lines = append(lines, fmt.Sprintf("  %s: %s", label, field.Value))
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("  [ %s ] → %s", form.SubmitLabel, form.Command))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,142 @@
+// Package content implements the drawing characters used by the Universal Application Console renderers.
+// Tables, trees, separators, progress bars, checklists, collapsible indicators, and list markers are drawn with
+// box-drawing and block characters, which legacy terminals show as mojibake. Unicode support is probed
+// from the terminal's locale at startup, and terminals without it get ASCII equivalents of every glyph.
+package content
+
+import (
+	"os"
+	"strings"
+)
+
+// glyphSet holds the characters drawn for content structure
+type glyphSet struct {
+	tableVertical   string   // Column border
+	tableHorizontal string   // Header separator line
+	tableCross      string   // Where the header separator meets a column border
+	tableLeftJoin   string   // Header separator at the left border
+	tableRightJoin  string   // Header separator at the right border
+	treeBranch      string   // Connector to a child with later siblings
+	treeLast        string   // Connector to the last child
+	treeContinue    string   // Indent below a child with later siblings
+	collapsed       string   // Indicator of a collapsed section or tree node
+	expanded        string   // Indicator of an expanded section or tree node
+	selected        string   // Mark of a selected tree node
+	bullets         []string // Unordered list markers by nesting level
+	progressFilled  string   // Completed part of a progress bar
+	progressEmpty   string   // Remaining part of a progress bar
+	progressDetail  string   // Between the rate and time estimate that follow a progress bar
+	separatorLine   string   // Default "line" separator
+	separatorDots   string   // Default "dots" separator
+	stepDone        string   // Completed workflow step
+	stepCurrent     string   // Current workflow step
+	stepPending     string   // Upcoming workflow step
+	stepLink        string   // Joins workflow steps
+	checkDone       string   // Completed checklist step
+	checkCurrent    string   // Checklist step in progress
+	checkPending    string   // Upcoming checklist step
+	checkFailed     string   // Failed checklist step
+	lineNumberBar   string   // Between a code line number and the code
+	ellipsis        string   // Marks content that continues
+}
+
+// unicodeGlyphs draws with box-drawing and block characters
+var unicodeGlyphs = glyphSet{
+	tableVertical:   "│",
+	tableHorizontal: "─",
+	tableCross:      "┼",
+	tableLeftJoin:   "├",
+	tableRightJoin:  "┤",
+	treeBranch:      "├── ",
+	treeLast:        "└── ",
+	treeContinue:    "│   ",
+	collapsed:       "▶",
+	expanded:        "▼",
+	selected:        "✓",
+	bullets:         []string{"•", "◦", "▪", "▫"},
+	progressFilled:  "█",
+	progressEmpty:   "░",
+	progressDetail:  " · ",
+	separatorLine:   "─",
+	separatorDots:   "·",
+	stepDone:        "●",
+	stepCurrent:     "◉",
+	stepPending:     "○",
+	stepLink:        "─",
+	checkDone:       "✓",
+	checkCurrent:    "▶",
+	checkPending:    "○",
+	checkFailed:     "✗",
+	lineNumberBar:   "│",
+	ellipsis:        "…",
+}
+
+// asciiGlyphs draws with printable ASCII only, for terminals without Unicode support
+var asciiGlyphs = glyphSet{
+	tableVertical:   "|",
+	tableHorizontal: "-",
+	tableCross:      "+",
+	tableLeftJoin:   "+",
+	tableRightJoin:  "+",
+	treeBranch:      "+-- ",
+	treeLast:        "`-- ",
+	treeContinue:    "|   ",
+	collapsed:       ">",
+	expanded:        "v",
+	selected:        "*",
+	bullets:         []string{"*", "-", "+", "o"},
+	progressFilled:  "#",
+	progressEmpty:   "-",
+	progressDetail:  " | ",
+	separatorLine:   "-",
+	separatorDots:   ".",
+	stepDone:        "*",
+	stepCurrent:     "@",
+	stepPending:     "o",
+	stepLink:        "-",
+	checkDone:       "[x]",
+	checkCurrent:    "[>]",
+	checkPending:    "[ ]",
+	checkFailed:     "[!]",
+	lineNumberBar:   "|",
+	ellipsis:        "...",
+}
+
+// legacyTerminals are TERM values of terminals that cannot display Unicode
+var legacyTerminals = []string{"dumb", "vt52", "vt100", "vt102", "vt220", "ansi", "cons25", "linux"}
+
+// DetectUnicodeSupport determines whether the terminal can display Unicode from its environment.
+// CONSOLE_UNICODE overrides detection with 1/true or 0/false. Otherwise the first locale variable set
+// (LC_ALL, LC_CTYPE, LANG) decides, with UTF-8 locales supported; without a locale, legacy TERM values
+// such as vt100 and linux are unsupported and everything else is assumed supported.
+func DetectUnicodeSupport() bool {
+	switch strings.ToLower(os.Getenv("CONSOLE_UNICODE")) {
+	case "1", "true", "yes", "on":
+		return true
+	case "0", "false", "no", "off":
+		return false
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+
+	term := os.Getenv("TERM")
+	for _, legacy := range legacyTerminals {
+		if term == legacy {
+			return false
+		}
+	}
+	return true
+}
+
+// glyphsFor returns the glyph set for a terminal with or without Unicode support
+func glyphsFor(unicode bool) *glyphSet {
+	if unicode {
+		return &unicodeGlyphs
+	}
+	return &asciiGlyphs
+}
@@ -0,0 +1,106 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// glyphBlocks are blocks that draw structure: a bordered table, a tree, a line separator, a progress bar,
+// and a collapsed section
+var glyphBlocks = []interface{}{
+	map[string]interface{}{
+		"type":    "table",
+		"content": map[string]interface{}{"headers": []string{"Name", "State"}, "rows": [][]string{{"api", "up"}}, "borders": true},
+	},
+	map[string]interface{}{
+		"type": "tree",
+		"content": map[string]interface{}{"root": map[string]interface{}{
+			"id": "root", "label": "root", "expanded": true,
+			"children": []map[string]interface{}{
+				{"id": "a", "label": "first", "isLeaf": true},
+				{"id": "b", "label": "second", "isLeaf": true},
+			},
+		}},
+	},
+	map[string]interface{}{"type": "separator", "content": map[string]interface{}{"style": "line", "length": 10}},
+	map[string]interface{}{"type": "progress", "content": map[string]interface{}{"label": "Build", "progress": 50}},
+	map[string]interface{}{
+		"type":    "collapsible",
+		"content": map[string]interface{}{"title": "Details", "collapsed": true, "content": []map[string]interface{}{{"type": "text", "content": "hidden"}}},
+	},
+}
+
+// renderGlyphBlocks renders glyphBlocks with Unicode support on or off and returns the plain text
+func renderGlyphBlocks(t *testing.T, unicode bool) string {
+	t.Helper()
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	r.SetUnicodeSupport(unicode)
+	if r.UnicodeSupport() != unicode {
+		t.Fatalf("UnicodeSupport = %v after SetUnicodeSupport(%v)", r.UnicodeSupport(), unicode)
+	}
+
+	rendered, err := r.RenderContent(glyphBlocks, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	var text strings.Builder
+	for _, block := range rendered {
+		text.WriteString(ansi.Strip(block.Text))
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+func TestUnicodeModeDrawsBoxCharacters(t *testing.T) {
+	text := renderGlyphBlocks(t, true)
+	for _, glyph := range []string{"│", "┼", "├── ", "└── ", "──────────", "█", "░", "▶"} {
+		if !strings.Contains(text, glyph) {
+			t.Errorf("Unicode rendering has no %q:\n%s", glyph, text)
+		}
+	}
+}
+
+func TestASCIIModeDrawsOnlyASCII(t *testing.T) {
+	text := renderGlyphBlocks(t, false)
+	for _, glyph := range []string{"|", "+", "+-- ", "`-- ", "----------", "#", ">"} {
+		if !strings.Contains(text, glyph) {
+			t.Errorf("ASCII rendering has no %q:\n%s", glyph, text)
+		}
+	}
+	for _, r := range text {
+		if r > 0x7e {
+			t.Errorf("ASCII rendering contains %q:\n%s", r, text)
+			break
+		}
+	}
+}
+
+func TestDetectUnicodeSupport(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"override on", map[string]string{"CONSOLE_UNICODE": "1", "LANG": "C"}, true},
+		{"override off", map[string]string{"CONSOLE_UNICODE": "off", "LANG": "en_US.UTF-8"}, false},
+		{"UTF-8 locale", map[string]string{"LANG": "en_US.UTF-8"}, true},
+		{"LC_ALL wins over LANG", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{"legacy terminal", map[string]string{"TERM": "vt100"}, false},
+		{"modern terminal", map[string]string{"TERM": "xterm-256color"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"CONSOLE_UNICODE", "LC_ALL", "LC_CTYPE", "LANG", "TERM"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := DetectUnicodeSupport(); got != tt.want {
+				t.Errorf("DetectUnicodeSupport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
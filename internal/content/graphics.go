@@ -0,0 +1,264 @@
+// Package content implements inline image rendering for the Universal Application Console.
+// This file detects which terminal graphics protocol is available (Kitty, iTerm2, or Sixel) and
+// encodes image content blocks for it, reserving enough text rows for the image so the history
+// pane layout stays aligned. Terminals without graphics support get a text placeholder.
+package content
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // Register GIF decoding
+	_ "image/jpeg" // Register JPEG decoding
+	"image/png"
+	"os"
+	"strings"
+)
+
+// GraphicsProtocol identifies a terminal inline image protocol
+type GraphicsProtocol string
+
+const (
+	GraphicsNone   GraphicsProtocol = "none"
+	GraphicsKitty  GraphicsProtocol = "kitty"
+	GraphicsITerm2 GraphicsProtocol = "iterm2"
+	GraphicsSixel  GraphicsProtocol = "sixel"
+)
+
+// Assumed terminal cell size in pixels and the largest image drawn, in rows, to keep history readable
+const (
+	cellPixelWidth  = 10
+	cellPixelHeight = 20
+	maxImageRows    = 20
+)
+
+// maxImagePixels is the most pixels an image may declare to be decoded. Image data comes from the
+// application, and a small file can declare dimensions whose decoding would take gigabytes of memory.
+const maxImagePixels = 4096 * 4096
+
+// DetectGraphicsProtocol determines the graphics protocol supported by the current terminal from its
+// environment. CONSOLE_GRAPHICS overrides detection with none, kitty, iterm2, or sixel.
+func DetectGraphicsProtocol() GraphicsProtocol {
+	switch override := GraphicsProtocol(strings.ToLower(os.Getenv("CONSOLE_GRAPHICS"))); override {
+	case GraphicsNone, GraphicsKitty, GraphicsITerm2, GraphicsSixel:
+		return override
+	}
+
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || strings.Contains(term, "kitty") || termProgram == "ghostty":
+		return GraphicsKitty
+	case termProgram == "iTerm.app" || termProgram == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return GraphicsITerm2
+	case strings.Contains(term, "sixel") || termProgram == "mlterm" || strings.HasPrefix(term, "foot"):
+		return GraphicsSixel
+	default:
+		return GraphicsNone
+	}
+}
+
+// renderImage encodes an image for the given protocol. It returns the text lines to display, with the
+// escape sequence on the first line and blank lines reserving the rows the image covers.
+func renderImage(img *ImageContent, protocol GraphicsProtocol, maxColumns int) []string {
+	if img.Data == "" {
+		return []string{imagePlaceholder(img)}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(img.Data)
+	if err != nil {
+		return []string{imagePlaceholder(img)}
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return []string{imagePlaceholder(img)}
+	}
+	img.Width, img.Height = config.Width, config.Height
+
+	if protocol == GraphicsNone || int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return []string{imagePlaceholder(img)}
+	}
+
+	columns, rows := imageCells(config.Width, config.Height, maxColumns)
+
+	var sequence string
+	switch protocol {
+	case GraphicsKitty:
+		sequence, err = kittySequence(data, format, columns, rows)
+	case GraphicsITerm2:
+		sequence = iterm2Sequence(data, columns, rows)
+	case GraphicsSixel:
+		sequence, err = sixelSequence(data, columns*cellPixelWidth, rows*cellPixelHeight)
+	}
+	if err != nil {
+		return []string{imagePlaceholder(img)}
+	}
+
+	lines := make([]string, rows)
+	lines[0] = sequence
+	return lines
+}
+
+// imagePlaceholder describes an image that cannot be drawn, e.g. "[image: 640x480] chart of sales"
+func imagePlaceholder(img *ImageContent) string {
+	text := "[image]"
+	if img.Width > 0 && img.Height > 0 {
+		text = fmt.Sprintf("[image: %dx%d]", img.Width, img.Height)
+	}
+	if img.Alt != "" {
+		text += " " + img.Alt
+	}
+	if img.URL != "" {
+		text += " " + img.URL
+	}
+	return text
+}
+
+// imageCells scales an image to fit within maxColumns and maxImageRows, preserving its aspect ratio
+func imageCells(width, height, maxColumns int) (int, int) {
+	if maxColumns <= 0 {
+		maxColumns = 80
+	}
+
+	columns := (width + cellPixelWidth - 1) / cellPixelWidth
+	rows := (height + cellPixelHeight - 1) / cellPixelHeight
+
+	if columns > maxColumns {
+		rows = rows * maxColumns / columns
+		columns = maxColumns
+	}
+	if rows > maxImageRows {
+		columns = columns * maxImageRows / rows
+		rows = maxImageRows
+	}
+
+	if columns < 1 {
+		columns = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return columns, rows
+}
+
+// kittySequence encodes an image with the Kitty graphics protocol, which requires PNG data sent in chunks
+func kittySequence(data []byte, format string, columns, rows int) (string, error) {
+	if format != "png" {
+		decoded, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, decoded); err != nil {
+			return "", err
+		}
+		data = buf.Bytes()
+	}
+
+	const chunkSize = 4096
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var sb strings.Builder
+	for offset := 0; offset < len(encoded); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		more := 0
+		if end < len(encoded) {
+			more = 1
+		}
+
+		if offset == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,q=2,c=%d,r=%d,m=%d;%s\x1b\\", columns, rows, more, encoded[offset:end])
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, encoded[offset:end])
+		}
+	}
+	return sb.String(), nil
+}
+
+// iterm2Sequence encodes an image with the iTerm2 inline image protocol
+func iterm2Sequence(data []byte, columns, rows int) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+		len(data), columns, rows, base64.StdEncoding.EncodeToString(data))
+}
+
+// sixelSequence scales an image to the given pixel size and encodes it as Sixel using a 6x6x6 color cube
+func sixelSequence(data []byte, width, height int) (string, error) {
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	// Scale to fit while preserving the aspect ratio
+	bounds := decoded.Bounds()
+	scale := float64(width) / float64(bounds.Dx())
+	if s := float64(height) / float64(bounds.Dy()); s < scale {
+		scale = s
+	}
+	width = int(float64(bounds.Dx()) * scale)
+	height = int(float64(bounds.Dy()) * scale)
+	if width < 1 || height < 1 {
+		return "", fmt.Errorf("image too small to display")
+	}
+
+	// Quantize each pixel to a palette index, nearest-neighbor sampling the source
+	pixels := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			sx := bounds.Min.X + int(float64(x)/scale)
+			sy := bounds.Min.Y + int(float64(y)/scale)
+			pixels[y*width+x] = sixelPaletteIndex(decoded.At(sx, sy))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\x1bPq")
+	fmt.Fprintf(&sb, "\"1;1;%d;%d", width, height)
+	for i := 0; i < 216; i++ {
+		r, g, b := i/36, (i/6)%6, i%6
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, r*20, g*20, b*20)
+	}
+
+	// Each sixel band covers six pixel rows; draw one pass per color present in the band
+	for top := 0; top < height; top += 6 {
+		used := make(map[int]bool)
+		for y := top; y < top+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				used[pixels[y*width+x]] = true
+			}
+		}
+
+		for colorIndex := range used {
+			fmt.Fprintf(&sb, "#%d", colorIndex)
+			for x := 0; x < width; x++ {
+				bits := 0
+				for bit := 0; bit < 6 && top+bit < height; bit++ {
+					if pixels[(top+bit)*width+x] == colorIndex {
+						bits |= 1 << bit
+					}
+				}
+				sb.WriteByte(byte(63 + bits))
+			}
+			sb.WriteByte('$') // Return to the start of the band for the next color
+		}
+		sb.WriteByte('-') // Advance to the next band
+	}
+
+	sb.WriteString("\x1b\\")
+	return sb.String(), nil
+}
+
+// sixelPaletteIndex maps a color to the nearest entry of the 6x6x6 color cube
+func sixelPaletteIndex(c color.Color) int {
+	r, g, b, _ := c.RGBA()
+	level := func(v uint32) int {
+		return int((v*5 + 0x7fff) / 0xffff)
+	}
+	return level(r)*36 + level(g)*6 + level(b)
+}
@@ -0,0 +1,46 @@
+package content
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// pngDeclaring returns a 1x1 PNG whose header declares the given dimensions instead
+func pngDeclaring(t *testing.T, width, height uint32) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// The IHDR chunk follows the 8-byte signature: length, type, width, height, ..., then its CRC
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestOversizedImagesAreNotDecoded(t *testing.T) {
+	for _, protocol := range []GraphicsProtocol{GraphicsKitty, GraphicsITerm2, GraphicsSixel} {
+		img := &ImageContent{Data: pngDeclaring(t, 100000, 100000), Alt: "bomb"}
+		lines := renderImage(img, protocol, 80)
+		if len(lines) != 1 || lines[0] != "[image: 100000x100000] bomb" {
+			t.Errorf("%s: oversized image rendered %q, want the placeholder", protocol, lines)
+		}
+	}
+}
+
+func TestImagesWithinLimitAreDrawn(t *testing.T) {
+	img := &ImageContent{Data: pngDeclaring(t, 1, 1)}
+	lines := renderImage(img, GraphicsKitty, 80)
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "\x1b_G") {
+		t.Errorf("a 1x1 image rendered %q, want a Kitty graphics sequence", lines)
+	}
+}
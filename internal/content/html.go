@@ -0,0 +1,406 @@
+// Package content implements HTML rendering of structured content for the Universal Application Console.
+// Exported transcripts render each response as an HTML fragment built from the same content blocks as the
+// terminal view: code is highlighted with chroma's HTML formatter using inline styles, collapsible sections
+// and tree branches become <details> elements, and status colors are left to the page's stylesheet through
+// "status-<name>" classes so that the fragment follows the theme of the page it is embedded in. Transcripts
+// are meant to be shared, so links the application supplies are only made live for http and https URLs.
+package content
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// RenderHTML renders structured content as an HTML fragment for exported transcripts
+func (r *Renderer) RenderHTML(content interface{}, theme *interfaces.Theme) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if theme != nil {
+		r.setCodeTheme(theme.CodeTheme)
+	}
+
+	blocks, err := r.parseContentStructure(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse content structure: %w", err)
+	}
+
+	var b strings.Builder
+	if err := r.writeHTMLBlocks(&b, blocks); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeHTMLBlocks renders a sequence of content blocks
+func (r *Renderer) writeHTMLBlocks(b *strings.Builder, blocks []interfaces.ContentBlock) error {
+	for _, block := range blocks {
+		if err := r.writeHTMLBlock(b, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHTMLBlock renders a single content block, falling back to text for unknown types as the terminal renderer does
+func (r *Renderer) writeHTMLBlock(b *strings.Builder, block interfaces.ContentBlock) error {
+	switch block.Type {
+	case "code":
+		var code CodeContent
+		if err := r.parseBlockContent(block.Content, &code); err != nil {
+			return fmt.Errorf("failed to parse code content: %w", err)
+		}
+		return r.writeHTMLCode(b, &code)
+
+	case "table":
+		var table TableContent
+		if err := r.parseBlockContent(block.Content, &table); err != nil {
+			return fmt.Errorf("failed to parse table content: %w", err)
+		}
+		writeHTMLTable(b, &table)
+
+	case "collapsible":
+		var collapsible CollapsibleContent
+		if err := r.parseBlockContent(block.Content, &collapsible); err != nil {
+			return fmt.Errorf("failed to parse collapsible content: %w", err)
+		}
+		open := ""
+		if !collapsible.Collapsed {
+			open = " open"
+		}
+		fmt.Fprintf(b, "<details class=\"collapsible\"%s><summary>%s</summary>\n", open, htmlLabel(collapsible.Icon, collapsible.Title))
+		if err := r.writeHTMLBlocks(b, collapsible.Content); err != nil {
+			return err
+		}
+		b.WriteString("</details>\n")
+
+	case "progress":
+		var progress ProgressContent
+		if err := r.parseBlockContent(block.Content, &progress); err != nil {
+			return fmt.Errorf("failed to parse progress content: %w", err)
+		}
+		r.syncProgress(&progress)
+		writeHTMLProgress(b, &progress)
+
+	case "list":
+		var list ListContent
+		if err := r.parseBlockContent(block.Content, &list); err != nil {
+			return fmt.Errorf("failed to parse list content: %w", err)
+		}
+		writeHTMLList(b, &list, list.Items)
+
+	case "tree":
+		var tree TreeContent
+		if err := r.parseBlockContent(block.Content, &tree); err != nil {
+			return fmt.Errorf("failed to parse tree content: %w", err)
+		}
+		b.WriteString("<ul class=\"tree\">\n")
+		writeHTMLTreeNode(b, &tree.Root)
+		b.WriteString("</ul>\n")
+
+	case "separator":
+		var separator SeparatorContent
+		if err := r.parseBlockContent(block.Content, &separator); err != nil {
+			return fmt.Errorf("failed to parse separator content: %w", err)
+		}
+		if separator.Label != "" {
+			fmt.Fprintf(b, "<div class=\"separator\"><span>%s</span></div>\n", html.EscapeString(separator.Label))
+		} else {
+			b.WriteString("<hr>\n")
+		}
+
+	case "image":
+		var image ImageContent
+		if err := r.parseBlockContent(block.Content, &image); err != nil {
+			return fmt.Errorf("failed to parse image content: %w", err)
+		}
+		writeHTMLImage(b, &image)
+
+	case "ansi":
+		var ansiContent AnsiContent
+		if err := r.parseBlockContent(block.Content, &ansiContent); err != nil {
+			return fmt.Errorf("failed to parse ANSI content: %w", err)
+		}
+		fmt.Fprintf(b, "<pre class=\"ansi\">%s</pre>\n", html.EscapeString(ansi.Strip(ansiContent.Text)))
+
+	case "keyvalue":
+		var keyValue KeyValueContent
+		if err := parseKeyValueContent(r, block.Content, &keyValue); err != nil {
+			return err
+		}
+		writeHTMLKeyValue(b, &keyValue)
+
+	case "reference":
+		if err := r.writeHTMLReference(b, block); err != nil {
+			return err
+		}
+
+	case "checklist":
+		var checklist ChecklistContent
+		if err := parseChecklistContent(r, block.Content, &checklist); err != nil {
+			return err
+		}
+		r.writeHTMLChecklist(b, &checklist)
+
+	case "form":
+		var formContent FormContent
+		if err := r.parseBlockContent(block.Content, &formContent); err != nil {
+			return fmt.Errorf("failed to parse form content: %w", err)
+		}
+		if formContent.Title == "" {
+			formContent.Title = block.Title
+		}
+		form, err := buildRenderedForm(&formContent)
+		if err != nil {
+			return err
+		}
+		writeHTMLForm(b, form)
+
+	default:
+		var text TextContent
+		if str, ok := block.Content.(string); ok {
+			text.Text = str
+		} else if err := r.parseBlockContent(block.Content, &text); err != nil {
+			return fmt.Errorf("failed to parse text content: %w", err)
+		}
+		fmt.Fprintf(b, "<div class=\"%s\">%s</div>\n", htmlClasses("text", text.Status), html.EscapeString(text.Text))
+	}
+
+	return nil
+}
+
+// writeHTMLCode highlights code with chroma's HTML formatter, using the same style as the terminal
+func (r *Renderer) writeHTMLCode(b *strings.Builder, code *CodeContent) error {
+	language := code.Language
+	if language == "" {
+		language = r.preferences.CodeLanguage
+	}
+	lexer, note := lookupLexer(code.Code, language)
+
+	style := styles.Get(code.Theme)
+	if code.Theme == "" || style == styles.Fallback {
+		style = r.syntaxHighlighter.style
+	}
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	options := []chromahtml.Option{chromahtml.WithLineNumbers(code.LineNumbers)}
+	if len(code.Highlight) > 0 {
+		ranges := make([][2]int, len(code.Highlight))
+		for i, highlight := range code.Highlight {
+			ranges[i] = [2]int{highlight.StartLine, highlight.EndLine}
+		}
+		options = append(options, chromahtml.HighlightLines(ranges))
+	}
+
+	iterator, err := lexer.Tokenise(nil, code.Code)
+	if err != nil {
+		return fmt.Errorf("failed to tokenize code: %w", err)
+	}
+
+	b.WriteString("<div class=\"code\">\n")
+	if code.Filename != "" {
+		fmt.Fprintf(b, "<div class=\"filename\">%s</div>\n", html.EscapeString(code.Filename))
+	}
+	if note != "" {
+		fmt.Fprintf(b, "<div class=\"note\">%s</div>\n", html.EscapeString(note))
+	}
+	if err := chromahtml.New(options...).Format(b, style, iterator); err != nil {
+		return fmt.Errorf("failed to highlight code: %w", err)
+	}
+	b.WriteString("</div>\n")
+	return nil
+}
+
+// writeHTMLTable renders a table with its caption, header, and footer
+func writeHTMLTable(b *strings.Builder, table *TableContent) {
+	b.WriteString("<table>\n")
+	if table.Caption != "" {
+		fmt.Fprintf(b, "<caption>%s</caption>\n", html.EscapeString(table.Caption))
+	}
+
+	writeRow := func(cell string, cells []string) {
+		b.WriteString("<tr>")
+		for i, value := range cells {
+			align := ""
+			if i < len(table.Alignment) && (table.Alignment[i] == "center" || table.Alignment[i] == "right") {
+				align = fmt.Sprintf(" style=\"text-align: %s\"", table.Alignment[i])
+			}
+			fmt.Fprintf(b, "<%s%s>%s</%s>", cell, align, html.EscapeString(value), cell)
+		}
+		b.WriteString("</tr>\n")
+	}
+
+	if len(table.Headers) > 0 {
+		b.WriteString("<thead>")
+		writeRow("th", table.Headers)
+		b.WriteString("</thead>\n")
+	}
+	b.WriteString("<tbody>\n")
+	for _, row := range table.Rows {
+		writeRow("td", row)
+	}
+	b.WriteString("</tbody>\n")
+	if len(table.Footer) > 0 {
+		b.WriteString("<tfoot>")
+		writeRow("td", table.Footer)
+		b.WriteString("</tfoot>\n")
+	}
+	b.WriteString("</table>\n")
+}
+
+// writeHTMLProgress renders a progress indicator with its label and message
+func writeHTMLProgress(b *strings.Builder, progress *ProgressContent) {
+	fmt.Fprintf(b, "<div class=\"%s\">", htmlClasses("progress", progress.Status))
+	if progress.Label != "" {
+		fmt.Fprintf(b, "<span class=\"label\">%s</span> ", html.EscapeString(progress.Label))
+	}
+	if progress.Indeterminate {
+		b.WriteString("<progress></progress>")
+	} else {
+		fmt.Fprintf(b, "<progress max=\"100\" value=\"%d\"></progress> %d%%", progress.Progress, progress.Progress)
+	}
+	if progress.Message != "" {
+		fmt.Fprintf(b, " <span class=\"message\">%s</span>", html.EscapeString(progress.Message))
+	}
+	b.WriteString("</div>\n")
+}
+
+// writeHTMLList renders list items and their children as nested HTML lists
+func writeHTMLList(b *strings.Builder, list *ListContent, items []ListItem) {
+	tag := "ul"
+	attributes := ""
+	if isOrderedList(list) {
+		tag = "ol"
+		switch strings.ToLower(list.Style) {
+		case ListStyleAlpha:
+			attributes = " type=\"a\""
+		case ListStyleRoman:
+			attributes = " type=\"i\""
+		}
+	}
+
+	fmt.Fprintf(b, "<%s%s>\n", tag, attributes)
+	for _, item := range items {
+		fmt.Fprintf(b, "<li class=\"%s\">%s", htmlClasses("item", item.Status), htmlLabel(item.Icon, item.Text))
+		if len(item.Children) > 0 {
+			b.WriteString("\n")
+			writeHTMLList(b, list, item.Children)
+		}
+		b.WriteString("</li>\n")
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+}
+
+// writeHTMLTreeNode renders a tree node, with branches as <details> open as far as the tree is expanded
+func writeHTMLTreeNode(b *strings.Builder, node *TreeNode) {
+	label := htmlLabel(node.Icon, node.Label)
+	if len(node.Children) == 0 {
+		fmt.Fprintf(b, "<li>%s</li>\n", label)
+		return
+	}
+
+	open := ""
+	if node.Expanded {
+		open = " open"
+	}
+	fmt.Fprintf(b, "<li><details%s><summary>%s</summary>\n<ul>\n", open, label)
+	for i := range node.Children {
+		writeHTMLTreeNode(b, &node.Children[i])
+	}
+	b.WriteString("</ul>\n</details></li>\n")
+}
+
+// writeHTMLImage embeds inline image data as a data URI; remote images are linked rather than embedded,
+// and a URL that is not http or https is shown as text
+func writeHTMLImage(b *strings.Builder, image *ImageContent) {
+	alt := html.EscapeString(image.Alt)
+	if image.Data != "" {
+		data, err := base64.StdEncoding.DecodeString(image.Data)
+		if contentType := http.DetectContentType(data); err == nil && strings.HasPrefix(contentType, "image/") {
+			fmt.Fprintf(b, "<img alt=\"%s\" src=\"data:%s;base64,%s\">\n", alt, contentType, image.Data)
+			return
+		}
+	}
+	if image.URL != "" && !isWebURL(image.URL) {
+		fmt.Fprintf(b, "<div class=\"image\">[image: %s]</div>\n", html.EscapeString(strings.TrimSpace(image.Alt+" "+image.URL)))
+		return
+	}
+	if image.URL != "" {
+		if alt == "" {
+			alt = html.EscapeString(image.URL)
+		}
+		fmt.Fprintf(b, "<a class=\"image\" href=\"%s\">%s</a>\n", html.EscapeString(image.URL), alt)
+		return
+	}
+	fmt.Fprintf(b, "<div class=\"image\">[image: %s]</div>\n", alt)
+}
+
+// writeHTMLForm renders a form as disabled inputs showing their initial values, since a transcript cannot submit it
+func writeHTMLForm(b *strings.Builder, form *interfaces.RenderedForm) {
+	b.WriteString("<fieldset class=\"form\" disabled>\n")
+	if form.Title != "" {
+		fmt.Fprintf(b, "<legend>%s</legend>\n", html.EscapeString(form.Title))
+	}
+	for _, field := range form.Fields {
+		label := html.EscapeString(field.Label)
+		switch field.Type {
+		case FormFieldCheckbox:
+			checked := ""
+			if field.Checked {
+				checked = " checked"
+			}
+			fmt.Fprintf(b, "<label><input type=\"checkbox\"%s> %s</label>\n", checked, label)
+		case FormFieldSelect:
+			fmt.Fprintf(b, "<label>%s <select>", label)
+			for _, option := range field.Options {
+				selected := ""
+				if option == field.Value {
+					selected = " selected"
+				}
+				fmt.Fprintf(b, "<option%s>%s</option>", selected, html.EscapeString(option))
+			}
+			b.WriteString("</select></label>\n")
+		default:
+			fmt.Fprintf(b, "<label>%s <input type=\"text\" value=\"%s\" placeholder=\"%s\"></label>\n",
+				label, html.EscapeString(field.Value), html.EscapeString(field.Placeholder))
+		}
+	}
+	fmt.Fprintf(b, "<button>%s</button>\n</fieldset>\n", html.EscapeString(form.SubmitLabel))
+}
+
+// isWebURL reports whether a URL is an absolute http or https URL, the only kind made a live link
+func isWebURL(rawURL string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	return scheme == "http" || scheme == "https"
+}
+
+// htmlLabel escapes a label, prefixed with its icon if it has one
+func htmlLabel(icon, label string) string {
+	if icon == "" {
+		return html.EscapeString(label)
+	}
+	return html.EscapeString(icon + " " + label)
+}
+
+// htmlClasses returns a class attribute value with a "status-<name>" class when a status is set
+func htmlClasses(class, status string) string {
+	if status == "" {
+		return class
+	}
+	return class + " status-" + html.EscapeString(status)
+}
@@ -0,0 +1,56 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteHTMLImageLinksOnlyWebURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    ImageContent
+		wantLink bool
+		want     string
+	}{
+		{"https", ImageContent{URL: "https://example.com/a.png", Alt: "chart"}, true, `href="https://example.com/a.png"`},
+		{"http", ImageContent{URL: "http://example.com/a.png"}, true, `href="http://example.com/a.png"`},
+		{"javascript", ImageContent{URL: "javascript:alert(1)", Alt: "chart"}, false, "[image: chart javascript:alert(1)]"},
+		{"mixed case javascript", ImageContent{URL: " JavaScript:alert(1)"}, false, "javascript:alert(1)"},
+		{"data", ImageContent{URL: "data:text/html;base64,PHNjcmlwdD4="}, false, "data:text/html"},
+		{"relative", ImageContent{URL: "/images/a.png"}, false, "[image: /images/a.png]"},
+		{"scheme-relative", ImageContent{URL: "//example.com/a.png"}, false, "[image: //example.com/a.png]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeHTMLImage(&b, &tt.image)
+			got := b.String()
+
+			if hasLink := strings.Contains(got, "href="); hasLink != tt.wantLink {
+				t.Errorf("link = %v, want %v: %s", hasLink, tt.wantLink, got)
+			}
+			if !strings.Contains(strings.ToLower(got), strings.ToLower(tt.want)) {
+				t.Errorf("output %q does not contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHTMLImageEmbedsOnlyImageData(t *testing.T) {
+	// A 1x1 GIF, then an HTML document passed off as image data
+	gif := "R0lGODlhAQABAAAAACw="
+	page := "PGh0bWw+PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0PjwvaHRtbD4="
+
+	var b strings.Builder
+	writeHTMLImage(&b, &ImageContent{Data: gif})
+	if !strings.Contains(b.String(), `src="data:image/gif;base64,`) {
+		t.Errorf("GIF data was not embedded: %s", b.String())
+	}
+
+	b.Reset()
+	writeHTMLImage(&b, &ImageContent{Data: page, Alt: "page"})
+	if strings.Contains(b.String(), "<img") {
+		t.Errorf("non-image data was embedded: %s", b.String())
+	}
+}
@@ -0,0 +1,91 @@
+// Package content implements key-value detail blocks for the Universal Application Console.
+// A "keyvalue" block carries an ordered list of labeled fields, such as the properties of a resource, and
+// renders them as "Label: value" pairs with the values aligned after the widest label. Label widths are
+// measured in terminal columns, so CJK and emoji labels line up too. A field with a status has its value
+// colored like a status line, and values spanning several lines keep their continuation lines aligned.
+// The content may be an object with a "fields" array or the array of fields itself.
+package content
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// keyValueLabelStyle sets labels apart from their values
+var keyValueLabelStyle = lipgloss.NewStyle().Bold(true)
+
+// parseKeyValueContent reads a keyvalue block's content, accepting a bare array of fields
+func parseKeyValueContent(r *Renderer, content interface{}, target *KeyValueContent) error {
+	if fields, ok := content.([]interface{}); ok {
+		content = map[string]interface{}{"fields": fields}
+	}
+	if err := r.parseBlockContent(content, target); err != nil {
+		return fmt.Errorf("failed to parse keyvalue content: %w", err)
+	}
+	return nil
+}
+
+// keyValueText formats a field's value, leaving a missing value empty
+func keyValueText(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// renderKeyValueContent handles labeled fields aligned on their labels
+func (r *Renderer) renderKeyValueContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	var keyValue KeyValueContent
+	if err := parseKeyValueContent(r, block.Content, &keyValue); err != nil {
+		return nil, err
+	}
+
+	labelWidth := 0
+	for _, field := range keyValue.Fields {
+		if width := runewidth.StringWidth(field.Label); width > labelWidth {
+			labelWidth = width
+		}
+	}
+	indent := strings.Repeat(" ", labelWidth+2)
+
+	lines := make([]string, 0, len(keyValue.Fields))
+	for _, field := range keyValue.Fields {
+		label := keyValueLabelStyle.Render(field.Label + ":")
+		padding := strings.Repeat(" ", labelWidth-runewidth.StringWidth(field.Label)+1)
+
+		valueLines := strings.Split(keyValueText(field.Value), "\n")
+		for i, line := range valueLines {
+			if field.Status != "" {
+				line = r.themeManager.GetStatusStyle(field.Status).Render(line)
+			}
+			if i == 0 {
+				lines = append(lines, label+padding+line)
+			} else {
+				lines = append(lines, indent+line)
+			}
+		}
+	}
+
+	content := interfaces.RenderedContent{
+		Text:      strings.Join(lines, "\n"),
+		Focusable: false,
+		ID:        generateContentID(),
+	}
+
+	return []interfaces.RenderedContent{content}, nil
+}
+
+// writeHTMLKeyValue renders labeled fields as a description list
+func writeHTMLKeyValue(b *strings.Builder, keyValue *KeyValueContent) {
+	b.WriteString("<dl class=\"keyvalue\">\n")
+	for _, field := range keyValue.Fields {
+		fmt.Fprintf(b, "<dt>%s</dt><dd class=\"%s\">%s</dd>\n", html.EscapeString(field.Label),
+			htmlClasses("value", field.Status), html.EscapeString(keyValueText(field.Value)))
+	}
+	b.WriteString("</dl>\n")
+}
@@ -0,0 +1,90 @@
+// Package content implements ordered list markers for the Universal Application Console.
+// The "number" style, the default for ordered lists, numbers nested items hierarchically (1., 1.1.,
+// 1.2.1.), so an item's marker shows where it sits in the whole list. The "alpha" and "roman" styles
+// number each level on its own with letters (a., b., ... z., aa.) or lowercase roman numerals
+// (i., ii., iii.), relying on indentation to show nesting. Naming any of these styles makes a list
+// ordered, and the "bullet" style makes it unordered.
+package content
+
+import (
+	"strconv"
+	"strings"
+)
+
+// List styles for ListContent.Style
+const (
+	ListStyleBullet = "bullet"
+	ListStyleNumber = "number"
+	ListStyleAlpha  = "alpha"
+	ListStyleRoman  = "roman"
+)
+
+// isOrderedList reports whether a list's items are numbered
+func isOrderedList(list *ListContent) bool {
+	switch strings.ToLower(list.Style) {
+	case ListStyleBullet:
+		return false
+	case ListStyleNumber, ListStyleAlpha, ListStyleRoman:
+		return true
+	default:
+		return list.Ordered
+	}
+}
+
+// orderedListMarker returns the marker for the item whose per-level numbers are counters
+func orderedListMarker(style string, counters []int) string {
+	number := counters[len(counters)-1]
+
+	switch strings.ToLower(style) {
+	case ListStyleAlpha:
+		return alphaNumeral(number) + "."
+	case ListStyleRoman:
+		return romanNumeral(number) + "."
+	default:
+		parts := make([]string, len(counters))
+		for i, counter := range counters {
+			// A level skipped by item levels has no item of its own; count it as the first
+			if counter == 0 {
+				counter = 1
+			}
+			parts[i] = strconv.Itoa(counter)
+		}
+		return strings.Join(parts, ".") + "."
+	}
+}
+
+// alphaNumeral numbers like spreadsheet columns: a..z, then aa, ab, and so on
+func alphaNumeral(number int) string {
+	var letters []byte
+	for number > 0 {
+		number--
+		letters = append([]byte{byte('a' + number%26)}, letters...)
+		number /= 26
+	}
+	return string(letters)
+}
+
+// romanNumeral returns number in lowercase roman numerals, falling back to digits outside 1..3999
+func romanNumeral(number int) string {
+	if number < 1 || number > 3999 {
+		return strconv.Itoa(number)
+	}
+
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"},
+		{100, "c"}, {90, "xc"}, {50, "l"}, {40, "xl"},
+		{10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+	}
+
+	var roman strings.Builder
+	for _, numeral := range numerals {
+		for number >= numeral.value {
+			roman.WriteString(numeral.symbol)
+			number -= numeral.value
+		}
+	}
+	return roman.String()
+}
@@ -0,0 +1,183 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+// threeLevelList returns an ordered list nested three levels deep through item children
+func threeLevelList(style string, compact bool) *ListContent {
+	return &ListContent{
+		Ordered: true,
+		Style:   style,
+		Compact: compact,
+		Items: []ListItem{
+			{Text: "Build", Children: []ListItem{
+				{Text: "Compile", Children: []ListItem{
+					{Text: "Parse"},
+					{Text: "Link"},
+				}},
+				{Text: "Package"},
+			}},
+			{Text: "Deploy", Children: []ListItem{
+				{Text: "Upload"},
+			}},
+		},
+	}
+}
+
+func TestThreeLevelOrderedLists(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	tests := []struct {
+		style   string
+		compact bool
+		want    []string
+	}{
+		{ListStyleNumber, false, []string{
+			"1. Build",
+			"  1.1. Compile",
+			"    1.1.1. Parse",
+			"    1.1.2. Link",
+			"  1.2. Package",
+			"",
+			"2. Deploy",
+			"  2.1. Upload",
+		}},
+		{ListStyleNumber, true, []string{
+			"1. Build",
+			"  1.1. Compile",
+			"    1.1.1. Parse",
+			"    1.1.2. Link",
+			"  1.2. Package",
+			"2. Deploy",
+			"  2.1. Upload",
+		}},
+		{ListStyleAlpha, true, []string{
+			"a. Build",
+			"  a. Compile",
+			"    a. Parse",
+			"    b. Link",
+			"  b. Package",
+			"b. Deploy",
+			"  a. Upload",
+		}},
+		{ListStyleRoman, true, []string{
+			"i. Build",
+			"  i. Compile",
+			"    i. Parse",
+			"    ii. Link",
+			"  ii. Package",
+			"ii. Deploy",
+			"  i. Upload",
+		}},
+	}
+	for _, tt := range tests {
+		got := r.formatList(threeLevelList(tt.style, tt.compact))
+		if want := strings.Join(tt.want, "\n"); got != want {
+			t.Errorf("%s list (compact %v):\n%s\nwant:\n%s", tt.style, tt.compact, got, want)
+		}
+	}
+}
+
+func TestOrderedListNestedByItemLevels(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	list := &ListContent{Ordered: true, Items: []ListItem{
+		{Text: "Build"},
+		{Text: "Compile", Level: 1},
+		{Text: "Parse", Level: 2},
+		{Text: "Package", Level: 1},
+		{Text: "Deploy"},
+	}}
+	want := strings.Join([]string{
+		"1. Build",
+		"  1.1. Compile",
+		"    1.1.1. Parse",
+		"  1.2. Package",
+		"2. Deploy",
+	}, "\n")
+	if got := r.formatList(list); got != want {
+		t.Errorf("list nested by levels:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestListNumerals(t *testing.T) {
+	alpha := map[int]string{1: "a", 26: "z", 27: "aa", 52: "az", 53: "ba", 702: "zz", 703: "aaa"}
+	for number, want := range alpha {
+		if got := alphaNumeral(number); got != want {
+			t.Errorf("alphaNumeral(%d) = %q, want %q", number, got, want)
+		}
+	}
+
+	roman := map[int]string{1: "i", 4: "iv", 9: "ix", 14: "xiv", 40: "xl", 1994: "mcmxciv", 4000: "4000"}
+	for number, want := range roman {
+		if got := romanNumeral(number); got != want {
+			t.Errorf("romanNumeral(%d) = %q, want %q", number, got, want)
+		}
+	}
+}
+
+func TestAlphaListWrapsPastZ(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	// Nested letters wrap per level, and style names match in any case
+	children := make([]ListItem, 28)
+	for i := range children {
+		children[i] = ListItem{Text: "step"}
+	}
+	items := make([]ListItem, 30)
+	for i := range items {
+		items[i] = ListItem{Text: "task"}
+	}
+	items[27].Children = children
+	lines := strings.Split(r.formatList(&ListContent{Style: "Alpha", Compact: true, Items: items}), "\n")
+
+	markers := make([]string, len(lines))
+	for i, line := range lines {
+		markers[i] = strings.Fields(line)[0]
+	}
+	for index, want := range map[int]string{0: "a.", 25: "z.", 26: "aa.", 27: "ab."} {
+		if markers[index] != want {
+			t.Errorf("item %d has marker %q, want %q", index+1, markers[index], want)
+		}
+	}
+	for index, want := range map[int]string{28: "a.", 53: "z.", 54: "aa.", 55: "ab."} {
+		if markers[index] != want || !strings.HasPrefix(lines[index], "  ") {
+			t.Errorf("nested step %d is %q, want an indented %q", index-27, lines[index], want)
+		}
+	}
+	if last := markers[len(markers)-1]; last != "ad." {
+		t.Errorf("the thirtieth item has marker %q, want ad.", last)
+	}
+}
+
+func TestHTMLListStyles(t *testing.T) {
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{"alpha", `<ol type="a">`},
+		{"Alpha", `<ol type="a">`},
+		{"ROMAN", `<ol type="i">`},
+		{"number", `<ol>`},
+		{"bullet", `<ul>`},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		list := &ListContent{Style: tt.style, Items: []ListItem{{Text: "one"}}}
+		writeHTMLList(&b, list, list.Items)
+		if !strings.HasPrefix(b.String(), tt.want) {
+			t.Errorf("HTML list of style %q = %q, want it to open with %s", tt.style, b.String(), tt.want)
+		}
+	}
+}
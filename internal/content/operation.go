@@ -0,0 +1,72 @@
+// Package content implements progress blocks that follow a running operation in the Universal Application
+// Console. A progress block may name the operation it reports with "operationId"; while the block says the
+// operation is running, the console polls the application for the operation's progress, and the block is
+// drawn with the latest polled progress, status, and message in place of the values it arrived with.
+package content
+
+import (
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// isFinishedProgress reports whether a progress status means the operation will not advance any further
+func isFinishedProgress(status string) bool {
+	return status == "complete" || status == "error"
+}
+
+// syncProgress sets a progress block's progress, status, and message from the latest polled progress of the
+// operation it names, when there is any
+func (r *Renderer) syncProgress(progress *ProgressContent) {
+	if progress.OperationID == "" {
+		return
+	}
+	polled, tracked := r.operations[progress.OperationID]
+	if !tracked {
+		return
+	}
+	progress.Progress = polled.Progress
+	if polled.Status != "" {
+		progress.Status = polled.Status
+	}
+	if polled.Message != "" {
+		progress.Message = polled.Message
+	}
+}
+
+// TrackProgress records the latest polled progress of an operation, which progress blocks naming it show
+func (r *Renderer) TrackProgress(operationID string, progress *interfaces.ProgressResponse) {
+	if operationID == "" || progress == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.operations[operationID] = progress
+}
+
+// ProgressOperations returns the IDs of the operations named by the progress blocks of content that are
+// still running, in the order the blocks appear
+func (r *Renderer) ProgressOperations(content interface{}) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	blocks, err := r.parseContentStructure(content)
+	if err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, block := range blocks {
+		if block.Type != "progress" {
+			continue
+		}
+		var progress ProgressContent
+		if err := r.parseBlockContent(block.Content, &progress); err != nil || progress.OperationID == "" {
+			continue
+		}
+		r.syncProgress(&progress)
+		if !isFinishedProgress(progress.Status) {
+			ids = append(ids, progress.OperationID)
+		}
+	}
+	return ids
+}
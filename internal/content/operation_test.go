@@ -0,0 +1,44 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestProgressBlockShowsPolledProgress(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type":    "progress",
+			"content": map[string]interface{}{"label": "Build", "progress": 10, "status": "running", "showPercent": true, "operationId": "build"},
+		},
+		map[string]interface{}{
+			"type":    "progress",
+			"content": map[string]interface{}{"label": "Lint", "progress": 100, "status": "complete", "operationId": "lint"},
+		},
+	}
+
+	if ids := r.ProgressOperations(blocks); len(ids) != 1 || ids[0] != "build" {
+		t.Fatalf("ProgressOperations = %v, want [build]", ids)
+	}
+
+	r.TrackProgress("build", &interfaces.ProgressResponse{Progress: 75, Status: "running"})
+	rendered, err := r.RenderContent(blocks[:1], nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	if len(rendered) != 1 || !strings.Contains(rendered[0].Text, "75%") {
+		t.Errorf("rendered progress = %q, want the polled 75%%", rendered[0].Text)
+	}
+
+	r.TrackProgress("build", &interfaces.ProgressResponse{Progress: 100, Status: "complete"})
+	if ids := r.ProgressOperations(blocks); len(ids) != 0 {
+		t.Errorf("ProgressOperations after completion = %v, want none", ids)
+	}
+}
@@ -0,0 +1,90 @@
+// Package content implements paginated list rendering for the Universal Application Console.
+// A list block with a nextToken holds only the first page of a longer list, which keeps the initial
+// render fast. The renderer remembers such lists by content ID, reports the continuation on the
+// rendered content, and appends later pages fetched by the application as they arrive.
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// AppendListPage appends the next page of a paginated list and returns the re-rendered list.
+// The page is response content containing a list block; its continuation replaces the list's.
+func (r *Renderer) AppendListPage(listID string, page interface{}) (*interfaces.RenderedContent, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	list, exists := r.pagedLists[listID]
+	if !exists {
+		return nil, fmt.Errorf("list %s has no further pages", listID)
+	}
+
+	next, err := r.findListPage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Items = append(list.Items, next.Items...)
+	list.NextToken = next.NextToken
+	if next.NextCommand != "" {
+		list.NextCommand = next.NextCommand
+	}
+	if next.Total > 0 {
+		list.Total = next.Total
+	}
+
+	if list.NextToken == "" {
+		delete(r.pagedLists, listID)
+	}
+
+	return r.renderList(listID, list), nil
+}
+
+// findListPage returns the first list block in a page of response content
+func (r *Renderer) findListPage(page interface{}) (*ListContent, error) {
+	blocks, err := r.parseContentStructure(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list page: %w", err)
+	}
+
+	for _, block := range blocks {
+		if block.Type != "list" {
+			continue
+		}
+		var list ListContent
+		if err := r.parseBlockContent(block.Content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse list page: %w", err)
+		}
+		return &list, nil
+	}
+	return nil, fmt.Errorf("response does not contain a list page")
+}
+
+// renderList formats a list, noting how much of it has been loaded when more pages remain
+func (r *Renderer) renderList(listID string, list *ListContent) *interfaces.RenderedContent {
+	text := r.formatList(list)
+
+	var nextPage *interfaces.ListContinuation
+	if list.NextToken != "" {
+		nextPage = &interfaces.ListContinuation{
+			Token:   list.NextToken,
+			Command: list.NextCommand,
+		}
+
+		summary := fmt.Sprintf("%s %d items shown, more available (Show more)", r.glyphs.ellipsis, len(list.Items))
+		if list.Total > len(list.Items) {
+			summary = fmt.Sprintf("%s %d of %d items shown (Show more)", r.glyphs.ellipsis, len(list.Items), list.Total)
+		}
+		text = strings.Join([]string{text, r.themeManager.GetInfoStyle().Render(summary)}, "\n")
+	}
+
+	return &interfaces.RenderedContent{
+		Text:      text,
+		Focusable: false,
+		ID:        listID,
+		NextPage:  nextPage,
+	}
+}
@@ -0,0 +1,112 @@
+// Package content implements lazily fetched reference blocks for the Universal Application Console.
+// A "reference" block stands in for content too large to send inline, such as a long log or a big table:
+// it carries the URL the application serves the content at and a summary of what it holds. The block is
+// rendered as a collapsed section showing the summary, and expanding it has the console fetch the content.
+// Fetched content is kept by URL and rendered in place of the reference from then on, so re-rendering a
+// response, after a resize or a theme change, shows the content without fetching it again.
+package content
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// parseReferenceContent reads a reference block's content, which must name a URL
+func (r *Renderer) parseReferenceContent(block interfaces.ContentBlock) (*ReferenceContent, error) {
+	var reference ReferenceContent
+	if err := r.parseBlockContent(block.Content, &reference); err != nil {
+		return nil, fmt.Errorf("failed to parse reference content: %w", err)
+	}
+	reference.URL = strings.TrimSpace(reference.URL)
+	if reference.URL == "" {
+		return nil, fmt.Errorf("reference content has no url")
+	}
+	if reference.Summary == "" {
+		reference.Summary = reference.URL
+	}
+	return &reference, nil
+}
+
+// renderReferenceContent renders a reference as a collapsed section until its content is fetched, and as
+// the fetched content under the summary afterwards
+func (r *Renderer) renderReferenceContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	reference, err := r.parseReferenceContent(block)
+	if err != nil {
+		return nil, err
+	}
+	headerStyle := r.themeManager.GetCollapsibleHeaderStyle()
+
+	fetched, resolved := r.references[reference.URL]
+	if !resolved {
+		expanded := false
+		hint := r.themeManager.GetInfoStyle().Render("(expand to load)")
+		return []interfaces.RenderedContent{{
+			Text:      headerStyle.Render(reference.Summary) + " " + hint,
+			Focusable: true,
+			Expanded:  &expanded,
+			ID:        generateContentID(),
+			Reference: &interfaces.ContentReference{URL: reference.URL, Summary: reference.Summary},
+		}}, nil
+	}
+
+	result := []interfaces.RenderedContent{{
+		Text: headerStyle.Render(fmt.Sprintf("%s %s", r.glyphs.expanded, reference.Summary)),
+		ID:   generateContentID(),
+	}}
+	for i, child := range fetched {
+		childRendered, err := r.renderContentBlock(child, i)
+		if err == nil {
+			result = append(result, childRendered...)
+		}
+	}
+	return result, nil
+}
+
+// ResolveReference supplies the fetched content of the reference at url. The content is response content: a
+// block, an array of blocks, or text.
+func (r *Renderer) ResolveReference(url string, content interface{}) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	blocks, err := r.parseContentStructure(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse referenced content: %w", err)
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("the application returned no content for %s", url)
+	}
+
+	r.references[strings.TrimSpace(url)] = blocks
+	return nil
+}
+
+// writeHTMLReference exports fetched reference content as an open section, and an unfetched reference as a
+// link to the content, or as text when its URL is not an absolute http or https URL
+func (r *Renderer) writeHTMLReference(b *strings.Builder, block interfaces.ContentBlock) error {
+	reference, err := r.parseReferenceContent(block)
+	if err != nil {
+		return err
+	}
+
+	fetched, resolved := r.references[reference.URL]
+	if !resolved && !isWebURL(reference.URL) {
+		fmt.Fprintf(b, "<div class=\"reference\">%s (%s)</div>\n",
+			html.EscapeString(reference.Summary), html.EscapeString(reference.URL))
+		return nil
+	}
+	if !resolved {
+		fmt.Fprintf(b, "<div class=\"reference\"><a href=\"%s\">%s</a></div>\n",
+			html.EscapeString(reference.URL), html.EscapeString(reference.Summary))
+		return nil
+	}
+
+	fmt.Fprintf(b, "<details class=\"reference\" open><summary>%s</summary>\n", html.EscapeString(reference.Summary))
+	if err := r.writeHTMLBlocks(b, fetched); err != nil {
+		return err
+	}
+	b.WriteString("</details>\n")
+	return nil
+}
@@ -0,0 +1,75 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLReferenceLinksOnlyWebURLs(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	tests := []struct {
+		url      string
+		wantLink bool
+	}{
+		{"https://app.example/logs/full", true},
+		{"/logs/full", false},
+		{"javascript:alert(1)", false},
+	}
+	for _, tt := range tests {
+		block := map[string]interface{}{
+			"type":    "reference",
+			"content": map[string]interface{}{"url": tt.url, "summary": "Build log"},
+		}
+		got, err := r.RenderHTML([]interface{}{block}, nil)
+		if err != nil {
+			t.Fatalf("RenderHTML(%q) failed: %v", tt.url, err)
+		}
+		if hasLink := strings.Contains(got, "href="); hasLink != tt.wantLink {
+			t.Errorf("reference to %q: link = %v, want %v: %s", tt.url, hasLink, tt.wantLink, got)
+		}
+		if !strings.Contains(got, "Build log") {
+			t.Errorf("reference to %q lost its summary: %s", tt.url, got)
+		}
+	}
+}
+
+func TestResolvedReferenceRendersContent(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	block := map[string]interface{}{
+		"type":    "reference",
+		"content": map[string]interface{}{"url": "/logs/full", "summary": "Build log"},
+	}
+
+	rendered, err := r.RenderContent([]interface{}{block}, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	if len(rendered) != 1 || rendered[0].Reference == nil || rendered[0].Reference.URL != "/logs/full" {
+		t.Fatalf("unresolved reference rendered as %+v, want one collapsed reference", rendered)
+	}
+
+	if err := r.ResolveReference("/logs/full", "line one\nline two"); err != nil {
+		t.Fatalf("ResolveReference failed: %v", err)
+	}
+	rendered, err = r.RenderContent([]interface{}{block}, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	var text strings.Builder
+	for _, item := range rendered {
+		if item.Reference != nil {
+			t.Errorf("resolved reference is still a reference: %+v", item)
+		}
+		text.WriteString(item.Text)
+	}
+	if !strings.Contains(text.String(), "line two") {
+		t.Errorf("resolved reference does not show its content: %q", text.String())
+	}
+}
@@ -16,19 +16,37 @@ import (
 	"github.com/alecthomas/chroma/lexers"
 	"github.com/alecthomas/chroma/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 
+	"github.com/universal-console/console/internal/contrast"
 	"github.com/universal-console/console/internal/interfaces"
 )
 
+// emptyContentPlaceholder stands in for a response whose content renders nothing
+const emptyContentPlaceholder = "(no content)"
+
+// emptyContentStyle keeps the placeholder subtle
+var emptyContentStyle = lipgloss.NewStyle().Faint(true).Italic(true)
+
 // Renderer implements the ContentRenderer interface with comprehensive content processing capabilities
 type Renderer struct {
 	collapsibleManager *CollapsibleManager
+	treeManager        *TreeManager
 	syntaxHighlighter  *SyntaxHighlighter
 	themeManager       *ThemeManager
 	cache              *RenderCache
 	mutex              sync.RWMutex
 	preferences        RenderingPreferences
 	metrics            ContentMetrics
+	graphicsProtocol   GraphicsProtocol                        // Inline image support detected at startup
+	glyphs             *glyphSet                               // Drawing characters; ASCII when Unicode is not supported
+	contentWidth       int                                     // Columns available for wrapped content; 0 uses the default
+	pagedLists         map[string]*ListContent                 // Paginated lists by content ID, kept so later pages can be appended
+	references         map[string][]interfaces.ContentBlock    // Fetched content of reference blocks, by URL
+	workflowSteps      map[string]int                          // Step each workflow has reached, by workflow ID
+	operations         map[string]*interfaces.ProgressResponse // Latest polled progress of operations, by operation ID
+	filter             blockFilter                             // Block types and status levels left out of rendered content
+	complexityBudget   int                                     // Complexity score above which content is truncated; 0 renders everything
 }
 
 // RenderCache provides intelligent caching of rendered content for performance optimization
@@ -95,6 +113,7 @@ func NewRenderer() (*Renderer, error) {
 
 	renderer := &Renderer{
 		collapsibleManager: collapsibleManager,
+		treeManager:        NewTreeManager(),
 		syntaxHighlighter:  highlighter,
 		themeManager:       themeManager,
 		cache:              cache,
@@ -102,13 +121,30 @@ func NewRenderer() (*Renderer, error) {
 		metrics: ContentMetrics{
 			ElementCounts: make(map[string]int),
 		},
+		graphicsProtocol: DetectGraphicsProtocol(),
+		glyphs:           glyphsFor(DetectUnicodeSupport()),
+		pagedLists:       make(map[string]*ListContent),
+		references:       make(map[string][]interfaces.ContentBlock),
+		workflowSteps:    make(map[string]int),
+		operations:       make(map[string]*interfaces.ProgressResponse),
 	}
 
 	return renderer, nil
 }
 
-// RenderContent transforms structured content into display-ready format
+// RenderContent transforms structured content into display-ready format, truncating it when it exceeds
+// the complexity budget
 func (r *Renderer) RenderContent(content interface{}, theme *interfaces.Theme) ([]interfaces.RenderedContent, error) {
+	return r.renderContent(content, theme, true)
+}
+
+// RenderFullContent transforms structured content into display-ready format whatever its complexity
+func (r *Renderer) RenderFullContent(content interface{}, theme *interfaces.Theme) ([]interfaces.RenderedContent, error) {
+	return r.renderContent(content, theme, false)
+}
+
+// renderContent renders content, applying the complexity budget when enforceBudget is set
+func (r *Renderer) renderContent(content interface{}, theme *interfaces.Theme, enforceBudget bool) ([]interfaces.RenderedContent, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
@@ -120,6 +156,7 @@ func (r *Renderer) RenderContent(content interface{}, theme *interfaces.Theme) (
 	// Update theme if provided
 	if theme != nil {
 		r.themeManager.SetTheme(theme)
+		r.setCodeTheme(theme.CodeTheme)
 	}
 
 	// Parse content structure
@@ -128,9 +165,40 @@ func (r *Renderer) RenderContent(content interface{}, theme *interfaces.Theme) (
 		return nil, fmt.Errorf("failed to parse content structure: %w", err)
 	}
 
+	// Leave out the blocks the filter hides, counting them
+	var hidden hiddenBlockCounts
+	shownBlocks := make([]interfaces.ContentBlock, 0, len(contentBlocks))
+	for _, block := range contentBlocks {
+		if reason := r.filter.hides(block); reason != "" {
+			hidden.add(reason)
+			continue
+		}
+		shownBlocks = append(shownBlocks, block)
+	}
+
+	// Score the response and cut it down to the budget
+	score := contentComplexity(shownBlocks)
+	r.metrics.ComplexityScore = score
+	truncated := false
+	if enforceBudget && r.complexityBudget > 0 && score > r.complexityBudget {
+		shownBlocks, truncated = applyBudget(shownBlocks, r.complexityBudget)
+	}
+
 	// Render each content block
 	var renderedBlocks []interfaces.RenderedContent
-	for i, block := range contentBlocks {
+	r.metrics.ElementCounts = make(map[string]int)
+	for i, block := range shownBlocks {
+		r.metrics.ElementCounts[block.Type]++
+
+		// Blocks without content are skipped rather than failing to parse or showing "<nil>", except
+		// separators, which draw their default line
+		if isEmptyContent(block.Content) {
+			if block.Type != "separator" {
+				continue
+			}
+			block.Content = nil
+		}
+
 		rendered, err := r.renderContentBlock(block, i)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render content block %d: %w", i, err)
@@ -138,6 +206,19 @@ func (r *Renderer) RenderContent(content interface{}, theme *interfaces.Theme) (
 		renderedBlocks = append(renderedBlocks, rendered...)
 	}
 
+	// Tell the user that content was truncated or filtered and how to see it
+	if truncated {
+		renderedBlocks = append(renderedBlocks, truncatedNotice(score, r.complexityBudget))
+	}
+	if len(hidden.reasons) > 0 {
+		renderedBlocks = append(renderedBlocks, hidden.notice())
+	}
+
+	// Say so when a response has nothing to show, rather than leaving a bare prefix
+	if len(renderedBlocks) == 0 {
+		renderedBlocks = []interfaces.RenderedContent{{Text: emptyContentStyle.Render(emptyContentPlaceholder)}}
+	}
+
 	// Update metrics
 	r.updateRenderingMetrics(renderedBlocks)
 
@@ -159,10 +240,14 @@ func (r *Renderer) RenderActions(actions []interfaces.Action, theme *interfaces.
 	var actionLines []string
 
 	for i, action := range actions {
-		actionStyle := r.getActionStyle(action.Type)
+		actionStyle := r.themeManager.GetActionStyle(action)
 
-		// Format action with number and icon
-		actionText := fmt.Sprintf("[%d] %s %s", i+1, action.Icon, action.Name)
+		// Format action with the key that runs it, when it has one, and its icon
+		key := ""
+		if shortcut := ShortcutKey(i); shortcut != "" {
+			key = fmt.Sprintf("[%s]", shortcut)
+		}
+		actionText := fmt.Sprintf("%-4s %s %s", key, ActionIcon(action), action.Name)
 		styledAction := actionStyle.Render(actionText)
 
 		actionLines = append(actionLines, styledAction)
@@ -202,11 +287,19 @@ func (r *Renderer) RenderError(errorResp *interfaces.ErrorResponse, theme *inter
 		errorComponents = append(errorComponents, r.themeManager.GetInfoStyle().Render(codeText))
 	}
 
-	// Render error details if present
+	// Render every block of the error details
 	if errorResp.Error.Details != nil {
-		detailsRendered, err := r.renderContentBlock(*errorResp.Error.Details, 0)
-		if err == nil && len(detailsRendered) > 0 {
-			errorComponents = append(errorComponents, detailsRendered[0].Text)
+		detailBlocks, err := r.parseContentStructure(ErrorDetailContent(errorResp.Error.Details))
+		if err == nil {
+			for i, block := range detailBlocks {
+				detailsRendered, err := r.renderContentBlock(block, i)
+				if err != nil {
+					continue
+				}
+				for _, rendered := range detailsRendered {
+					errorComponents = append(errorComponents, rendered.Text)
+				}
+			}
 		}
 	}
 
@@ -222,6 +315,26 @@ func (r *Renderer) RenderError(errorResp *interfaces.ErrorResponse, theme *inter
 	return strings.Join(errorComponents, "\n"), nil
 }
 
+// ErrorDetailContent returns the content to render for an error's details. Details whose content
+// is an array of content blocks render as those blocks; any other details render as one block.
+func ErrorDetailContent(details *interfaces.ContentBlock) interface{} {
+	items, ok := details.Content.([]interface{})
+	if !ok || len(items) == 0 {
+		return *details
+	}
+
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return *details
+		}
+		if blockType, ok := fields["type"].(string); !ok || blockType == "" {
+			return *details
+		}
+	}
+	return items
+}
+
 // RenderProgress formats progress indicators
 func (r *Renderer) RenderProgress(progress *interfaces.ProgressResponse, theme *interfaces.Theme) (string, error) {
 	if progress == nil {
@@ -249,7 +362,7 @@ func (r *Renderer) RenderProgress(progress *interfaces.ProgressResponse, theme *
 	return r.renderProgressBar(progressContent), nil
 }
 
-// RenderWorkflow formats workflow breadcrumbs
+// RenderWorkflow formats workflow breadcrumbs, listing the steps as a checklist when the workflow labels them
 func (r *Renderer) RenderWorkflow(workflow *interfaces.Workflow, theme *interfaces.Theme) (string, error) {
 	if workflow == nil {
 		return "", nil
@@ -263,8 +376,13 @@ func (r *Renderer) RenderWorkflow(workflow *interfaces.Workflow, theme *interfac
 	// Create workflow breadcrumb
 	breadcrumb := fmt.Sprintf("%s (%d/%d)", workflow.Title, workflow.Step, workflow.TotalSteps)
 
-	// Create progress indicator for workflow steps
-	progressBar := r.createWorkflowProgressBar(workflow)
+	// Show labeled steps as a checklist, and unlabeled ones as a progress indicator
+	var progressBar string
+	if len(workflow.Steps) > 0 {
+		progressBar = strings.Join(r.renderChecklistSteps(workflowChecklist(workflow)), "\n")
+	} else {
+		progressBar = r.createWorkflowProgressBar(workflow)
+	}
 
 	workflowStyle := r.themeManager.GetWorkflowStyle()
 	return workflowStyle.Render(breadcrumb + "\n" + progressBar), nil
@@ -275,6 +393,9 @@ func (r *Renderer) RenderWorkflow(workflow *interfaces.Workflow, theme *interfac
 // parseContentStructure analyzes and parses the content structure
 func (r *Renderer) parseContentStructure(content interface{}) ([]interfaces.ContentBlock, error) {
 	switch v := content.(type) {
+	case nil:
+		// Missing content is empty, not malformed
+		return nil, nil
 	case string:
 		// Simple text content
 		return []interfaces.ContentBlock{{
@@ -297,11 +418,34 @@ func (r *Renderer) parseContentStructure(content interface{}) ([]interfaces.Cont
 			return nil, err
 		}
 		return []interfaces.ContentBlock{block}, nil
+	case interfaces.ContentBlock:
+		// Already-parsed content block
+		return []interfaces.ContentBlock{v}, nil
+	case []interfaces.ContentBlock:
+		// Already-parsed content blocks
+		return v, nil
 	default:
 		return nil, fmt.Errorf("unsupported content type: %T", content)
 	}
 }
 
+// isEmptyContent reports whether block content has nothing to render: nil, a blank string, or an
+// empty object or array
+func isEmptyContent(content interface{}) bool {
+	switch v := content.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
 // parseContentItem converts interface{} to ContentBlock
 func (r *Renderer) parseContentItem(item interface{}) (interfaces.ContentBlock, error) {
 	// Convert to JSON and back to ensure proper structure
@@ -339,6 +483,18 @@ func (r *Renderer) renderContentBlock(block interfaces.ContentBlock, index int)
 		return r.renderTreeContent(block)
 	case "separator":
 		return r.renderSeparatorContent(block)
+	case "image":
+		return r.renderImageContent(block)
+	case "ansi":
+		return r.renderAnsiContent(block)
+	case "form":
+		return r.renderFormContent(block)
+	case "keyvalue":
+		return r.renderKeyValueContent(block)
+	case "reference":
+		return r.renderReferenceContent(block)
+	case "checklist":
+		return r.renderChecklistContent(block)
 	default:
 		// Fallback to text rendering for unknown types
 		return r.renderTextContent(block)
@@ -370,6 +526,11 @@ func (r *Renderer) renderCodeContent(block interfaces.ContentBlock) ([]interface
 		return nil, fmt.Errorf("failed to parse code content: %w", err)
 	}
 
+	// Unlabeled code uses the profile's default language, if any
+	if codeContent.Language == "" {
+		codeContent.Language = r.preferences.CodeLanguage
+	}
+
 	// Apply syntax highlighting
 	highlightedCode, err := r.syntaxHighlighter.Highlight(codeContent.Code, codeContent.Language)
 	if err != nil {
@@ -386,6 +547,12 @@ func (r *Renderer) renderCodeContent(block interfaces.ContentBlock) ([]interface
 	codeStyle := r.themeManager.GetCodeStyle()
 	renderedCode := codeStyle.Render(highlightedCode)
 
+	// Explain missing highlighting above the block
+	if codeContent.Language != "" && !HasLanguage(codeContent.Language) {
+		_, note := lookupLexer(codeContent.Code, codeContent.Language)
+		renderedCode = r.themeManager.GetAlternativeStyle().Italic(true).Render(note) + "\n" + renderedCode
+	}
+
 	content := interfaces.RenderedContent{
 		Text:      renderedCode,
 		Focusable: false,
@@ -431,9 +598,9 @@ func (r *Renderer) renderCollapsibleContent(block interfaces.ContentBlock) ([]in
 	r.collapsibleManager.RegisterSection(contentID, &collapsibleContent)
 
 	// Create header with toggle indicator
-	toggleIcon := "▶"
+	toggleIcon := r.glyphs.collapsed
 	if collapsibleContent.Expanded {
-		toggleIcon = "▼"
+		toggleIcon = r.glyphs.expanded
 	}
 
 	headerText := fmt.Sprintf("%s %s", toggleIcon, collapsibleContent.Title)
@@ -470,6 +637,7 @@ func (r *Renderer) renderProgressContent(block interfaces.ContentBlock) ([]inter
 	if err := r.parseBlockContent(block.Content, &progressContent); err != nil {
 		return nil, fmt.Errorf("failed to parse progress content: %w", err)
 	}
+	r.syncProgress(&progressContent)
 
 	progressText := r.renderProgressBar(&progressContent)
 
@@ -490,15 +658,12 @@ func (r *Renderer) renderListContent(block interfaces.ContentBlock) ([]interface
 		return nil, fmt.Errorf("failed to parse list content: %w", err)
 	}
 
-	listText := r.formatList(&listContent)
-
-	content := interfaces.RenderedContent{
-		Text:      listText,
-		Focusable: false,
-		ID:        generateContentID(),
+	listID := generateContentID()
+	if listContent.NextToken != "" {
+		r.pagedLists[listID] = &listContent
 	}
 
-	return []interfaces.RenderedContent{content}, nil
+	return []interfaces.RenderedContent{*r.renderList(listID, &listContent)}, nil
 }
 
 // renderTreeContent handles hierarchical tree structures
@@ -509,17 +674,40 @@ func (r *Renderer) renderTreeContent(block interfaces.ContentBlock) ([]interface
 		return nil, fmt.Errorf("failed to parse tree content: %w", err)
 	}
 
-	treeText := r.formatTree(&treeContent)
+	treeID := generateContentID()
+
+	// Register with tree manager so nodes can be expanded and selected interactively
+	if err := r.treeManager.RegisterTree(treeID, &treeContent); err != nil {
+		return nil, fmt.Errorf("failed to register tree content: %w", err)
+	}
+
+	return []interfaces.RenderedContent{*r.renderTree(treeID, &treeContent)}, nil
+}
+
+// renderImageContent handles images, drawing them inline when the terminal supports a graphics protocol
+func (r *Renderer) renderImageContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
+	var imageContent ImageContent
+
+	if err := r.parseBlockContent(block.Content, &imageContent); err != nil {
+		return nil, fmt.Errorf("failed to parse image content: %w", err)
+	}
+
+	lines := renderImage(&imageContent, r.graphicsProtocol, 80)
 
 	content := interfaces.RenderedContent{
-		Text:      treeText,
-		Focusable: true,
+		Text:      strings.Join(lines, "\n"),
+		Focusable: false,
 		ID:        generateContentID(),
 	}
 
 	return []interfaces.RenderedContent{content}, nil
 }
 
+// GraphicsProtocol returns the terminal graphics protocol used for inline images
+func (r *Renderer) GraphicsProtocol() GraphicsProtocol {
+	return r.graphicsProtocol
+}
+
 // renderSeparatorContent handles visual dividers
 func (r *Renderer) renderSeparatorContent(block interfaces.ContentBlock) ([]interfaces.RenderedContent, error) {
 	var separatorContent SeparatorContent
@@ -567,7 +755,7 @@ func (r *Renderer) formatTable(table *TableContent) string {
 	var lines []string
 
 	// Create header
-	headerLine := r.formatTableRow(table.Headers, columnWidths, true)
+	headerLine := r.formatTableRow(table, table.Headers, columnWidths, true)
 	lines = append(lines, headerLine)
 
 	// Create separator
@@ -581,57 +769,56 @@ func (r *Renderer) formatTable(table *TableContent) string {
 			lines = append(lines, fmt.Sprintf("... and %d more rows", len(table.Rows)-maxRows))
 			break
 		}
-		rowLine := r.formatTableRow(row, columnWidths, false)
+		rowLine := r.formatTableRow(table, row, columnWidths, false)
 		lines = append(lines, rowLine)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
-// calculateColumnWidths determines optimal column widths for tables
+// calculateColumnWidths determines column widths for tables: each column's widest cell, capped by
+// its ColumnWidth, then shrunk proportionally if the table is wider than the content area
 func (r *Renderer) calculateColumnWidths(table *TableContent) []int {
 	widths := make([]int, len(table.Headers))
 
-	// Initialize with header widths
+	// Initialize with header widths, measured in display columns so wide characters count double
 	for i, header := range table.Headers {
-		widths[i] = len(header)
+		widths[i] = runewidth.StringWidth(header)
 	}
 
 	// Check data row widths
 	for _, row := range table.Rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) {
+				if width := runewidth.StringWidth(cell); width > widths[i] {
+					widths[i] = width
+				}
 			}
 		}
 	}
 
-	// Apply minimum and maximum width constraints
+	// Apply the minimum width and any per-column maximum
 	for i := range widths {
-		if widths[i] < 8 {
-			widths[i] = 8
+		if widths[i] < minTableColumnWidth {
+			widths[i] = minTableColumnWidth
 		}
-		if widths[i] > 40 {
-			widths[i] = 40
+		if i < len(table.ColumnWidth) && table.ColumnWidth[i] > 0 && widths[i] > table.ColumnWidth[i] {
+			widths[i] = table.ColumnWidth[i]
 		}
 	}
 
-	return widths
+	return fitColumnWidths(widths, r.getContentWidth())
 }
 
-// formatTableRow creates a formatted table row
-func (r *Renderer) formatTableRow(cells []string, widths []int, isHeader bool) string {
+// formatTableRow creates a formatted table row, truncating cells that do not fit their column
+func (r *Renderer) formatTableRow(table *TableContent, cells []string, widths []int, isHeader bool) string {
 	var formattedCells []string
 
 	for i, cell := range cells {
-		width := widths[i]
 		if i < len(widths) {
-			// Truncate if too long
-			if len(cell) > width {
-				cell = cell[:width-3] + "..."
-			}
-			// Pad to width
-			formatted := fmt.Sprintf("%-*s", width, cell)
+			width := widths[i]
+			cell = truncateCell(cell, width, columnTruncation(table, i))
+			formatted := padCell(cell, width)
 			if isHeader {
 				formatted = r.themeManager.GetTableHeaderStyle().Render(formatted)
 			}
@@ -639,25 +826,46 @@ func (r *Renderer) formatTableRow(cells []string, widths []int, isHeader bool) s
 		}
 	}
 
-	return "│ " + strings.Join(formattedCells, " │ ") + " │"
+	bar := r.glyphs.tableVertical
+	return bar + " " + strings.Join(formattedCells, " "+bar+" ") + " " + bar
 }
 
 // createTableSeparator creates table separator lines
 func (r *Renderer) createTableSeparator(widths []int) string {
 	var parts []string
 	for _, width := range widths {
-		parts = append(parts, strings.Repeat("─", width))
+		parts = append(parts, strings.Repeat(r.glyphs.tableHorizontal, width)) // Widths are display columns
 	}
-	return "├─" + strings.Join(parts, "─┼─") + "─┤"
+	line := r.glyphs.tableHorizontal
+	return r.glyphs.tableLeftJoin + line + strings.Join(parts, line+r.glyphs.tableCross+line) + line + r.glyphs.tableRightJoin
 }
 
-// formatList creates formatted list output
+// formatList creates formatted list output. Nesting comes from item children and from item levels,
+// and ordered lists number their items by level as described in list.go.
 func (r *Renderer) formatList(list *ListContent) string {
 	var lines []string
+	var counters []int
+	r.formatListItems(list, list.Items, 0, &counters, &lines)
+	return strings.Join(lines, "\n")
+}
+
+// formatListItems appends the lines for items nested depth levels deep. The counters hold the
+// current item number at each level, so numbering continues across siblings and nested lists.
+func (r *Renderer) formatListItems(list *ListContent, items []ListItem, depth int, counters *[]int, lines *[]string) {
+	for i, item := range items {
+		level := item.Level
+		if level < depth {
+			level = depth
+		}
+
+		for len(*counters) <= level {
+			*counters = append(*counters, 0)
+		}
+		*counters = (*counters)[:level+1]
+		(*counters)[level]++
 
-	for i, item := range list.Items {
-		marker := r.getListMarker(list, i, item.Level)
-		indent := strings.Repeat("  ", item.Level)
+		marker := r.getListMarker(list, *counters, level)
+		indent := strings.Repeat("  ", level)
 		line := fmt.Sprintf("%s%s %s", indent, marker, item.Text)
 
 		if item.Status != "" {
@@ -665,84 +873,98 @@ func (r *Renderer) formatList(list *ListContent) string {
 			line = statusStyle.Render(line)
 		}
 
-		lines = append(lines, line)
+		*lines = append(*lines, line)
 
 		// Render nested items
 		if len(item.Children) > 0 {
-			nestedList := &ListContent{
-				Items:   item.Children,
-				Ordered: list.Ordered,
-				Style:   list.Style,
+			r.formatListItems(list, item.Children, level+1, counters, lines)
+
+			// Outside compact mode, a blank line sets each top-level group apart from the next item
+			if !list.Compact && depth == 0 && i < len(items)-1 {
+				*lines = append(*lines, "")
 			}
-			nestedText := r.formatList(nestedList)
-			lines = append(lines, nestedText)
 		}
 	}
-
-	return strings.Join(lines, "\n")
 }
 
-// getListMarker returns appropriate list markers based on style and order
-func (r *Renderer) getListMarker(list *ListContent, index, level int) string {
-	if list.Ordered {
-		return fmt.Sprintf("%d.", index+1)
+// getListMarker returns appropriate list markers based on style and order. Counters hold the item
+// number at each level down to the item's own.
+func (r *Renderer) getListMarker(list *ListContent, counters []int, level int) string {
+	if isOrderedList(list) {
+		return orderedListMarker(list.Style, counters)
 	}
 
-	markers := []string{"•", "◦", "▪", "▫"}
+	markers := r.glyphs.bullets
 	return markers[level%len(markers)]
 }
 
-// formatTree creates formatted tree output
-func (r *Renderer) formatTree(tree *TreeContent) string {
-	return r.formatTreeNode(&tree.Root, "", true, &tree.Options)
-}
-
-// formatTreeNode recursively formats tree nodes
-func (r *Renderer) formatTreeNode(node *TreeNode, prefix string, isLast bool, options *TreeOptions) string {
-	var lines []string
+// formatTreeNode recursively formats a tree node and its visible children, appending one line
+// and one node reference per visible node. Expansion and selection come from the tree state.
+func (r *Renderer) formatTreeNode(tree *TreeContent, node *TreeNode, prefix string, isLast bool, depth int, lines *[]string, nodes *[]interfaces.RenderedTreeNode) {
+	expanded := containsID(tree.State.ExpandedNodes, node.ID)
+	selected := containsID(tree.State.SelectedNodes, node.ID)
+	hasChildren := len(node.Children) > 0
 
 	// Create node line
-	connector := "├── "
+	connector := r.glyphs.treeBranch
 	if isLast {
-		connector = "└── "
+		connector = r.glyphs.treeLast
+	}
+
+	indicator := ""
+	if hasChildren {
+		indicator = r.glyphs.collapsed + " "
+		if expanded {
+			indicator = r.glyphs.expanded + " "
+		}
 	}
 
 	icon := ""
-	if options.ShowIcons && node.Icon != "" {
+	if tree.Options.ShowIcons && node.Icon != "" {
 		icon = node.Icon + " "
 	}
 
-	nodeLine := prefix + connector + icon + node.Label
-	lines = append(lines, nodeLine)
+	label := node.Label
+	if selected {
+		label = r.glyphs.selected + " " + label
+	}
+
+	*lines = append(*lines, prefix+connector+indicator+icon+label)
+	*nodes = append(*nodes, interfaces.RenderedTreeNode{
+		ID:          node.ID,
+		Label:       node.Label,
+		Depth:       depth,
+		HasChildren: hasChildren,
+		Expanded:    expanded,
+		Selected:    selected,
+		Command:     node.Metadata["command"],
+	})
 
 	// Process children if expanded
-	if node.Expanded && len(node.Children) > 0 {
+	if expanded && hasChildren {
 		childPrefix := prefix
 		if isLast {
 			childPrefix += "    "
 		} else {
-			childPrefix += "│   "
+			childPrefix += r.glyphs.treeContinue
 		}
 
-		for i, child := range node.Children {
+		for i := range node.Children {
 			isLastChild := i == len(node.Children)-1
-			childLines := r.formatTreeNode(&child, childPrefix, isLastChild, options)
-			lines = append(lines, childLines)
+			r.formatTreeNode(tree, &node.Children[i], childPrefix, isLastChild, depth+1, lines, nodes)
 		}
 	}
-
-	return strings.Join(lines, "\n")
 }
 
-// formatSeparator creates visual separators
+// formatSeparator creates visual separators, with an optional label centered by display width
 func (r *Renderer) formatSeparator(separator *SeparatorContent) string {
 	char := separator.Character
 	if char == "" {
 		switch separator.Style {
 		case "line":
-			char = "─"
+			char = r.glyphs.separatorLine
 		case "dots":
-			char = "·"
+			char = r.glyphs.separatorDots
 		case "stars":
 			char = "*"
 		default:
@@ -750,32 +972,50 @@ func (r *Renderer) formatSeparator(separator *SeparatorContent) string {
 		}
 	}
 
+	// Separators span the content width unless a shorter length is requested
+	width := r.getContentWidth()
 	length := separator.Length
-	if length <= 0 {
-		length = 50
+	if length <= 0 || length > width {
+		length = width
 	}
 
-	line := strings.Repeat(char, length)
-
+	line := repeatToWidth(char, length)
 	if separator.Label != "" {
-		labelPos := (length - len(separator.Label)) / 2
-		if labelPos > 0 {
-			line = line[:labelPos] + separator.Label + line[labelPos+len(separator.Label):]
-		}
+		label := prefixWithin(separator.Label, length)
+		left := (length - runewidth.StringWidth(label)) / 2
+		right := length - runewidth.StringWidth(label) - left
+		line = repeatToWidth(char, left) + label + repeatToWidth(char, right)
+	}
+
+	if separator.Centered && length < width {
+		line = strings.Repeat(" ", (width-length)/2) + line
 	}
 
 	return line
 }
 
+// repeatToWidth repeats char to fill width display columns, padding with spaces when a wide
+// character does not divide the width evenly
+func repeatToWidth(char string, width int) string {
+	charWidth := runewidth.StringWidth(char)
+	if charWidth <= 0 {
+		charWidth = 1
+	}
+	return strings.Repeat(char, width/charWidth) + strings.Repeat(" ", width%charWidth)
+}
+
 // renderProgressBar creates visual progress indicators
 func (r *Renderer) renderProgressBar(progress *ProgressContent) string {
 	barWidth := 40
 	filledWidth := int(float64(barWidth) * float64(progress.Progress) / 100.0)
 
-	filled := strings.Repeat("█", filledWidth)
-	empty := strings.Repeat("░", barWidth-filledWidth)
+	filled := strings.Repeat(r.glyphs.progressFilled, filledWidth)
+	empty := strings.Repeat(r.glyphs.progressEmpty, barWidth-filledWidth)
 
 	progressBar := fmt.Sprintf("[%s%s] %d%%", filled, empty, progress.Progress)
+	if details := r.progressDetails(progress); details != "" {
+		progressBar += r.glyphs.progressDetail + details
+	}
 
 	if progress.Label != "" {
 		progressBar = progress.Label + ": " + progressBar
@@ -797,15 +1037,15 @@ func (r *Renderer) createWorkflowProgressBar(workflow *interfaces.Workflow) stri
 	var steps []string
 	for i := 1; i <= totalSteps; i++ {
 		if i < currentStep {
-			steps = append(steps, "●")
+			steps = append(steps, r.glyphs.stepDone)
 		} else if i == currentStep {
-			steps = append(steps, "◉")
+			steps = append(steps, r.glyphs.stepCurrent)
 		} else {
-			steps = append(steps, "○")
+			steps = append(steps, r.glyphs.stepPending)
 		}
 	}
 
-	return strings.Join(steps, "─")
+	return strings.Join(steps, r.glyphs.stepLink)
 }
 
 // addLineNumbers adds line numbers to code blocks
@@ -814,37 +1054,23 @@ func (r *Renderer) addLineNumbers(code string) string {
 	var numberedLines []string
 
 	for i, line := range lines {
-		lineNumber := fmt.Sprintf("%3d │ ", i+1)
+		lineNumber := fmt.Sprintf("%3d %s ", i+1, r.glyphs.lineNumberBar)
 		numberedLines = append(numberedLines, lineNumber+line)
 	}
 
 	return strings.Join(numberedLines, "\n")
 }
 
-// getActionStyle returns appropriate styling for different action types
-func (r *Renderer) getActionStyle(actionType string) lipgloss.Style {
-	switch actionType {
-	case "confirmation":
-		return r.themeManager.GetConfirmationStyle()
-	case "cancel":
-		return r.themeManager.GetCancelStyle()
-	case "info":
-		return r.themeManager.GetInfoStyle()
-	case "alternative":
-		return r.themeManager.GetAlternativeStyle()
-	default:
-		return r.themeManager.GetPrimaryStyle()
-	}
-}
-
 // updateRenderingMetrics updates rendering performance metrics
 func (r *Renderer) updateRenderingMetrics(rendered []interfaces.RenderedContent) {
 	r.metrics.TotalLines = 0
 	r.metrics.MaxLineLength = 0
 	r.metrics.FocusableCount = 0
 	r.metrics.CollapsibleCount = 0
+	r.metrics.MemoryUsage = 0
 
 	for _, content := range rendered {
+		r.metrics.MemoryUsage += int64(len(content.Text))
 		lines := strings.Split(content.Text, "\n")
 		r.metrics.TotalLines += len(lines)
 
@@ -864,6 +1090,26 @@ func (r *Renderer) updateRenderingMetrics(rendered []interfaces.RenderedContent)
 	}
 }
 
+// HasCodeTheme reports whether a syntax highlighting style with the given name exists
+func HasCodeTheme(name string) bool {
+	_, exists := styles.Registry[name]
+	return exists
+}
+
+// setCodeTheme switches the syntax highlighting style when a theme names a different one
+func (r *Renderer) setCodeTheme(name string) {
+	if name == "" || name == r.syntaxHighlighter.theme || !HasCodeTheme(name) {
+		return
+	}
+
+	highlighter, err := NewSyntaxHighlighter(name, "terminal256")
+	if err != nil {
+		return
+	}
+	r.syntaxHighlighter = highlighter
+	r.preferences.CodeTheme = name
+}
+
 // NewSyntaxHighlighter creates a new syntax highlighter with specified theme and format
 func NewSyntaxHighlighter(themeName, formatterName string) (*SyntaxHighlighter, error) {
 	// Get the formatter
@@ -885,19 +1131,38 @@ func NewSyntaxHighlighter(themeName, formatterName string) (*SyntaxHighlighter,
 	}, nil
 }
 
-// Highlight applies syntax highlighting to code
-func (sh *SyntaxHighlighter) Highlight(code, language string) (string, error) {
-	// Get lexer for the language
-	lexer := lexers.Get(language)
-	if lexer == nil {
-		lexer = lexers.Analyse(code)
+// HasLanguage reports whether code in the named language can be syntax highlighted
+func HasLanguage(name string) bool {
+	return lexers.Get(name) != nil
+}
+
+// lookupLexer finds the lexer for a code block: the named language, else one detected from the code,
+// else plain text. When a named language is unknown, the returned note says so and how the code was
+// highlighted instead, so authors can tell why their code is not colored as expected.
+func lookupLexer(code, language string) (chroma.Lexer, string) {
+	if lexer := lexers.Get(language); lexer != nil {
+		return chroma.Coalesce(lexer), ""
 	}
+
+	lexer := lexers.Analyse(code)
 	if lexer == nil {
-		lexer = lexers.Fallback
+		note := ""
+		if language != "" {
+			note = fmt.Sprintf("plain — unknown language '%s'", language)
+		}
+		return chroma.Coalesce(lexers.Fallback), note
 	}
 
-	// Ensure lexer is configured
-	lexer = chroma.Coalesce(lexer)
+	note := ""
+	if language != "" {
+		note = fmt.Sprintf("%s — unknown language '%s'", lexer.Config().Name, language)
+	}
+	return chroma.Coalesce(lexer), note
+}
+
+// Highlight applies syntax highlighting to code
+func (sh *SyntaxHighlighter) Highlight(code, language string) (string, error) {
+	lexer, _ := lookupLexer(code, language)
 
 	// Tokenize the code
 	iterator, err := lexer.Tokenise(nil, code)
@@ -1041,6 +1306,40 @@ func (tm *ThemeManager) buildColorPalette() {
 	}
 }
 
+// SetHighContrast enables or disables high-contrast styling and rebuilds styles
+func (tm *ThemeManager) SetHighContrast(enabled bool) {
+	tm.highContrast = enabled
+	tm.initializeDefaultStyles()
+	if enabled {
+		tm.applyHighContrastStyles()
+	}
+	tm.buildLipglossStyles()
+}
+
+// applyHighContrastStyles replaces default colors with bold, maximum-contrast equivalents
+func (tm *ThemeManager) applyHighContrastStyles() {
+	white := lipgloss.Color("#FFFFFF")
+	black := lipgloss.Color("#000000")
+
+	tm.lipglossStyles["border_default"] = tm.lipglossStyles["border_default"].BorderForeground(white)
+	tm.lipglossStyles["border_actions"] = tm.lipglossStyles["border_actions"].BorderForeground(white)
+	tm.lipglossStyles["status_default"] = lipgloss.NewStyle().Foreground(white)
+	tm.lipglossStyles["status_success"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	tm.lipglossStyles["status_error"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+	tm.lipglossStyles["status_warning"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true)
+	tm.lipglossStyles["status_info"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true)
+	tm.lipglossStyles["error"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+	tm.lipglossStyles["info"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true)
+	tm.lipglossStyles["code"] = tm.lipglossStyles["code"].Foreground(white).Background(black).BorderForeground(white)
+	tm.lipglossStyles["collapsible_header"] = tm.lipglossStyles["collapsible_header"].Foreground(white)
+	tm.lipglossStyles["table_header"] = tm.lipglossStyles["table_header"].Foreground(white)
+	tm.lipglossStyles["workflow"] = tm.lipglossStyles["workflow"].Foreground(white).BorderForeground(white)
+	tm.lipglossStyles["confirmation"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
+	tm.lipglossStyles["cancel"] = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+	tm.lipglossStyles["alternative"] = lipgloss.NewStyle().Foreground(white)
+	tm.lipglossStyles["primary"] = lipgloss.NewStyle().Foreground(white).Bold(true).Underline(true)
+}
+
 // buildLipglossStyles creates Lipgloss styles based on the current theme
 func (tm *ThemeManager) buildLipglossStyles() {
 	if tm.currentTheme == nil {
@@ -1048,12 +1347,30 @@ func (tm *ThemeManager) buildLipglossStyles() {
 	}
 
 	// Update styles with theme colors
-	tm.lipglossStyles["status_success"] = tm.lipglossStyles["status_success"].Foreground(lipgloss.Color(tm.currentTheme.Success))
-	tm.lipglossStyles["status_error"] = tm.lipglossStyles["status_error"].Foreground(lipgloss.Color(tm.currentTheme.Error))
-	tm.lipglossStyles["status_warning"] = tm.lipglossStyles["status_warning"].Foreground(lipgloss.Color(tm.currentTheme.Warning))
-	tm.lipglossStyles["status_info"] = tm.lipglossStyles["status_info"].Foreground(lipgloss.Color(tm.currentTheme.Info))
-	tm.lipglossStyles["error"] = tm.lipglossStyles["error"].Foreground(lipgloss.Color(tm.currentTheme.Error))
-	tm.lipglossStyles["info"] = tm.lipglossStyles["info"].Foreground(lipgloss.Color(tm.currentTheme.Info))
+	tm.applyThemeColor("status_success", tm.currentTheme.Success)
+	tm.applyThemeColor("status_error", tm.currentTheme.Error)
+	tm.applyThemeColor("status_warning", tm.currentTheme.Warning)
+	tm.applyThemeColor("status_info", tm.currentTheme.Info)
+	tm.applyThemeColor("error", tm.currentTheme.Error)
+	tm.applyThemeColor("info", tm.currentTheme.Info)
+}
+
+// applyThemeColor sets a style's foreground to a theme color. Colors that cannot be resolved are ignored,
+// as are, in high-contrast mode, colors that are not readable against a dark background.
+func (tm *ThemeManager) applyThemeColor(styleName, color string) {
+	color, err := ResolveColor(color)
+	if err != nil {
+		return
+	}
+
+	if tm.highContrast {
+		ratio, err := contrast.Ratio(color, contrast.TypicalDarkBackground)
+		if err != nil || ratio < contrast.HighRatio {
+			return
+		}
+	}
+
+	tm.lipglossStyles[styleName] = tm.lipglossStyles[styleName].Foreground(lipgloss.Color(color))
 }
 
 // Interface implementation methods for collapsible management
@@ -1072,3 +1389,42 @@ func (r *Renderer) ExpandAll() error {
 func (r *Renderer) CollapseAll() error {
 	return r.collapsibleManager.CollapseAll()
 }
+
+// SetDefaultCodeLanguage sets the language of code blocks that do not name one; empty detects it from the code
+func (r *Renderer) SetDefaultCodeLanguage(language string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.preferences.CodeLanguage = language
+}
+
+// SetShowLineNumbers sets whether code blocks that ask for line numbers show them
+func (r *Renderer) SetShowLineNumbers(enabled bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.preferences.ShowLineNumbers = enabled
+}
+
+// SetUnicodeSupport draws structure with Unicode box-drawing characters when enabled, and with ASCII
+// otherwise. Content rendered afterwards uses the new characters.
+func (r *Renderer) SetUnicodeSupport(enabled bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.glyphs = glyphsFor(enabled)
+}
+
+// UnicodeSupport reports whether structure is drawn with Unicode characters
+func (r *Renderer) UnicodeSupport() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.glyphs == &unicodeGlyphs
+}
+
+// SetHighContrast enables or disables high-contrast rendering
+func (r *Renderer) SetHighContrast(enabled bool) {
+	r.preferences.HighContrastMode = enabled
+	r.themeManager.SetHighContrast(enabled)
+}
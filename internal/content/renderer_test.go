@@ -0,0 +1,99 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// emptyBlockTypes are the block types that render nothing without content
+var emptyBlockTypes = []string{
+	"text", "code", "table", "collapsible", "progress", "list", "tree", "image", "ansi", "form",
+	"keyvalue", "reference", "checklist", "unknown",
+}
+
+// emptyContents are the forms of block content that hold nothing
+var emptyContents = map[string]interface{}{
+	"nil":          nil,
+	"empty string": "",
+	"blank string": " \n\t",
+	"empty object": map[string]interface{}{},
+	"empty array":  []interface{}{},
+}
+
+func TestEmptyBlocksRenderPlaceholder(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	for _, blockType := range emptyBlockTypes {
+		for name, content := range emptyContents {
+			block := map[string]interface{}{"type": blockType, "content": content}
+			rendered, err := r.RenderContent([]interface{}{block}, nil)
+			if err != nil {
+				t.Errorf("%s block with %s content failed to render: %v", blockType, name, err)
+				continue
+			}
+			if len(rendered) != 1 || ansi.Strip(rendered[0].Text) != emptyContentPlaceholder {
+				t.Errorf("%s block with %s content rendered %+v, want the placeholder", blockType, name, rendered)
+			}
+		}
+	}
+}
+
+func TestEmptyBlocksAreSkippedBesideContent(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	rendered, err := r.RenderContent([]interface{}{
+		map[string]interface{}{"type": "code", "content": nil},
+		map[string]interface{}{"type": "text", "content": "Deployed"},
+		map[string]interface{}{"type": "table", "content": map[string]interface{}{}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("RenderContent failed: %v", err)
+	}
+	if len(rendered) != 1 || rendered[0].Text != "Deployed" {
+		t.Errorf("rendered %+v, want only the text block", rendered)
+	}
+}
+
+func TestEmptySeparatorDrawsDefaultSpacer(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	for name, content := range emptyContents {
+		rendered, err := r.RenderContent([]interface{}{map[string]interface{}{"type": "separator", "content": content}}, nil)
+		if err != nil {
+			t.Errorf("separator with %s content failed to render: %v", name, err)
+			continue
+		}
+		if len(rendered) != 1 || strings.Contains(rendered[0].Text, emptyContentPlaceholder) || ansi.StringWidth(rendered[0].Text) != defaultContentWidth {
+			t.Errorf("separator with %s content rendered %+v, want a spacer across the content width", name, rendered)
+		}
+	}
+}
+
+func TestEmptyResponseContentRendersPlaceholder(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	for name, content := range map[string]interface{}{"nil": nil, "empty array": []interface{}{}} {
+		rendered, err := r.RenderContent(content, nil)
+		if err != nil {
+			t.Errorf("%s content failed to render: %v", name, err)
+			continue
+		}
+		if len(rendered) != 1 || ansi.Strip(rendered[0].Text) != emptyContentPlaceholder {
+			t.Errorf("%s content rendered %+v, want the placeholder", name, rendered)
+		}
+	}
+}
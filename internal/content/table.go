@@ -0,0 +1,150 @@
+// Package content implements table column sizing and cell truncation for the Universal Application Console.
+// Columns keep their natural width when the table fits the content area. When it does not, the excess is
+// taken from each column in proportion to how far it is above the minimum width, so no single column is
+// sacrificed. A column can be capped with TableContent.ColumnWidth, and cells that do not fit are shortened
+// at the tail, head, or middle as chosen per column with TableContent.Truncate. All widths are display
+// columns as measured by go-runewidth, so CJK and emoji cells, which take two columns per character,
+// line up with ASCII ones.
+package content
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Table column sizing
+const (
+	minTableColumnWidth = 8 // Columns narrower than this are padded to it
+	tableEllipsis       = "..."
+)
+
+// Truncation sides for TableContent.Truncate
+const (
+	TruncateTail   = "tail"   // "/very/long/pa..."
+	TruncateHead   = "head"   // ".../path/file.go"
+	TruncateMiddle = "middle" // "/very/.../file.go"
+)
+
+// tableChromeWidth is the width of a row's borders and cell separators: "│ " + " │ " between cells + " │"
+func tableChromeWidth(columns int) int {
+	return 3*columns + 1
+}
+
+// fitColumnWidths shrinks columns so the table fits in available columns. Each column gives up a
+// share of the excess proportional to its width above the minimum; columns never shrink below it.
+func fitColumnWidths(widths []int, available int) []int {
+	budget := available - tableChromeWidth(len(widths))
+
+	total, slack := 0, 0
+	for _, width := range widths {
+		total += width
+		if width > minTableColumnWidth {
+			slack += width - minTableColumnWidth
+		}
+	}
+
+	excess := total - budget
+	if excess <= 0 || slack == 0 {
+		return widths
+	}
+	if excess >= slack {
+		for i := range widths {
+			if widths[i] > minTableColumnWidth {
+				widths[i] = minTableColumnWidth
+			}
+		}
+		return widths
+	}
+
+	removed := 0
+	for i, width := range widths {
+		if width > minTableColumnWidth {
+			share := excess * (width - minTableColumnWidth) / slack
+			widths[i] -= share
+			removed += share
+		}
+	}
+
+	// Rounding leaves a few columns of excess; take them from the widest columns
+	for removed < excess {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		widths[widest]--
+		removed++
+	}
+
+	return widths
+}
+
+// truncateCell shortens text to at most width display columns, replacing the removed part with an
+// ellipsis at the chosen side. Multibyte and wide characters are never split.
+func truncateCell(text string, width int, side string) string {
+	if runewidth.StringWidth(text) <= width {
+		return text
+	}
+
+	keep := width - len(tableEllipsis)
+	if keep <= 0 {
+		return prefixWithin(text, width)
+	}
+
+	switch side {
+	case TruncateHead:
+		return tableEllipsis + suffixWithin(text, keep)
+	case TruncateMiddle:
+		head := prefixWithin(text, (keep+1)/2)
+		tail := suffixWithin(text, keep-runewidth.StringWidth(head))
+		return head + tableEllipsis + tail
+	default:
+		return prefixWithin(text, keep) + tableEllipsis
+	}
+}
+
+// prefixWithin returns the longest prefix of text that is at most width display columns wide
+func prefixWithin(text string, width int) string {
+	used := 0
+	for i, r := range text {
+		used += runewidth.RuneWidth(r)
+		if used > width {
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// suffixWithin returns the longest suffix of text that is at most width display columns wide
+func suffixWithin(text string, width int) string {
+	runes := []rune(text)
+	used := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		used += runewidth.RuneWidth(runes[i])
+		if used > width {
+			return string(runes[i+1:])
+		}
+	}
+	return text
+}
+
+// padCell pads text with spaces to width display columns
+func padCell(text string, width int) string {
+	if padding := width - runewidth.StringWidth(text); padding > 0 {
+		return text + strings.Repeat(" ", padding)
+	}
+	return text
+}
+
+// columnTruncation returns the truncation side for a column, defaulting to the tail
+func columnTruncation(table *TableContent, column int) string {
+	if column < len(table.Truncate) {
+		switch side := strings.ToLower(table.Truncate[column]); side {
+		case TruncateHead, TruncateMiddle:
+			return side
+		}
+	}
+	return TruncateTail
+}
@@ -0,0 +1,145 @@
+package content
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncateCellSides(t *testing.T) {
+	path := "/very/long/path/to/file.go"
+	tests := []struct {
+		side string
+		want string
+	}{
+		{TruncateTail, "/very/long/pa..."},
+		{TruncateHead, "...h/to/file.go"},
+		{TruncateMiddle, "/very/l...file.go"},
+	}
+	for _, tt := range tests {
+		width := runewidth.StringWidth(tt.want)
+		if got := truncateCell(path, width, tt.side); got != tt.want {
+			t.Errorf("truncateCell(%q, %d, %s) = %q, want %q", path, width, tt.side, got, tt.want)
+		}
+	}
+
+	if got := truncateCell("short", 10, TruncateMiddle); got != "short" {
+		t.Errorf("truncateCell shortened a cell that fits: %q", got)
+	}
+}
+
+func TestTruncateCellMiddleKeepsMultibyteCharactersWhole(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		head  string
+		tail  string
+	}{
+		// Two-byte Latin characters, one column each
+		{"/données/résumé/année/été.txt", 17, "/donnée", "été.txt"},
+		// Three-byte CJK characters, two columns each; an odd budget cannot take half a character
+		{"/home/用户/文档/项目/说明.md", 16, "/home/", "说明.md"},
+		// Four-byte emoji, two columns each; the column the head cannot use goes to the tail
+		{"🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀", 9, "🚀", "🚀🚀"},
+	}
+	for _, tt := range tests {
+		got := truncateCell(tt.text, tt.width, TruncateMiddle)
+		if !utf8.ValidString(got) {
+			t.Errorf("truncateCell(%q) split a character: %q", tt.text, got)
+		}
+		if width := runewidth.StringWidth(got); width > tt.width {
+			t.Errorf("truncateCell(%q, %d) is %d columns wide: %q", tt.text, tt.width, width, got)
+		}
+		head, tail, found := strings.Cut(got, tableEllipsis)
+		if !found {
+			t.Errorf("truncateCell(%q) has no ellipsis: %q", tt.text, got)
+			continue
+		}
+		if head != tt.head || tail != tt.tail {
+			t.Errorf("truncateCell(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.head+tableEllipsis+tt.tail)
+		}
+	}
+}
+
+func TestFitColumnWidthsSharesExcess(t *testing.T) {
+	// 40 + 20 + 8 columns and 10 of chrome need 78; at 58 the 20 over budget come from the two wide columns
+	got := fitColumnWidths([]int{40, 20, 8}, 58)
+	if got[2] != 8 {
+		t.Errorf("the narrowest column shrank to %d, want it left at 8", got[2])
+	}
+	if total := got[0] + got[1] + got[2] + tableChromeWidth(3); total != 58 {
+		t.Errorf("fitted widths %v take %d columns, want 58", got, total)
+	}
+	if got[1] >= 20 || got[0] >= 40 {
+		t.Errorf("fitted widths %v, want both wide columns to give up width", got)
+	}
+
+	if got := fitColumnWidths([]int{10, 12}, 80); got[0] != 10 || got[1] != 12 {
+		t.Errorf("fitted widths of a table that fits = %v, want them unchanged", got)
+	}
+}
+
+func TestTableAlignsMixedASCIIAndCJKRows(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	table := &TableContent{
+		Headers: []string{"Name", "City", "Note"},
+		Rows: [][]string{
+			{"Alice", "London", "ok"},
+			{"王小明", "北京", "完成"},
+			{"Bob 🚀", "東京都千代田区丸の内", "-"},
+		},
+	}
+	lines := strings.Split(ansi.Strip(r.formatTable(table)), "\n")
+
+	// Every row, separator included, is as wide as the header, and its column bars fall on the same columns
+	want := barColumns(lines[0], r.glyphs.tableVertical)
+	for i, line := range lines {
+		if width := runewidth.StringWidth(line); width != runewidth.StringWidth(lines[0]) {
+			t.Errorf("line %d is %d columns wide, want %d: %q", i+1, width, runewidth.StringWidth(lines[0]), line)
+		}
+		if i == 1 {
+			continue
+		}
+		if got := barColumns(line, r.glyphs.tableVertical); !equalInts(got, want) {
+			t.Errorf("line %d has bars at columns %v, want %v: %q", i+1, got, want, line)
+		}
+	}
+
+	// The CJK city column is sized by its display width, 20 columns, not its 30 bytes
+	if widths := r.calculateColumnWidths(table); widths[1] != 20 {
+		t.Errorf("city column is %d columns wide, want 20", widths[1])
+	}
+}
+
+// barColumns returns the display columns at which bar appears in line
+func barColumns(line, bar string) []int {
+	var columns []int
+	column := 0
+	for _, r := range line {
+		if string(r) == bar {
+			columns = append(columns, column)
+		}
+		column += runewidth.RuneWidth(r)
+	}
+	return columns
+}
+
+// equalInts reports whether two slices hold the same values in order
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+// Package content implements interactive tree navigation for the Universal Application Console.
+// This file tracks the expansion and selection state of each rendered tree so that the
+// application model can move a focus cursor between nodes, expand and collapse branches,
+// and select nodes, re-rendering only the affected tree.
+package content
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// TreeManager holds the content and interaction state of every rendered tree
+type TreeManager struct {
+	trees map[string]*TreeContent
+	mutex sync.RWMutex
+}
+
+// NewTreeManager creates an empty tree manager
+func NewTreeManager() *TreeManager {
+	return &TreeManager{
+		trees: make(map[string]*TreeContent),
+	}
+}
+
+// RegisterTree stores a tree under its rendered content ID and seeds its state from the node flags and options
+func (tm *TreeManager) RegisterTree(treeID string, tree *TreeContent) error {
+	if treeID == "" {
+		return fmt.Errorf("tree ID cannot be empty")
+	}
+	if tree == nil {
+		return fmt.Errorf("tree cannot be nil")
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	// Explicit state from the application takes precedence over per-node flags
+	if tree.State.ExpandedNodes == nil && tree.State.SelectedNodes == nil {
+		walkTreeNodes(&tree.Root, func(node *TreeNode) {
+			expanded := node.Expanded
+			if tree.Options.ExpandAll {
+				expanded = true
+			} else if tree.Options.CollapseAll {
+				expanded = false
+			}
+			if expanded {
+				tree.State.ExpandedNodes = append(tree.State.ExpandedNodes, node.ID)
+			}
+			if node.Selected {
+				tree.State.SelectedNodes = append(tree.State.SelectedNodes, node.ID)
+			}
+		})
+	}
+
+	tm.trees[treeID] = tree
+	return nil
+}
+
+// ToggleNode expands a collapsed node or collapses an expanded one
+func (tm *TreeManager) ToggleNode(treeID, nodeID string) (*TreeContent, error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	tree, node, err := tm.findNode(treeID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(node.Children) == 0 {
+		return tree, nil
+	}
+
+	tree.State.ExpandedNodes = toggleID(tree.State.ExpandedNodes, nodeID)
+	return tree, nil
+}
+
+// SelectNode toggles a node's selection according to the tree's select mode
+func (tm *TreeManager) SelectNode(treeID, nodeID string) (*TreeContent, error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	tree, node, err := tm.findNode(treeID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tree.Options.SelectMode == "none" || !node.Selectable {
+		return nil, fmt.Errorf("node '%s' is not selectable", node.Label)
+	}
+
+	if tree.Options.SelectMode == "multiple" {
+		tree.State.SelectedNodes = toggleID(tree.State.SelectedNodes, nodeID)
+	} else if containsID(tree.State.SelectedNodes, nodeID) {
+		tree.State.SelectedNodes = nil
+	} else {
+		tree.State.SelectedNodes = []string{nodeID}
+	}
+
+	return tree, nil
+}
+
+// findNode looks up a registered tree and one of its nodes (caller must hold lock)
+func (tm *TreeManager) findNode(treeID, nodeID string) (*TreeContent, *TreeNode, error) {
+	tree, exists := tm.trees[treeID]
+	if !exists {
+		return nil, nil, fmt.Errorf("tree '%s' not found", treeID)
+	}
+
+	var found *TreeNode
+	walkTreeNodes(&tree.Root, func(node *TreeNode) {
+		if found == nil && node.ID == nodeID {
+			found = node
+		}
+	})
+	if found == nil {
+		return nil, nil, fmt.Errorf("node '%s' not found in tree", nodeID)
+	}
+
+	return tree, found, nil
+}
+
+// walkTreeNodes visits a node and all of its descendants depth-first
+func walkTreeNodes(node *TreeNode, visit func(*TreeNode)) {
+	visit(node)
+	for i := range node.Children {
+		walkTreeNodes(&node.Children[i], visit)
+	}
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleID adds id to ids, or removes it if already present
+func toggleID(ids []string, id string) []string {
+	for i, existing := range ids {
+		if existing == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return append(ids, id)
+}
+
+// ToggleTreeNode expands or collapses a tree node and returns the re-rendered tree
+func (r *Renderer) ToggleTreeNode(treeID, nodeID string) (*interfaces.RenderedContent, error) {
+	tree, err := r.treeManager.ToggleNode(treeID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return r.renderTree(treeID, tree), nil
+}
+
+// SelectTreeNode selects or deselects a tree node and returns the re-rendered tree
+func (r *Renderer) SelectTreeNode(treeID, nodeID string) (*interfaces.RenderedContent, error) {
+	tree, err := r.treeManager.SelectNode(treeID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return r.renderTree(treeID, tree), nil
+}
+
+// renderTree formats a tree with its current state, listing the visible nodes line by line
+func (r *Renderer) renderTree(treeID string, tree *TreeContent) *interfaces.RenderedContent {
+	r.treeManager.mutex.RLock()
+	defer r.treeManager.mutex.RUnlock()
+
+	var lines []string
+	var nodes []interfaces.RenderedTreeNode
+	r.formatTreeNode(tree, &tree.Root, "", true, 0, &lines, &nodes)
+
+	return &interfaces.RenderedContent{
+		Text:      strings.Join(lines, "\n"),
+		Focusable: true,
+		ID:        treeID,
+		TreeNodes: nodes,
+	}
+}
@@ -75,6 +75,38 @@ type TextContent struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// KeyValueContent represents labeled fields shown as "Label: value" pairs aligned on their labels, in order
+type KeyValueContent struct {
+	Fields []KeyValueField `json:"fields"`
+}
+
+// KeyValueField represents one labeled value, colored by its status when it has one
+type KeyValueField struct {
+	Label  string      `json:"label"`
+	Value  interface{} `json:"value"`
+	Status string      `json:"status,omitempty"`
+}
+
+// ReferenceContent represents content the application serves separately, fetched when it is expanded
+type ReferenceContent struct {
+	URL     string `json:"url"`     // Absolute URL or path on the application's host
+	Summary string `json:"summary"` // What the content holds, e.g. "Build log (4,812 lines)"
+}
+
+// ChecklistContent represents the steps of a multi-step operation, each marked with its status
+type ChecklistContent struct {
+	Title    string          `json:"title,omitempty"`
+	Steps    []ChecklistStep `json:"steps"`
+	Workflow string          `json:"workflow,omitempty"` // ID of a workflow whose step sets the steps' statuses
+}
+
+// ChecklistStep represents one step of a checklist
+type ChecklistStep struct {
+	Label  string `json:"label"`
+	Status string `json:"status,omitempty"` // "done", "current", "pending", or "failed"; pending when empty
+	Detail string `json:"detail,omitempty"`
+}
+
 // CollapsibleContent represents expandable content sections with titles
 type CollapsibleContent struct {
 	Title       string                    `json:"title"`
@@ -104,7 +136,8 @@ type TableContent struct {
 	Headers     []string      `json:"headers"`
 	Rows        [][]string    `json:"rows"`
 	Alignment   []string      `json:"alignment,omitempty"`   // Per-column alignment
-	ColumnWidth []int         `json:"columnWidth,omitempty"` // Per-column width hints
+	ColumnWidth []int         `json:"columnWidth,omitempty"` // Per-column maximum widths; 0 leaves a column uncapped
+	Truncate    []string      `json:"truncate,omitempty"`    // Per-column truncation side: "tail" (default), "head", "middle"
 	Zebra       bool          `json:"zebra"`                 // Alternating row colors
 	Borders     bool          `json:"borders"`               // Show table borders
 	Sortable    []bool        `json:"sortable,omitempty"`    // Per-column sortability
@@ -210,6 +243,7 @@ type ProgressContent struct {
 	ShowETA       bool              `json:"showETA"`
 	Details       ProgressDetails   `json:"details,omitempty"`
 	Animation     ProgressAnimation `json:"animation"`
+	OperationID   string            `json:"operationId,omitempty"` // Operation whose progress the console polls while it runs
 }
 
 // ProgressDetails provides detailed progress information
@@ -231,6 +265,40 @@ type ProgressAnimation struct {
 	Enabled   bool          `json:"enabled"`
 }
 
+// ImageContent represents an image supplied inline as base64 data or by URL
+type ImageContent struct {
+	Data   string `json:"data,omitempty"`   // Base64-encoded PNG, JPEG, or GIF
+	URL    string `json:"url,omitempty"`    // Remote location; shown as a placeholder, never fetched
+	Alt    string `json:"alt,omitempty"`    // Description shown in the placeholder
+	Width  int    `json:"width,omitempty"`  // Pixel width, if known without decoding
+	Height int    `json:"height,omitempty"` // Pixel height, if known without decoding
+}
+
+// FormContent represents an interactive form; submitting it sends the field values as the context of an action
+type FormContent struct {
+	Title       string      `json:"title,omitempty"`
+	Command     string      `json:"command"`               // Action command sent on submit
+	SubmitLabel string      `json:"submitLabel,omitempty"` // Submit button label; defaults to "Submit"
+	Fields      []FormField `json:"fields"`
+}
+
+// FormField represents one input of a form
+type FormField struct {
+	Name        string      `json:"name"`                  // Context key the value is sent under
+	Label       string      `json:"label,omitempty"`       // Defaults to the name
+	Type        string      `json:"type"`                  // "text", "select", "checkbox"
+	Options     []string    `json:"options,omitempty"`     // Choices of a select field
+	Default     interface{} `json:"default,omitempty"`     // Initial text, option, or checkbox state
+	Placeholder string      `json:"placeholder,omitempty"` // Hint shown in an empty text field
+	Required    bool        `json:"required,omitempty"`    // Submitting is refused while the field is empty
+}
+
+// AnsiContent represents pre-formatted text containing ANSI escape sequences
+type AnsiContent struct {
+	Text  string `json:"text"`
+	Strip bool   `json:"strip,omitempty"` // Remove escape sequences and show plain text
+}
+
 // ListContent represents ordered or unordered lists with nesting support
 type ListContent struct {
 	Items    []ListItem `json:"items"`
@@ -239,6 +307,12 @@ type ListContent struct {
 	Nested   bool       `json:"nested"`             // Indicates if list contains nested items
 	Compact  bool       `json:"compact"`            // Compact rendering style
 	MaxDepth int        `json:"maxDepth,omitempty"` // Maximum nesting depth
+
+	// Pagination: a list with a continuation token shows only its first page, and the console
+	// offers a "Show more" action that fetches the next page and appends it to the list
+	NextToken   string `json:"nextToken,omitempty"`   // Continuation token for the next page
+	NextCommand string `json:"nextCommand,omitempty"` // Action command that fetches the next page; defaults to the original command
+	Total       int    `json:"total,omitempty"`       // Total number of items across all pages, if known
 }
 
 // ListItem represents individual items within lists
@@ -294,22 +368,14 @@ type TreeState struct {
 // SeparatorContent represents visual dividers between content sections
 type SeparatorContent struct {
 	Style     string `json:"style"`           // "line", "space", "dots", "stars"
-	Length    int    `json:"length"`          // Length in characters
+	Length    int    `json:"length"`          // Length in display columns; 0 spans the content width
 	Character string `json:"character"`       // Custom separator character
 	Centered  bool   `json:"centered"`        // Center the separator
 	Label     string `json:"label,omitempty"` // Optional label within separator
 }
 
-// StatusContent represents status indicators with icons and colors
-type StatusContent struct {
-	Status    string    `json:"status"` // "success", "error", "warning", "info", "pending"
-	Message   string    `json:"message"`
-	Icon      string    `json:"icon,omitempty"`
-	Details   string    `json:"details,omitempty"`
-	Code      string    `json:"code,omitempty"` // Error/status code
-	Timestamp time.Time `json:"timestamp,omitempty"`
-	Severity  string    `json:"severity,omitempty"` // "low", "medium", "high", "critical"
-}
+// StatusContent represents status indicators with icons and colors; it is shared with response warnings
+type StatusContent = interfaces.StatusContent
 
 // RenderingContext provides context information for content rendering operations
 type RenderingContext struct {
@@ -334,6 +400,7 @@ type RenderingPreferences struct {
 	HighContrastMode  bool   `json:"highContrastMode"`
 	MaxTableRows      int    `json:"maxTableRows"`
 	CodeTheme         string `json:"codeTheme"`
+	CodeLanguage      string `json:"codeLanguage"` // Language for code blocks that do not name one
 	DateFormat        string `json:"dateFormat"`
 	TimeFormat        string `json:"timeFormat"`
 }
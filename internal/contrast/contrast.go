@@ -0,0 +1,82 @@
+// Package contrast implements color contrast measurement for the Universal Application Console.
+// It computes WCAG relative luminance and contrast ratios for hex theme colors, which are used both by
+// the configuration to validate themes and by the renderer to filter unreadable colors in high-contrast
+// mode, so neither has to depend on the other for it.
+package contrast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Contrast thresholds and reference backgrounds used for theme color checks
+const (
+	// MinimumRatio is the WCAG AA threshold for large text, used for theme warnings
+	MinimumRatio = 3.0
+
+	// HighRatio is the WCAG AA threshold for normal text, required in high-contrast mode
+	HighRatio = 4.5
+
+	// TypicalDarkBackground approximates the background of common dark terminal themes
+	TypicalDarkBackground = "#1E1E1E"
+)
+
+// Ratio returns the WCAG contrast ratio between two hex colors, from 1 to 21
+func Ratio(foreground, background string) (float64, error) {
+	fgLuminance, err := relativeLuminance(foreground)
+	if err != nil {
+		return 0, fmt.Errorf("invalid foreground color: %w", err)
+	}
+
+	bgLuminance, err := relativeLuminance(background)
+	if err != nil {
+		return 0, fmt.Errorf("invalid background color: %w", err)
+	}
+
+	lighter := math.Max(fgLuminance, bgLuminance)
+	darker := math.Min(fgLuminance, bgLuminance)
+	return (lighter + 0.05) / (darker + 0.05), nil
+}
+
+// relativeLuminance computes the WCAG relative luminance of a hex color
+func relativeLuminance(color string) (float64, error) {
+	r, g, b, err := ParseHex(color)
+	if err != nil {
+		return 0, err
+	}
+
+	linearize := func(channel float64) float64 {
+		if channel <= 0.03928 {
+			return channel / 12.92
+		}
+		return math.Pow((channel+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b), nil
+}
+
+// ParseHex converts "#RGB" or "#RRGGBB" into channel values between 0 and 1
+func ParseHex(color string) (float64, float64, float64, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+
+	// Expand shorthand notation such as "#fa0"
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if len(hex) != 6 {
+		return 0, 0, 0, fmt.Errorf("'%s' is not a hex color", color)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("'%s' is not a hex color", color)
+	}
+
+	r := float64((value>>16)&0xFF) / 255
+	g := float64((value>>8)&0xFF) / 255
+	b := float64(value&0xFF) / 255
+	return r, g, b, nil
+}
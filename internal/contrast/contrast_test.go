@@ -0,0 +1,36 @@
+package contrast
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		foreground, background string
+		want                   float64
+	}{
+		{"#FFFFFF", "#000000", 21},
+		{"#000", "#fff", 21},
+		{"#777777", "#777777", 1},
+		{"#767676", "#FFFFFF", 4.54},
+	}
+
+	for _, tt := range tests {
+		got, err := Ratio(tt.foreground, tt.background)
+		if err != nil {
+			t.Fatalf("Ratio(%q, %q) failed: %v", tt.foreground, tt.background, err)
+		}
+		if math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("Ratio(%q, %q) = %.2f, want %.2f", tt.foreground, tt.background, got, tt.want)
+		}
+	}
+}
+
+func TestParseHexRejectsOtherColors(t *testing.T) {
+	for _, color := range []string{"red", "12", "#12345", "#ggg"} {
+		if _, _, _, err := ParseHex(color); err == nil {
+			t.Errorf("ParseHex(%q) succeeded, want an error", color)
+		}
+	}
+}
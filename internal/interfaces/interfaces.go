@@ -4,17 +4,49 @@ package interfaces
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
 // Profile represents a complete configuration profile for connecting to an application
 type Profile struct {
-	Name          string            `yaml:"name"`
-	Host          string            `yaml:"host"`
-	Theme         string            `yaml:"theme"`
-	Confirmations bool              `yaml:"confirmations"`
-	Auth          AuthConfig        `yaml:"auth"`
-	Metadata      map[string]string `yaml:"metadata,omitempty"`
+	Name             string            `yaml:"name"`
+	Host             string            `yaml:"host"`
+	Theme            string            `yaml:"theme"`
+	Confirmations    bool              `yaml:"confirmations"`
+	HighContrast     bool              `yaml:"highContrast,omitempty"`
+	MaxHistory       int               `yaml:"maxHistory,omitempty"`       // In-memory history entries; 0 uses the default
+	HistorySpill     string            `yaml:"historySpill,omitempty"`     // Session log for entries beyond MaxHistory
+	RateLimit        float64           `yaml:"rateLimit,omitempty"`        // Maximum requests per second; 0 disables throttling
+	RateBurst        int               `yaml:"rateBurst,omitempty"`        // Requests allowed in a burst before throttling
+	ProgressPollRate float64           `yaml:"progressPollRate,omitempty"` // Progress polls per second across all running operations; 0 uses the default
+	CircuitThreshold int               `yaml:"circuitThreshold,omitempty"` // Consecutive failures before failing fast; 0 uses the default
+	CircuitCooldown  int               `yaml:"circuitCooldown,omitempty"`  // Seconds to fail fast before testing recovery; 0 uses the default
+	KeepAlive        int               `yaml:"keepAlive,omitempty"`        // Seconds without traffic before a keep-alive probe; 0 disables
+	IdleTimeout      int               `yaml:"idleTimeout,omitempty"`      // Minutes without requests before closing the connection; 0 disables
+	CommandTimeout   int               `yaml:"commandTimeout,omitempty"`   // Seconds to wait for a command or action response; 0 uses --timeout
+	PoolSize         int               `yaml:"poolSize,omitempty"`         // Idle connections kept open to the application; 0 uses the default
+	MaxConnections   int               `yaml:"maxConnections,omitempty"`   // Concurrent connections to the application; 0 means no limit
+	MaxInFlight      int               `yaml:"maxInFlight,omitempty"`      // Commands awaiting a response at once, later ones are queued; 0 means no limit
+	DisableHTTP2     bool              `yaml:"disableHTTP2,omitempty"`     // Use HTTP/1.1 even when the application offers HTTP/2 over TLS
+	CodeLanguage     string            `yaml:"codeLanguage,omitempty"`     // Language of code blocks that do not name one; empty detects it
+	ShowTimestamps   bool              `yaml:"showTimestamps,omitempty"`   // Show when each history entry was sent; toggled with /timestamps
+	HideLineNumbers  bool              `yaml:"hideLineNumbers,omitempty"`  // Leave line numbers out of code blocks; toggled with /linenumbers
+	MaxContentWidth  int               `yaml:"maxContentWidth,omitempty"`  // Widest the history pane grows, centered on wider terminals; 0 fills the terminal
+	UserLabel        string            `yaml:"userLabel,omitempty"`        // Prefix of commands in history; defaults to "YOU>"
+	AppLabel         string            `yaml:"appLabel,omitempty"`         // Prefix of responses, where {app} is the application name; defaults to "APP>"
+	UserLabelColor   string            `yaml:"userLabelColor,omitempty"`   // Color of the command prefix, e.g. "#89B4FA" or "12"
+	AppLabelColor    string            `yaml:"appLabelColor,omitempty"`    // Color of the response prefix
+	ShellRedirection bool              `yaml:"shellRedirection,omitempty"` // Allow "cmd > file" and "cmd | program" output redirection
+	CacheCommands    []string          `yaml:"cacheCommands,omitempty"`    // Commands, by first word, whose responses are reused for CacheTTL seconds
+	CacheTTL         int               `yaml:"cacheTTL,omitempty"`         // Seconds to reuse responses of CacheCommands; 0 uses the default
+	Confirm          map[string]bool   `yaml:"confirm,omitempty"`          // Per action type, whether selecting it asks for confirmation
+	Variables        map[string]string `yaml:"variables,omitempty"`        // Context variables sent with every request, changed with /set
+	HideBlocks       []string          `yaml:"hideBlocks,omitempty"`       // Content block types left out of responses; shown again with /show-all
+	HideStatuses     []string          `yaml:"hideStatuses,omitempty"`     // Status levels of content blocks left out of responses, e.g. "info"
+	ComplexityBudget int               `yaml:"complexityBudget,omitempty"` // Complexity score above which responses are truncated until expanded; 0 renders everything
+	Auth             AuthConfig        `yaml:"auth"`
+	Metadata         map[string]string `yaml:"metadata,omitempty"`
 }
 
 // AuthConfig represents authentication configuration for a profile
@@ -25,11 +57,12 @@ type AuthConfig struct {
 
 // Theme represents visual styling configuration
 type Theme struct {
-	Name    string `yaml:"name"`
-	Success string `yaml:"success"`
-	Error   string `yaml:"error"`
-	Warning string `yaml:"warning"`
-	Info    string `yaml:"info"`
+	Name      string `yaml:"name"`
+	Success   string `yaml:"success"`
+	Error     string `yaml:"error"`
+	Warning   string `yaml:"warning"`
+	Info      string `yaml:"info"`
+	CodeTheme string `yaml:"codeTheme,omitempty"` // Syntax highlighting style; empty keeps the current style
 }
 
 // RegisteredApp represents an application registered in the Console Menu
@@ -54,6 +87,15 @@ type ConfigManager interface {
 	// LoadTheme retrieves theme configuration by name
 	LoadTheme(name string) (*Theme, error)
 	
+	// SaveTheme persists a theme to the configuration file
+	SaveTheme(theme *Theme) error
+	
+	// DeleteTheme removes a custom theme from the configuration file
+	DeleteTheme(name string) error
+	
+	// ListThemes returns all available theme names
+	ListThemes() ([]string, error)
+	
 	// GetRegisteredApps returns all registered applications
 	GetRegisteredApps() ([]RegisteredApp, error)
 	
@@ -65,6 +107,9 @@ type ConfigManager interface {
 	
 	// GetConfigPath returns the path to the configuration file
 	GetConfigPath() string
+	
+	// InvalidateCache discards the cached configuration so the file is read again, e.g. after it was edited
+	InvalidateCache()
 }
 
 // SpecResponse represents the handshake response from a Compliant Application
@@ -73,11 +118,20 @@ type SpecResponse struct {
 	AppVersion      string            `json:"appVersion"`
 	ProtocolVersion string            `json:"protocolVersion"`
 	Features        map[string]bool   `json:"features"`
+	Commands        []CommandInfo     `json:"commands,omitempty"` // Optional command catalog, used for completion and /commands
+}
+
+// CommandInfo describes one command in the catalog an application publishes in its handshake
+type CommandInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Args        string `json:"args,omitempty"` // Argument hint, e.g. "<service> [--force]"
 }
 
 // CommandRequest represents a command execution request
 type CommandRequest struct {
-	Command string `json:"command"`
+	Command string                 `json:"command"`
+	Context map[string]interface{} `json:"context,omitempty"`
 }
 
 // ActionRequest represents an action execution request
@@ -95,8 +149,9 @@ type SuggestRequest struct {
 
 // ProgressRequest represents a request for operation progress
 type ProgressRequest struct {
-	OperationID   string `json:"operationId"`
-	RequestUpdate bool   `json:"requestUpdate"`
+	OperationID   string   `json:"operationId"`
+	RequestUpdate bool     `json:"requestUpdate"`
+	OperationIDs  []string `json:"operationIds,omitempty"` // Further operations to report in one response, for applications advertising "progress-batch"
 }
 
 // CancelRequest represents a request to cancel an operation
@@ -120,20 +175,28 @@ type ContentBlock struct {
 	Label     string                 `json:"label,omitempty"`
 }
 
+// ActionTypes lists the action types defined by the protocol
+var ActionTypes = []string{"primary", "confirmation", "cancel", "info", "alternative"}
+
+// StatusLevels lists the status levels of content blocks defined by the protocol
+var StatusLevels = []string{"success", "error", "warning", "info", "pending"}
+
 // Action represents an executable action from the Actions Pane
 type Action struct {
 	Name string `json:"name"`
 	Command string `json:"command"`
-	Type string `json:"type"` // "primary", "confirmation", "cancel", "info", "alternative"
+	Type string `json:"type"` // One of ActionTypes
 	Icon string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"` // Color overriding the type's, e.g. "#F38BA8", "9", or "red"
 }
 
 // Workflow represents multi-step operation context
 type Workflow struct {
-	ID         string `json:"id"`
-	Step       int    `json:"step"`
-	TotalSteps int    `json:"totalSteps"`
-	Title      string `json:"title"`
+	ID         string   `json:"id"`
+	Step       int      `json:"step"`
+	TotalSteps int      `json:"totalSteps"`
+	Title      string   `json:"title"`
+	Steps      []string `json:"steps,omitempty"` // Step labels, in order; shown as a checklist when present
 }
 
 // CommandResponse represents a structured response from command execution
@@ -145,6 +208,19 @@ type CommandResponse struct {
 	Actions             []Action  `json:"actions,omitempty"`
 	Workflow            *Workflow `json:"workflow,omitempty"`
 	RequiresConfirmation bool     `json:"requiresConfirmation,omitempty"`
+	Warnings            []StatusContent `json:"warnings,omitempty"` // Problems that did not stop the command, shown above its content
+	CacheTTL            int       `json:"cacheTtl,omitempty"` // Seconds the response may be reused for the same command; 0 disables caching
+}
+
+// StatusContent represents status indicators with icons and colors
+type StatusContent struct {
+	Status    string    `json:"status"` // "success", "error", "warning", "info", "pending"
+	Message   string    `json:"message"`
+	Icon      string    `json:"icon,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	Code      string    `json:"code,omitempty"` // Error/status code
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Severity  string    `json:"severity,omitempty"` // "low", "medium", "high", "critical"
 }
 
 // SuggestionItem represents a single command suggestion
@@ -170,6 +246,7 @@ type ProgressResponse struct {
 		Total     int    `json:"total"`
 		Current   string `json:"current"`
 	} `json:"details,omitempty"`
+	Operations map[string]ProgressResponse `json:"operations,omitempty"` // Progress of each further operation of a batched request, by ID
 }
 
 // CancelResponse represents the result of canceling an operation
@@ -189,6 +266,9 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
+// PushHandler receives messages the application sends without a matching request, e.g. progress updates
+type PushHandler func(messageType string, payload json.RawMessage)
+
 // ProtocolClient handles HTTP communication with Compliant Applications
 type ProtocolClient interface {
 	// Connect establishes connection and performs handshake with the application
@@ -209,6 +289,9 @@ type ProtocolClient interface {
 	// CancelOperation requests operation cancellation
 	CancelOperation(ctx context.Context, request CancelRequest) (*CancelResponse, error)
 	
+	// FetchReference fetches the content of a reference block from the application's host
+	FetchReference(ctx context.Context, url string) (interface{}, error)
+	
 	// IsConnected returns whether the client is currently connected
 	IsConnected() bool
 	
@@ -217,6 +300,28 @@ type ProtocolClient interface {
 	
 	// GetLastError returns the last communication error
 	GetLastError() error
+	
+	// GetAppInfo returns the application details from the last handshake, or nil when not connected
+	GetAppInfo() *SpecResponse
+	
+	// SetRateLimit throttles outgoing requests; a rate of zero or less disables throttling
+	SetRateLimit(requestsPerSecond float64, burst int)
+	
+	// SetCircuitBreaker configures how many consecutive failures open the circuit and for how long
+	SetCircuitBreaker(failureThreshold int, cooldown time.Duration)
+	
+	// CircuitState returns the circuit breaker state: "closed", "open", or "half-open"
+	CircuitState() string
+	
+	// SetIdlePolicy configures keep-alive probes and closing connections after inactivity; zero disables either
+	SetIdlePolicy(keepAlive, idleTimeout time.Duration)
+	
+	// SetConnectionPool tunes the idle connection pool, the connection limit, and HTTP/2 negotiation
+	SetConnectionPool(poolSize, maxConnections int, disableHTTP2 bool)
+	
+	// SetPushHandler registers a handler for messages the application pushes without a request; only the
+	// WebSocket transport receives any
+	SetPushHandler(handler PushHandler)
 }
 
 // RenderedContent represents content after processing for display
@@ -225,6 +330,54 @@ type RenderedContent struct {
 	Focusable bool
 	Expanded  *bool
 	ID        string
+	TreeNodes []RenderedTreeNode // Visible tree nodes, one per line of Text; nil for non-tree content
+	NextPage  *ListContinuation  // How to fetch the next page of a paginated list; nil when complete
+	Form      *RenderedForm      // Fields of an interactive form, with Text as its read-only summary; nil for other content
+	Truncated bool               // Marks the notice standing in for content left out by the complexity budget
+	Reference *ContentReference  // Content to fetch when the section is expanded; nil for other content
+}
+
+// RenderedForm describes an interactive form whose values are sent as the context of an action
+type RenderedForm struct {
+	Title       string
+	Command     string // Action command sent when the form is submitted
+	SubmitLabel string
+	Fields      []RenderedFormField
+}
+
+// RenderedFormField describes one input of a form with its initial value
+type RenderedFormField struct {
+	Name        string   // Context key the value is sent under
+	Label       string
+	Type        string   // "text", "select", or "checkbox"
+	Options     []string // Choices of a select field
+	Value       string   // Initial text, or the initially chosen option of a select field
+	Checked     bool     // Initial state of a checkbox
+	Placeholder string
+	Required    bool
+}
+
+// ListContinuation describes the next page of a paginated list
+type ListContinuation struct {
+	Token   string // Continuation token from the backend
+	Command string // Action command that fetches the page; empty to repeat the original command
+}
+
+// ContentReference describes content the application serves separately, fetched when it is expanded
+type ContentReference struct {
+	URL     string // Absolute URL or path on the application's host
+	Summary string // What the content holds, shown until it is fetched
+}
+
+// RenderedTreeNode describes one visible line of a rendered tree
+type RenderedTreeNode struct {
+	ID          string
+	Label       string
+	Depth       int
+	HasChildren bool
+	Expanded    bool
+	Selected    bool
+	Command     string // Command to run when the node is selected, from the node's "command" metadata
 }
 
 // ContentRenderer processes structured content for display
@@ -232,6 +385,12 @@ type ContentRenderer interface {
 	// RenderContent transforms structured content into display-ready format
 	RenderContent(content interface{}, theme *Theme) ([]RenderedContent, error)
 	
+	// RenderFullContent renders content like RenderContent, ignoring the complexity budget
+	RenderFullContent(content interface{}, theme *Theme) ([]RenderedContent, error)
+	
+	// RenderDiff shows current rendered content as a line diff against previous rendered content
+	RenderDiff(previous, current []RenderedContent, theme *Theme) []RenderedContent
+	
 	// RenderActions formats actions for the Actions Pane
 	RenderActions(actions []Action, theme *Theme) (string, error)
 	
@@ -244,6 +403,15 @@ type ContentRenderer interface {
 	// RenderWorkflow formats workflow breadcrumbs
 	RenderWorkflow(workflow *Workflow, theme *Theme) (string, error)
 	
+	// TrackWorkflow records the step a workflow has reached, for checklist blocks synced to it
+	TrackWorkflow(workflow *Workflow)
+	
+	// TrackProgress records the latest polled progress of an operation, for progress blocks naming it
+	TrackProgress(operationID string, progress *ProgressResponse)
+	
+	// ProgressOperations returns the IDs of the running operations named by progress blocks in content
+	ProgressOperations(content interface{}) []string
+	
 	// ToggleCollapsible expands or collapses a collapsible section
 	ToggleCollapsible(contentID string) error
 	
@@ -252,17 +420,63 @@ type ContentRenderer interface {
 	
 	// CollapseAll collapses all collapsible sections
 	CollapseAll() error
+	
+	// SetHighContrast enables or disables high-contrast rendering
+	SetHighContrast(enabled bool)
+	
+	// SetContentWidth sets the column width that pre-formatted content is wrapped to
+	SetContentWidth(width int)
+	
+	// SetDefaultCodeLanguage sets the language of code blocks that do not name one
+	SetDefaultCodeLanguage(language string)
+	
+	// SetShowLineNumbers sets whether code blocks that ask for line numbers show them
+	SetShowLineNumbers(enabled bool)
+	
+	// SetUnicodeSupport chooses Unicode box-drawing characters or ASCII for tables, trees, and indicators
+	SetUnicodeSupport(enabled bool)
+	
+	// UnicodeSupport reports whether structure is drawn with Unicode characters
+	UnicodeSupport() bool
+	
+	// SetBlockFilter hides content blocks of the given types or status levels; empty lists show every block
+	SetBlockFilter(hiddenTypes, hiddenStatuses []string)
+	
+	// SetComplexityBudget truncates content whose complexity score exceeds the budget; zero renders everything
+	SetComplexityBudget(budget int)
+	
+	// ToggleTreeNode expands or collapses a tree node and returns the re-rendered tree
+	ToggleTreeNode(treeID, nodeID string) (*RenderedContent, error)
+	
+	// SelectTreeNode selects or deselects a tree node and returns the re-rendered tree
+	SelectTreeNode(treeID, nodeID string) (*RenderedContent, error)
+	
+	// AppendListPage appends the next page of a paginated list and returns the re-rendered list
+	AppendListPage(listID string, page interface{}) (*RenderedContent, error)
+	
+	// ResolveReference supplies the fetched content of a reference, which is rendered in its place from then on
+	ResolveReference(url string, content interface{}) error
+	
+	// RenderHTML renders structured content as an HTML fragment for exported transcripts
+	RenderHTML(content interface{}, theme *Theme) (string, error)
 }
 
 // AppHealth represents the health status of a registered application
 type AppHealth struct {
 	Name         string    `json:"name"`
-	Status       string    `json:"status"` // "ready", "offline", "error", "checking"
+	Status       string    `json:"status"` // "ready", "degraded", "offline", "error", "checking"
 	LastChecked  time.Time `json:"lastChecked"`
 	ResponseTime time.Duration `json:"responseTime,omitempty"`
 	Error        string    `json:"error,omitempty"`
 }
 
+// AppHealthEvent reports a completed health check or status update for a registered application
+type AppHealthEvent struct {
+	AppName    string
+	PrevStatus string // "unknown" for the first result
+	Health     AppHealth
+}
+
 // RegistryManager handles application registration and health monitoring
 type RegistryManager interface {
 	// GetRegisteredApps returns all registered applications with current status
@@ -291,6 +505,9 @@ type RegistryManager interface {
 	
 	// GetAppByName retrieves application details by name
 	GetAppByName(name string) (*RegisteredApp, error)
+	
+	// SubscribeHealth delivers health events until the returned cancel function is called
+	SubscribeHealth() (<-chan AppHealthEvent, func())
 }
 
 // AuthManager handles security credentials and authentication
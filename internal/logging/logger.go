@@ -5,9 +5,9 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
-	"strings"
 	"time"
 )
 
@@ -42,14 +42,23 @@ type Logger struct {
 	logger    *slog.Logger
 	level     LogLevel
 	component string
+	output    io.Closer // Log file opened by NewLogger; nil for standard streams and derived loggers
 }
 
 // Config represents logging configuration
 type Config struct {
 	Level     LogLevel
 	Format    string // "json" or "text"
-	Output    string // "stdout", "stderr", or file path
+	Output    string // "stdout", "stderr", "none", or file path
 	Component string
+
+	// Rotation settings, used when Output is a file path
+	MaxSizeMB  int // Rotate when the file reaches this size; 0 disables
+	MaxAgeDays int // Rotate files older than this and delete older backups; 0 disables
+	MaxBackups int // Number of rotated files to keep; 0 keeps all
+
+	// SecretPatterns are additional regular expressions for field names whose values are redacted
+	SecretPatterns []string
 }
 
 // DefaultConfig returns a sensible default logging configuration
@@ -59,6 +68,10 @@ func DefaultConfig() Config {
 		Format:    "text",
 		Output:    "stdout",
 		Component: "console",
+
+		MaxSizeMB:  10,
+		MaxAgeDays: 7,
+		MaxBackups: 5,
 	}
 }
 
@@ -67,31 +80,33 @@ func NewLogger(config Config) (*Logger, error) {
 	var handler slog.Handler
 	
 	// Determine output destination
-	var output *os.File
+	var output io.Writer
+	var closer io.Closer
 	switch config.Output {
 	case "stdout", "":
 		output = os.Stdout
 	case "stderr":
 		output = os.Stderr
+	case "none":
+		output = io.Discard
 	default:
-		// File output
-		file, err := os.OpenFile(config.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		// File output with size and age based rotation
+		file, err := NewRotatingFile(config.Output, config.MaxSizeMB, config.MaxAgeDays, config.MaxBackups)
 		if err != nil {
-			return nil, fmt.Errorf("failed to open log file %s: %w", config.Output, err)
+			return nil, err
 		}
 		output = file
+		closer = file
 	}
 
-	// Create appropriate handler based on format
+	// Create appropriate handler based on format, redacting secrets before they are written
+	redactor, err := NewRedactor(config.SecretPatterns)
+	if err != nil {
+		return nil, err
+	}
 	opts := &slog.HandlerOptions{
-		Level: slogLevel(config.Level),
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Redact sensitive information
-			if a.Key == "token" || strings.Contains(strings.ToLower(a.Key), "password") {
-				return slog.String(a.Key, "[REDACTED]")
-			}
-			return a
-		},
+		Level:       slogLevel(config.Level),
+		ReplaceAttr: redactor.ReplaceAttr,
 	}
 
 	switch config.Format {
@@ -107,9 +122,19 @@ func NewLogger(config Config) (*Logger, error) {
 		logger:    logger,
 		level:     config.Level,
 		component: config.Component,
+		output:    closer,
 	}, nil
 }
 
+// Close flushes and closes the log file, if the logger writes to one. Messages logged afterwards
+// are dropped. Loggers derived with WithComponent and similar share the file but do not close it.
+func (l *Logger) Close() error {
+	if l.output == nil {
+		return nil
+	}
+	return l.output.Close()
+}
+
 // slogLevel converts our LogLevel to slog.Level
 func slogLevel(level LogLevel) slog.Level {
 	switch level {
@@ -305,6 +330,11 @@ func InitGlobalLogger(config Config) error {
 		return fmt.Errorf("failed to initialize global logger: %w", err)
 	}
 	globalLogger = logger
+
+	// Keep standalone redaction consistent with the configured patterns
+	if redactor, err := NewRedactor(config.SecretPatterns); err == nil {
+		globalRedactor = redactor
+	}
 	return nil
 }
 
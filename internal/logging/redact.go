@@ -0,0 +1,144 @@
+// Package logging implements secret redaction for the Universal Application Console.
+// This file masks authorization headers, bearer tokens, JWTs, and any field or query parameter whose
+// name matches a secret pattern before log records are written, so debug logs are safe to share.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces secret values in log output
+const RedactedValue = "[REDACTED]"
+
+// DefaultSecretPatterns match field and parameter names whose values are always redacted
+var DefaultSecretPatterns = []string{
+	`(?i)authorization`,
+	`(?i)token`,
+	`(?i)password`,
+	`(?i)passwd`,
+	`(?i)secret`,
+	`(?i)api[-_]?key`,
+	`(?i)cookie`,
+	`(?i)credential`,
+}
+
+// Value patterns that identify secrets regardless of the field they appear in
+var (
+	authSchemeRegex = regexp.MustCompile(`(?i)\b(bearer|basic)\s+([A-Za-z0-9\-._~+/]+=*)`)
+	plainWordRegex  = regexp.MustCompile(`^[a-z]+$`)
+	jwtRegex        = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	queryParamRegex = regexp.MustCompile(`([?&])([^=&\s#]+)=([^&\s#]*)`)
+	keyValueRegex   = regexp.MustCompile(`([A-Za-z0-9_.\-]+)(\s*[=:]\s*)("[^"]*"|[^\s,;&]+)`)
+)
+
+// Redactor masks secrets in log attributes and free-form strings
+type Redactor struct {
+	keyPatterns []*regexp.Regexp
+}
+
+// NewRedactor creates a redactor using the default secret patterns plus any additional ones
+func NewRedactor(extraPatterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string{}, DefaultSecretPatterns...), extraPatterns...) {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret pattern %q: %w", pattern, err)
+		}
+		r.keyPatterns = append(r.keyPatterns, compiled)
+	}
+	return r, nil
+}
+
+// IsSecretKey reports whether a field or parameter name matches a secret pattern
+func (r *Redactor) IsSecretKey(key string) bool {
+	for _, pattern := range r.keyPatterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactString masks authorization values, JWTs, and secret query or key=value parameters in s
+func (r *Redactor) RedactString(s string) string {
+	if s == "" {
+		return s
+	}
+
+	s = authSchemeRegex.ReplaceAllStringFunc(s, func(match string) string {
+		parts := authSchemeRegex.FindStringSubmatch(match)
+		// Prose such as "bearer token cannot be empty" is not a credential
+		if plainWordRegex.MatchString(parts[2]) {
+			return match
+		}
+		return parts[1] + " " + RedactedValue
+	})
+	s = jwtRegex.ReplaceAllString(s, RedactedValue)
+
+	s = queryParamRegex.ReplaceAllStringFunc(s, func(match string) string {
+		parts := queryParamRegex.FindStringSubmatch(match)
+		if !r.IsSecretKey(parts[2]) {
+			return match
+		}
+		return parts[1] + parts[2] + "=" + RedactedValue
+	})
+
+	s = keyValueRegex.ReplaceAllStringFunc(s, func(match string) string {
+		parts := keyValueRegex.FindStringSubmatch(match)
+		if !r.IsSecretKey(parts[1]) || parts[3] == RedactedValue {
+			return match
+		}
+		// Authorization schemes were already masked above; keep the scheme name readable
+		scheme := strings.TrimLeft(parts[3], `["'`)
+		if strings.EqualFold(scheme, "bearer") || strings.EqualFold(scheme, "basic") {
+			return match
+		}
+		return parts[1] + parts[2] + RedactedValue
+	})
+
+	return s
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr function that redacts secrets in each attribute
+func (r *Redactor) ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	// Built-in time and level attributes never carry secrets
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+		return a
+	}
+
+	if a.Key != slog.MessageKey && r.IsSecretKey(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.RedactString(a.Value.String()))
+	case slog.KindAny:
+		// Errors, headers, and other values are checked in their formatted form
+		formatted := fmt.Sprintf("%+v", a.Value.Any())
+		if redacted := r.RedactString(formatted); redacted != formatted {
+			return slog.String(a.Key, redacted)
+		}
+	}
+
+	return a
+}
+
+// globalRedactor masks secrets for callers outside the logger, such as error messages shown to users
+var globalRedactor, _ = NewRedactor(nil)
+
+// Redact masks secrets in s using the patterns of the global logger
+func Redact(s string) string {
+	return globalRedactor.RedactString(s)
+}
+
+// RedactSecret masks every occurrence of a known secret value in s, then applies pattern redaction
+func RedactSecret(s, secret string) string {
+	if strings.TrimSpace(secret) != "" {
+		s = strings.ReplaceAll(s, secret, RedactedValue)
+	}
+	return Redact(s)
+}
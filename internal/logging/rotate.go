@@ -0,0 +1,180 @@
+// Package logging implements a size- and age-based rotating log file for the Universal Application Console.
+// This file provides an io.Writer that starts a new log file when the current one grows too large or too old,
+// keeping a bounded number of timestamped backups alongside it.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp embedded in rotated log file names
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a log file writer that rotates by size and age. It is safe for concurrent use.
+type RotatingFile struct {
+	path       string
+	maxSize    int64         // Rotate when the file would exceed this many bytes; 0 disables
+	maxAge     time.Duration // Rotate when the file is older than this, and delete older backups; 0 disables
+	maxBackups int           // Number of rotated files to keep; 0 keeps all
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFile opens or creates the log file at path, appending to existing content
+func NewRotatingFile(path string, maxSizeMB, maxAgeDays, maxBackups int) (*RotatingFile, error) {
+	rf := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+		maxBackups: maxBackups,
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Write appends p to the log file, rotating first if the size or age limit would be exceeded
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	if rf.file == nil {
+		return 0, fmt.Errorf("log file %s is closed", rf.path)
+	}
+
+	tooLarge := rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize
+	tooOld := rf.maxAge > 0 && time.Since(rf.openedAt) > rf.maxAge
+	if tooLarge || tooOld {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close flushes the current log file to disk and closes it
+func (rf *RotatingFile) Close() error {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	if rf.file == nil {
+		return nil
+	}
+	syncErr := rf.file.Sync()
+	err := rf.file.Close()
+	rf.file = nil
+	if err == nil {
+		err = syncErr
+	}
+	return err
+}
+
+// open opens the log file for appending and records its current size and age
+func (rf *RotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", rf.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file %s: %w", rf.path, err)
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	rf.openedAt = time.Now()
+	if info.Size() > 0 {
+		// An existing file ages from its last modification, not from when this process opened it
+		rf.openedAt = info.ModTime()
+	}
+	return nil
+}
+
+// rotate renames the current file to a timestamped backup, opens a fresh file, and prunes old backups
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	rf.file = nil
+
+	if err := os.Rename(rf.path, rf.backupName(time.Now().UTC())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	rf.pruneBackups()
+	return nil
+}
+
+// backupName returns the rotated file name for the given time, e.g. console-2024-01-02T15-04-05.000.log
+func (rf *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(rf.path)
+	base := strings.TrimSuffix(rf.path, ext)
+	return fmt.Sprintf("%s-%s%s", base, t.Format(backupTimeFormat), ext)
+}
+
+// pruneBackups removes backups beyond the retention count or older than the maximum age
+func (rf *RotatingFile) pruneBackups() {
+	ext := filepath.Ext(rf.path)
+	prefix := filepath.Base(strings.TrimSuffix(rf.path, ext)) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(rf.path))
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path      string
+		timestamp time.Time
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		timestamp, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(rf.path), name), timestamp: timestamp})
+	}
+
+	// Newest first, so retention keeps the most recent backups
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	for i, b := range backups {
+		expired := rf.maxAge > 0 && time.Since(b.timestamp) > rf.maxAge
+		excess := rf.maxBackups > 0 && i >= rf.maxBackups
+		if expired || excess {
+			os.Remove(b.path)
+		}
+	}
+}
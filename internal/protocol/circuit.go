@@ -0,0 +1,218 @@
+// Package protocol implements circuit-breaker behavior for the protocol client.
+// This file stops sending requests to a backend after repeated consecutive failures, failing fast
+// with a clear "backend unavailable" error during a cooldown, then letting a single trial request
+// through to test whether the backend has recovered. Background requests, such as suggestions, are
+// sent only while the circuit is closed, and their outcomes are not counted.
+package protocol
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Circuit breaker defaults, used when the profile does not configure them
+const (
+	DefaultCircuitThreshold = 5
+	DefaultCircuitCooldown  = 30 * time.Second
+)
+
+// Circuit breaker states reported by CircuitState
+const (
+	CircuitClosed   = "closed"    // Requests flow normally
+	CircuitOpen     = "open"      // Requests fail fast until the cooldown ends
+	CircuitHalfOpen = "half-open" // A single trial request is testing recovery
+)
+
+// circuitBreaker tracks backend health. The client's mutex guards all of its fields.
+type circuitBreaker struct {
+	threshold     int           // Consecutive failures that open the circuit
+	cooldown      time.Duration // How long the circuit stays open before a trial request
+	state         string
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// newCircuitBreaker creates a closed circuit breaker
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     CircuitClosed,
+	}
+}
+
+// allow reports whether a request may be sent now, moving an expired open circuit to half-open
+func (cb *circuitBreaker) allow(now time.Time) error {
+	switch cb.state {
+	case CircuitOpen:
+		remaining := cb.cooldown - now.Sub(cb.openedAt)
+		if remaining > 0 {
+			return cb.unavailableError(remaining)
+		}
+		cb.state = CircuitHalfOpen
+		cb.trialInFlight = true
+		return nil
+
+	case CircuitHalfOpen:
+		if cb.trialInFlight {
+			return cb.unavailableError(0)
+		}
+		cb.trialInFlight = true
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit from a request outcome and the current consecutive failure count
+func (cb *circuitBreaker) record(failed bool, consecutiveFailures int, now time.Time) {
+	cb.trialInFlight = false
+
+	if !failed {
+		cb.state = CircuitClosed
+		return
+	}
+
+	// A failed trial reopens immediately; otherwise the threshold decides
+	if cb.state == CircuitHalfOpen || consecutiveFailures >= cb.threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = now
+	}
+}
+
+// allowBackground reports whether a background request may be sent now. Background requests are only
+// sent through a closed circuit, and never become the trial request of a half-open one.
+func (cb *circuitBreaker) allowBackground(now time.Time) error {
+	switch cb.state {
+	case CircuitOpen:
+		if remaining := cb.cooldown - now.Sub(cb.openedAt); remaining > 0 {
+			return cb.unavailableError(remaining)
+		}
+		return cb.unavailableError(0)
+	case CircuitHalfOpen:
+		return cb.unavailableError(0)
+	default:
+		return nil
+	}
+}
+
+// release ends a request that says nothing about backend health, such as one refused before it reached
+// the backend, freeing the trial slot without changing the circuit's state
+func (cb *circuitBreaker) release() {
+	cb.trialInFlight = false
+}
+
+// reset closes the circuit, as after a successful handshake
+func (cb *circuitBreaker) reset() {
+	cb.state = CircuitClosed
+	cb.trialInFlight = false
+}
+
+// unavailableError is returned instead of sending a request while the circuit is open
+func (cb *circuitBreaker) unavailableError(remaining time.Duration) error {
+	message := "Backend unavailable: circuit open, testing recovery"
+	if remaining > 0 {
+		message = fmt.Sprintf("Backend unavailable: circuit open, retrying in %ds", int(math.Ceil(remaining.Seconds())))
+	}
+
+	return &ProtocolError{
+		Type:            "circuit_open",
+		Message:         message,
+		OriginalError:   fmt.Errorf("circuit breaker open after %d consecutive failures", cb.threshold),
+		Timestamp:       time.Now(),
+		Recoverable:     false, // Retrying immediately would defeat the breaker
+		SuggestedAction: "Check that the application is running, then try again after the cooldown",
+	}
+}
+
+// SetCircuitBreaker configures the circuit breaker. It opens after failureThreshold consecutive
+// failed requests and stays open for cooldown. Zero values use the defaults.
+func (c *Client) SetCircuitBreaker(failureThreshold int, cooldown time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultCircuitThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitCooldown
+	}
+	c.breaker = newCircuitBreaker(failureThreshold, cooldown)
+}
+
+// CircuitState returns the circuit breaker state: closed, open, or half-open
+func (c *Client) CircuitState() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// An open circuit whose cooldown has passed admits the next request
+	if c.breaker.state == CircuitOpen && time.Since(c.breaker.openedAt) >= c.breaker.cooldown {
+		return CircuitHalfOpen
+	}
+	return c.breaker.state
+}
+
+// isBackgroundEndpoint reports whether requests to an endpoint are background requests, which are not
+// rate limited and do not count toward the circuit breaker. Suggestions are requested as the user types,
+// so they must not use up the rate limit meant for commands, or open the circuit while no command failed.
+func isBackgroundEndpoint(endpoint string) bool {
+	return endpoint == EndpointSuggest
+}
+
+// checkBackgroundCircuit fails fast unless the circuit breaker is closed
+func (c *Client) checkBackgroundCircuit() error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.breaker.allowBackground(time.Now())
+}
+
+// checkRequestCircuit fails fast when the circuit breaker does not allow a request, of the background
+// kind or not
+func (c *Client) checkRequestCircuit(background bool) error {
+	if background {
+		return c.checkBackgroundCircuit()
+	}
+	return c.checkCircuit()
+}
+
+// checkCircuit fails fast when the circuit breaker is open
+func (c *Client) checkCircuit() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.breaker.allow(time.Now())
+}
+
+// recordCircuitResult updates the consecutive failure count and the circuit breaker after a request
+func (c *Client) recordCircuitResult(failed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := &c.connectionState.Statistics
+	if failed {
+		stats.ConsecutiveFailures++
+	} else {
+		stats.ConsecutiveFailures = 0
+	}
+
+	previous := c.breaker.state
+	c.breaker.record(failed, stats.ConsecutiveFailures, time.Now())
+	if c.breaker.state != previous {
+		c.logger.Warn("Circuit breaker state changed",
+			"from", previous,
+			"to", c.breaker.state,
+			"consecutive_failures", stats.ConsecutiveFailures)
+	}
+}
+
+// releaseCircuitTrial ends a request whose outcome does not count toward backend health, so that a
+// half-open circuit can send its next trial request
+func (c *Client) releaseCircuitTrial() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.breaker.release()
+}
@@ -0,0 +1,123 @@
+package protocol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefusedRedirectReleasesCircuitTrial(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer other.Close()
+
+	var mode atomic.Value // "fail", "redirect", or "ok"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch mode.Load() {
+		case "fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "redirect":
+			http.Redirect(w, r, other.URL+r.URL.Path, http.StatusTemporaryRedirect)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetCircuitBreaker(1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	mode.Store("fail")
+	client.executeJSONRequest(ctx, EndpointCommand, struct{}{})
+	if state := client.CircuitState(); state != CircuitOpen {
+		t.Fatalf("circuit is %s after a failure, want open", state)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// The trial request is redirected to another host and refused
+	mode.Store("redirect")
+	if _, err := client.executeJSONRequest(ctx, EndpointCommand, struct{}{}); err == nil {
+		t.Fatal("redirected request succeeded, want a refused redirect")
+	}
+
+	mode.Store("ok")
+	if _, err := client.executeJSONRequest(ctx, EndpointCommand, struct{}{}); err != nil {
+		t.Fatalf("request after a refused redirect failed: %v", err)
+	}
+	if state := client.CircuitState(); state != CircuitClosed {
+		t.Errorf("circuit is %s after a successful trial, want closed", state)
+	}
+}
+
+func TestSuggestionsDoNotCountTowardCircuitOrRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointSuggest {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetCircuitBreaker(1, time.Minute)
+	client.SetRateLimit(1, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := client.executeJSONRequest(ctx, EndpointSuggest, struct{}{}); err == nil {
+			t.Fatal("failing suggestion succeeded")
+		}
+	}
+	if state := client.CircuitState(); state != CircuitClosed {
+		t.Errorf("circuit is %s after failed suggestions, want closed", state)
+	}
+
+	// The one token in the bucket is still there for a command
+	if _, err := client.executeJSONRequest(ctx, EndpointCommand, struct{}{}); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("suggestions and a command took %v, want them unthrottled", elapsed)
+	}
+}
+
+func TestSuggestionsNeverTakeTheCircuitTrial(t *testing.T) {
+	var mode atomic.Value
+	mode.Store("fail")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mode.Load() == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetCircuitBreaker(1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	client.executeJSONRequest(ctx, EndpointCommand, struct{}{})
+	if _, err := client.executeJSONRequest(ctx, EndpointSuggest, struct{}{}); err == nil {
+		t.Error("suggestion sent through an open circuit")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	mode.Store("ok")
+	if _, err := client.executeJSONRequest(ctx, EndpointSuggest, struct{}{}); err == nil {
+		t.Error("suggestion sent as the trial request of a half-open circuit")
+	}
+	if _, err := client.executeJSONRequest(ctx, EndpointCommand, struct{}{}); err != nil {
+		t.Fatalf("trial command failed: %v", err)
+	}
+	if _, err := client.executeJSONRequest(ctx, EndpointSuggest, struct{}{}); err != nil {
+		t.Errorf("suggestion through a closed circuit failed: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -32,10 +33,22 @@ type Client struct {
 	userAgent       string
 	sessionID       string
 	logger          *logging.Logger
+	middlewares     []ResponseMiddleware
+	limiter         *rateLimiter
+	breaker         *circuitBreaker
+	ws              *wsTransport // Persistent transport for ws:// and wss:// hosts; nil when using HTTP
+	pushHandler     PushHandler
+	pushMutex       sync.RWMutex // Guards pushHandler apart from mutex, which Connect holds through the WebSocket handshake
+	idle            idlePolicy
 }
 
 // NewClient creates a new protocol client with injected dependencies and secure defaults
 func NewClient(configManager interfaces.ConfigManager, authManager interfaces.AuthManager) (*Client, error) {
+	return NewPooledClient(configManager, authManager, PoolConfig{})
+}
+
+// NewPooledClient creates a new protocol client whose HTTP transport has the given pool tuning
+func NewPooledClient(configManager interfaces.ConfigManager, authManager interfaces.AuthManager, pool PoolConfig) (*Client, error) {
 	if configManager == nil {
 		return nil, fmt.Errorf("configManager cannot be nil")
 	}
@@ -44,15 +57,10 @@ func NewClient(configManager interfaces.ConfigManager, authManager interfaces.Au
 		return nil, fmt.Errorf("authManager cannot be nil")
 	}
 
+	// Requests are bounded by their contexts, so commands can be given longer than DefaultRequestTimeout
 	httpClient := &http.Client{
-		Timeout: DefaultRequestTimeout,
-		Transport: &http.Transport{
-			MaxIdleConns:        10,
-			IdleConnTimeout:     30 * time.Second,
-			DisableCompression:  false,
-			DisableKeepAlives:   false,
-			MaxIdleConnsPerHost: 2,
-		},
+		Transport:     NewTransport(pool),
+		CheckRedirect: CheckRedirect,
 	}
 
 	logger := logging.GetProtocolLogger().WithField("session_id", generateSessionID())
@@ -69,6 +77,7 @@ func NewClient(configManager interfaces.ConfigManager, authManager interfaces.Au
 		userAgent: fmt.Sprintf("Universal-Console/%s (Protocol/%s)", "2.0.0", ProtocolVersion),
 		sessionID: generateSessionID(),
 		logger:    logger,
+		breaker:   newCircuitBreaker(DefaultCircuitThreshold, DefaultCircuitCooldown),
 	}
 	
 	logger.Info("Protocol client initialized",
@@ -112,6 +121,28 @@ func (c *Client) Connect(ctx context.Context, host string, auth *interfaces.Auth
 	c.connectionState.Connected = false
 	c.connectionState.LastError = nil
 	c.connectionState.Auth = auth // Store auth config for subsequent requests
+	c.connectionState.Idle = false
+	c.stopIdleMonitorUnsafe()
+
+	if c.ws != nil {
+		c.ws.Close()
+		c.ws = nil
+	}
+
+	// Prefer the persistent WebSocket transport when the host asks for it, falling back to HTTP
+	if isWebSocketHost(host) {
+		wsCtx, wsCancel := context.WithTimeout(ctx, HandshakeTimeout)
+		specResponse, err := c.connectWebSocket(wsCtx, host, auth)
+		wsCancel()
+		if err == nil {
+			c.logger.Info("Using WebSocket transport", "host", host)
+			return c.completeConnectionUnsafe(host, specResponse, startTime), nil
+		}
+
+		host = httpHostFor(host)
+		c.connectionState.Host = host
+		c.logger.Warn("WebSocket upgrade failed, falling back to HTTP", "host", host, "error", err.Error())
+	}
 
 	handshakeURL := c.buildURL(host, EndpointSpec)
 	c.logger.Debug("Built handshake URL", "url", handshakeURL)
@@ -184,12 +215,22 @@ func (c *Client) Connect(ctx context.Context, host string, auth *interfaces.Auth
 		return nil, contextualErr
 	}
 
+	return c.completeConnectionUnsafe(host, specResponse, startTime), nil
+}
+
+// completeConnectionUnsafe records a successful handshake (caller must hold lock)
+func (c *Client) completeConnectionUnsafe(host string, specResponse *SpecResponseInternal, startTime time.Time) *interfaces.SpecResponse {
 	totalDuration := time.Since(startTime)
 	c.connectionState.Connected = true
 	c.connectionState.AppName = specResponse.AppName
 	c.connectionState.AppVersion = specResponse.AppVersion
+	c.connectionState.ProtocolVersion = specResponse.ProtocolVersion
 	c.connectionState.LastHandshake = time.Now()
 	c.connectionState.Features = specResponse.Features
+	c.connectionState.Commands = specResponse.Commands
+	c.connectionState.Statistics.ConsecutiveFailures = 0
+	c.breaker.reset()
+	c.startIdleMonitorUnsafe()
 
 	c.logger.LogConnectionSuccess(host, specResponse.AppName, specResponse.ProtocolVersion, totalDuration)
 	c.logger.Info("Connection established successfully",
@@ -199,7 +240,7 @@ func (c *Client) Connect(ctx context.Context, host string, auth *interfaces.Auth
 		"total_duration", totalDuration,
 		"features", len(specResponse.Features))
 
-	return &specResponse.SpecResponse, nil
+	return &specResponse.SpecResponse
 }
 
 // ExecuteCommand sends a command to the application, handling the full request lifecycle.
@@ -235,6 +276,11 @@ func (c *Client) ExecuteCommand(ctx context.Context, request interfaces.CommandR
 		return nil, err
 	}
 
+	response, err = c.applyMiddleware(response)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := c.validateCommandResponse(response); err != nil {
 		return nil, fmt.Errorf("invalid command response received: %w", err)
 	}
@@ -275,6 +321,11 @@ func (c *Client) ExecuteAction(ctx context.Context, request interfaces.ActionReq
 		return nil, err
 	}
 
+	response, err = c.applyMiddleware(response)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := c.validateCommandResponse(response); err != nil {
 		return nil, fmt.Errorf("invalid action response received: %w", err)
 	}
@@ -404,9 +455,19 @@ func (c *Client) Disconnect() error {
 	c.connectionState.Connected = false
 	c.connectionState.AppName = ""
 	c.connectionState.AppVersion = ""
+	c.connectionState.ProtocolVersion = ""
 	c.connectionState.Features = nil
+	c.connectionState.Commands = nil
 	c.connectionState.Auth = nil
 	c.connectionState.LastError = nil
+	c.connectionState.Idle = false
+	c.breaker.reset()
+	c.stopIdleMonitorUnsafe()
+
+	if c.ws != nil {
+		c.ws.Close()
+		c.ws = nil
+	}
 
 	c.httpClient.CloseIdleConnections()
 
@@ -430,10 +491,62 @@ func (c *Client) GetConnectionState() *ConnectionState {
 	return &stateCopy
 }
 
+// GetAppInfo returns the application details from the last handshake, or nil when not connected
+func (c *Client) GetAppInfo() *interfaces.SpecResponse {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.connectionState.Connected {
+		return nil
+	}
+
+	features := make(map[string]bool, len(c.connectionState.Features))
+	for name, enabled := range c.connectionState.Features {
+		features[name] = enabled
+	}
+	return &interfaces.SpecResponse{
+		AppName:         c.connectionState.AppName,
+		AppVersion:      c.connectionState.AppVersion,
+		ProtocolVersion: c.connectionState.ProtocolVersion,
+		Features:        features,
+		Commands:        append([]interfaces.CommandInfo(nil), c.connectionState.Commands...),
+	}
+}
+
 // --- Internal Helper Methods ---
 
 // executeJSONRequest handles the core logic of making a POST request with a JSON body.
 func (c *Client) executeJSONRequest(ctx context.Context, endpoint string, payload interface{}) ([]byte, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultRequestTimeout)
+		defer cancel()
+	}
+
+	background := isBackgroundEndpoint(endpoint)
+	if !background {
+		if err := c.waitForRateLimit(ctx); err != nil {
+			c.logger.Warn("Request throttled by client rate limit", "endpoint", endpoint, "error", err.Error())
+			return nil, c.wrapProtocolError("request not sent", err)
+		}
+	}
+
+	if err := c.recordActivity(ctx); err != nil {
+		c.logger.Error("Failed to reconnect after idle timeout", "endpoint", endpoint, "error", err.Error())
+		return nil, err
+	}
+
+	c.mutex.RLock()
+	transport := c.ws
+	c.mutex.RUnlock()
+
+	if transport != nil {
+		if err := c.checkRequestCircuit(background); err != nil {
+			return nil, err
+		}
+		return c.executeWebSocketRequest(ctx, transport, endpoint, payload)
+	}
+
 	c.logger.Debug("Creating JSON request", "endpoint", endpoint)
 	req, err := c.createJSONRequest(ctx, endpoint, payload)
 	if err != nil {
@@ -441,6 +554,11 @@ func (c *Client) executeJSONRequest(ctx context.Context, endpoint string, payloa
 		return nil, c.wrapProtocolError("failed to create request", err)
 	}
 
+	if err := c.checkRequestCircuit(background); err != nil {
+		c.logger.Warn("Request rejected by open circuit breaker", "endpoint", endpoint)
+		return nil, err
+	}
+
 	c.logger.Debug("Executing JSON request", 
 		"method", req.Method, 
 		"url", req.URL.String(),
@@ -451,7 +569,19 @@ func (c *Client) executeJSONRequest(ctx context.Context, endpoint string, payloa
 	duration := time.Since(startTime)
 	c.updateRequestStatistics(duration, err == nil)
 
+	var redirectErr *RedirectError
+	if stderrors.As(err, &redirectErr) {
+		// A refused redirect is a configuration problem, not a failing backend, and retrying cannot help
+		if !background {
+			c.releaseCircuitTrial()
+		}
+		c.logger.Warn("Refused redirect", "endpoint", endpoint, "error", redirectErr.Error())
+		return nil, c.wrapProtocolError("request was redirected", redirectErr)
+	}
 	if err != nil {
+		if !background {
+			c.recordCircuitResult(true)
+		}
 		c.logger.Error("JSON request execution failed", 
 			"endpoint", endpoint,
 			"error", err.Error(),
@@ -463,6 +593,12 @@ func (c *Client) executeJSONRequest(ctx context.Context, endpoint string, payloa
 	c.logger.LogHTTPRequest(req.Method, req.URL.String(), resp.StatusCode, duration)
 
 	body, err := io.ReadAll(resp.Body)
+
+	// Server errors and broken responses count against backend health; client errors do not
+	if !background {
+		c.recordCircuitResult(err != nil || resp.StatusCode >= 500)
+	}
+
 	if err != nil {
 		c.logger.Error("Failed to read response body", 
 			"endpoint", endpoint,
@@ -477,7 +613,8 @@ func (c *Client) executeJSONRequest(ctx context.Context, endpoint string, payloa
 		"content_length", len(body),
 		"duration", duration)
 
-	if resp.StatusCode >= 400 {
+	// Redirects that were not followed, such as one without a Location, carry no response to use
+	if resp.StatusCode >= 300 {
 		c.logger.Warn("HTTP error response", 
 			"endpoint", endpoint,
 			"status_code", resp.StatusCode,
@@ -561,18 +698,34 @@ func (c *Client) processHandshakeResponse(resp *http.Response) (*SpecResponseInt
 	if specResp.ProtocolVersion != ProtocolVersion {
 		return nil, fmt.Errorf("incompatible protocol version: server=%s, client=%s", specResp.ProtocolVersion, ProtocolVersion)
 	}
+	specResp.Commands = normalizeCommandCatalog(specResp.Commands)
 	return &specResp, nil
 }
 
+// normalizeCommandCatalog trims the names in a published command catalog, dropping unnamed and repeated
+// commands; the catalog is optional, so a malformed entry is skipped rather than failing the handshake
+func normalizeCommandCatalog(commands []interfaces.CommandInfo) []interfaces.CommandInfo {
+	seen := make(map[string]bool, len(commands))
+	catalog := make([]interfaces.CommandInfo, 0, len(commands))
+	for _, command := range commands {
+		command.Name = strings.TrimSpace(command.Name)
+		if command.Name == "" || seen[command.Name] {
+			continue
+		}
+		seen[command.Name] = true
+		catalog = append(catalog, command)
+	}
+	if len(catalog) == 0 {
+		return nil
+	}
+	return catalog
+}
+
 func (c *Client) validateCommandResponse(response *interfaces.CommandResponse) error {
 	if response == nil {
 		return fmt.Errorf("response cannot be nil")
 	}
-	if response.Response.Type == "structured" {
-		if response.Response.Content == nil {
-			return fmt.Errorf("structured response content cannot be nil")
-		}
-	}
+	// Missing structured content is empty rather than invalid; it renders as a "(no content)" placeholder
 	return nil
 }
 
@@ -599,6 +752,11 @@ func (c *Client) validateProgressResponse(response *interfaces.ProgressResponse)
 	if !validStatuses[response.Status] {
 		return fmt.Errorf("invalid progress status: %s", response.Status)
 	}
+	for id, operation := range response.Operations {
+		if err := c.validateProgressResponse(&operation); err != nil {
+			return fmt.Errorf("operation %s: %w", id, err)
+		}
+	}
 	return nil
 }
 
@@ -651,15 +809,38 @@ func (c *Client) handleHTTPError(resp *http.Response, body []byte) error {
 		OriginalError: fmt.Errorf("server returned status %s", resp.Status),
 		Timestamp:     time.Now(),
 		Recoverable:   resp.StatusCode >= 500,
-		HTTPDetails:   &HTTPErrorDetails{StatusCode: resp.StatusCode, StatusText: resp.Status, Body: string(body)},
+		HTTPDetails: &HTTPErrorDetails{
+			StatusCode:    resp.StatusCode,
+			StatusText:    resp.Status,
+			Body:          string(body), // Kept whole for /inspect; the message only carries a summary
+			ContentType:   resp.Header.Get("Content-Type"),
+			ContentLength: int64(len(body)),
+		},
 	}
 
-	// Try to parse a more specific structured error.
+	// Try to parse a more specific structured error, otherwise summarize the body, which may be a
+	// proxy's HTML error page or plain text
 	var errorResp ErrorResponseInternal
-	if err := json.Unmarshal(body, &errorResp); err == nil {
+	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error.Message != "" {
 		protocolErr.Type = "http_structured"
 		protocolErr.Message = errorResp.Error.Message
 		protocolErr.OriginalError = fmt.Errorf("server returned status %s with code %s", resp.Status, errorResp.Error.Code)
+	} else if summary := summarizeErrorBody(errorBodyMediaType(protocolErr.HTTPDetails.ContentType, body), body); summary != "" &&
+		!strings.EqualFold(summary, resp.Status) {
+		protocolErr.Message = fmt.Sprintf("HTTP error %s: %s", resp.Status, summary)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		protocolErr.HTTPDetails.RetryAfter = retryAfter
+		delay := protocolErr.GetRetryDelay()
+		if protocolErr.IsRetryable() {
+			protocolErr.Message = fmt.Sprintf("Rate limited, retrying in %ds", int(delay.Round(time.Second).Seconds()))
+			c.logger.Warn("Rate limited by application, retrying", "retry_after", delay)
+		} else {
+			protocolErr.Message = fmt.Sprintf("Rate limited, try again in %ds", int(retryAfter.Round(time.Second).Seconds()))
+		}
+		protocolErr.SuggestedAction = "Wait before sending more requests, or lower rateLimit in the profile"
 	}
 
 	c.mutex.Lock()
@@ -728,10 +909,12 @@ func (c *Client) updateRequestStatisticsUnsafe(responseTime time.Duration, succe
 // --- Utility and Helper Functions ---
 
 // executeWithRetry executes a function with basic retry logic for transient failures.
+// Retries are reported to the observer and drawn from the budget attached to ctx with WithRetryObserver.
 // This is a package-private FUNCTION, not a method.
 func executeWithRetry[T any](ctx context.Context, operation func() (*T, error)) (*T, error) {
 	const maxRetries = 2
 	var lastErr error
+	options := retryOptionsFrom(ctx)
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		response, err := operation()
@@ -742,6 +925,12 @@ func executeWithRetry[T any](ctx context.Context, operation func() (*T, error))
 
 		if protocolErr, ok := err.(*ProtocolError); ok && protocolErr.IsRetryable() {
 			if attempt < maxRetries {
+				if options.budget != nil && !options.budget.take() {
+					break // The session has spent its retries
+				}
+				if options.notify != nil {
+					options.notify(attempt+1, maxRetries, err, protocolErr.GetRetryDelay())
+				}
 				select {
 				case <-ctx.Done():
 					return nil, ctx.Err()
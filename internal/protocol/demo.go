@@ -0,0 +1,445 @@
+// Package protocol implements an offline demo client for the Universal Application Console.
+// DemoClient satisfies interfaces.ProtocolClient without a backend, answering a small set of commands
+// with canned structured responses that between them exercise every content renderer: text, tables,
+// code, collapsible sections, lists with pagination, trees, progress bars, separators, ANSI output,
+// forms, references fetched on expand, checklists, and a multi-step workflow. The "broken" command fails with a structured error so the error pane and
+// recovery actions can be shown. It is used by --demo for demonstrations, screenshots, and UI work.
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// DemoHost is the host name the demo client reports
+const DemoHost = "demo:0"
+
+// demoLatency is how long each demo request takes, so in-flight indicators are visible
+const demoLatency = 400 * time.Millisecond
+
+// demoResponses holds the canned responses, keyed by command or action command
+var demoResponses = map[string]string{
+	"help": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Demo commands:", "status": "info"},
+			{"type": "list", "content": {"items": [
+				{"text": "status  - service overview with a table and progress"},
+				{"text": "code    - syntax-highlighted source"},
+				{"text": "details - labeled fields and collapsible sections"},
+				{"text": "users   - a paginated list (use Show more)"},
+				{"text": "files   - an interactive tree"},
+				{"text": "logs    - pre-formatted ANSI output with the full log fetched on expand"},
+				{"text": "invite  - a form that sends its values with an action"},
+				{"text": "deploy  - a three-step workflow with confirmation"},
+				{"text": "broken  - a failing command with recovery actions"}
+			]}}
+		]},
+		"actions": [
+			{"name": "Show status", "command": "status", "type": "primary", "icon": "📊"},
+			{"name": "Start deploy", "command": "deploy", "type": "alternative", "icon": "🚀"}
+		]
+	}`,
+
+	"status": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "All systems operational", "status": "success"},
+			{"type": "table", "content": {
+				"headers": ["Service", "Status", "Latency", "Region"],
+				"rows": [
+					["api-gateway", "ready", "12ms", "eu-west-1"],
+					["billing", "degraded", "340ms", "us-east-1"],
+					["search-indexer", "ready", "48ms", "ap-south-1"],
+					["notifications", "offline", "-", "eu-west-1"]
+				],
+				"borders": true,
+				"caption": "Services"
+			}},
+			{"type": "separator", "content": {"style": "line", "label": " Background jobs "}},
+			{"type": "progress", "content": {"label": "Reindexing", "progress": 65, "status": "running", "showPercent": true, "showETA": true,
+				"details": {"current": 650000000, "total": 1000000000, "rate": 1200000, "units": "bytes"}}},
+			{"type": "progress", "content": {"label": "Backup", "progress": 100, "status": "complete", "showPercent": true}}
+		]},
+		"warnings": [
+			{"status": "warning", "message": "billing is responding slowly", "code": "W_LATENCY", "details": "p95 latency 340ms exceeds the 200ms objective"}
+		],
+		"cacheTtl": 30,
+		"actions": [
+			{"name": "Refresh", "command": "status", "type": "primary", "icon": "🔄"},
+			{"name": "View logs", "command": "logs", "type": "info", "icon": "📜"}
+		]
+	}`,
+
+	"code": `{
+		"response": {"type": "structured", "content": [
+			{"type": "code", "content": {
+				"language": "go",
+				"filename": "main.go",
+				"lineNumbers": true,
+				"code": "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfor i := 1; i <= 3; i++ {\n\t\tfmt.Printf(\"hello %d\\n\", i)\n\t}\n}"
+			}}
+		]}
+	}`,
+
+	"details": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Deployment summary for release 2024.06"},
+			{"type": "keyvalue", "content": {"fields": [
+				{"label": "Release", "value": "2024.06"},
+				{"label": "Environment", "value": "production"},
+				{"label": "Replicas", "value": 3},
+				{"label": "Health checks", "value": "passing", "status": "success"},
+				{"label": "Rollback window", "value": "closes in 2 hours", "status": "warning"}
+			]}},
+			{"type": "collapsible", "content": {"title": "Changed services (3)", "expanded": true, "content": [
+				{"type": "list", "content": {"items": [{"text": "api-gateway"}, {"text": "billing"}, {"text": "search-indexer"}]}}
+			]}},
+			{"type": "collapsible", "content": {"title": "Configuration diff", "expanded": false, "content": [
+				{"type": "code", "content": {"language": "yaml", "code": "replicas: 3\nmemory: 512Mi\nfeatureFlags:\n  newCheckout: true"}}
+			]}}
+		]}
+	}`,
+
+	"users": `{
+		"response": {"type": "structured", "content": [
+			{"type": "list", "content": {
+				"ordered": true,
+				"items": [{"text": "ada"}, {"text": "grace"}, {"text": "linus"}],
+				"nextToken": "page-2",
+				"nextCommand": "users_page",
+				"total": 6
+			}}
+		]}
+	}`,
+
+	"users_page": `{
+		"response": {"type": "structured", "content": [
+			{"type": "list", "content": {
+				"ordered": true,
+				"items": [{"text": "margaret"}, {"text": "dennis"}, {"text": "barbara"}],
+				"total": 6
+			}}
+		]}
+	}`,
+
+	"files": `{
+		"response": {"type": "structured", "content": [
+			{"type": "tree", "content": {
+				"root": {"id": "root", "label": "project", "expanded": true, "children": [
+					{"id": "cmd", "label": "cmd", "expanded": true, "children": [
+						{"id": "main", "label": "main.go", "isLeaf": true, "selectable": true}
+					]},
+					{"id": "internal", "label": "internal", "children": [
+						{"id": "protocol", "label": "protocol", "isLeaf": true, "selectable": true},
+						{"id": "content", "label": "content", "isLeaf": true, "selectable": true}
+					]},
+					{"id": "readme", "label": "README.md", "isLeaf": true, "selectable": true}
+				]},
+				"options": {"showRoot": true, "showLines": true, "selectMode": "single"}
+			}}
+		]}
+	}`,
+
+	"logs": `{
+		"response": {"type": "structured", "content": [
+			{"type": "ansi", "content": "\u001b[32mINFO\u001b[0m  server started on :8080\n\u001b[33mWARN\u001b[0m  cache miss ratio above 20%\n\u001b[31mERROR\u001b[0m connection to billing timed out\n\u001b[32mINFO\u001b[0m  retry succeeded"},
+			{"type": "reference", "content": {"url": "/demo/logs/full", "summary": "Full server log (40 lines)"}}
+		]}
+	}`,
+
+	"invite": `{
+		"response": {"type": "structured", "content": [
+			{"type": "form", "content": {
+				"title": "Invite a teammate",
+				"command": "invite_send",
+				"submitLabel": "Send invite",
+				"fields": [
+					{"name": "email", "label": "Email", "type": "text", "placeholder": "name@example.com", "required": true},
+					{"name": "role", "label": "Role", "type": "select", "options": ["viewer", "editor", "admin"], "default": "editor"},
+					{"name": "notify", "label": "Send a welcome email", "type": "checkbox", "default": true}
+				]
+			}}
+		]}
+	}`,
+
+	"invite_send": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Invitation sent.", "status": "success"}
+		]}
+	}`,
+
+	"deploy": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Step 1: choose the target environment."}
+		]},
+		"workflow": {"id": "deploy-demo", "step": 1, "totalSteps": 3, "title": "Deploy", "steps": ["Choose environment", "Review plan", "Roll out"]},
+		"actions": [
+			{"name": "Staging", "command": "deploy_review", "type": "primary", "icon": "🧪"},
+			{"name": "Production", "command": "deploy_review", "type": "primary", "icon": "🏭", "color": "red"},
+			{"name": "Cancel", "command": "deploy_cancel", "type": "cancel", "icon": "✖"}
+		]
+	}`,
+
+	"deploy_review": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Step 2: review the plan."},
+			{"type": "table", "content": {"headers": ["Service", "From", "To"], "rows": [["api-gateway", "1.4.2", "1.5.0"], ["billing", "2.0.1", "2.1.0"]], "borders": true}}
+		]},
+		"workflow": {"id": "deploy-demo", "step": 2, "totalSteps": 3, "title": "Deploy", "steps": ["Choose environment", "Review plan", "Roll out"]},
+		"actions": [
+			{"name": "Deploy now", "command": "deploy_apply", "type": "confirmation", "icon": "🚀"},
+			{"name": "Cancel", "command": "deploy_cancel", "type": "cancel", "icon": "✖"}
+		]
+	}`,
+
+	"deploy_apply": `{
+		"response": {"type": "structured", "content": [
+			{"type": "text", "content": "Step 3: deployment complete.", "status": "success"},
+			{"type": "progress", "content": {"label": "Rollout", "progress": 100, "status": "complete", "showPercent": true}},
+			{"type": "checklist", "content": {"title": "Rollout", "steps": [
+				{"label": "api-gateway 1.5.0", "status": "done", "detail": "3/3 pods ready"},
+				{"label": "billing 2.1.0", "status": "done", "detail": "2/2 pods ready"},
+				{"label": "Smoke tests", "status": "done"}
+			]}}
+		]},
+		"workflow": {"id": "deploy-demo", "step": 3, "totalSteps": 3, "title": "Deploy", "steps": ["Choose environment", "Review plan", "Roll out"]}
+	}`,
+
+	"deploy_cancel": `{
+		"response": {"type": "text", "content": "Deployment cancelled. Nothing was changed."}
+	}`,
+}
+
+// demoBrokenError is the structured error returned by the "broken" command
+const demoBrokenError = `{
+	"error": {
+		"message": "Validation failed: 'region' is required",
+		"code": "DEMO_VALIDATION",
+		"details": {"type": "text", "content": "The broken command always fails so the error pane can be shown. Try 'status' instead."},
+		"recoveryActions": [
+			{"name": "Run status instead", "command": "status", "type": "primary", "icon": "📊"},
+			{"name": "Show help", "command": "help", "type": "info", "icon": "❓"}
+		]
+	}
+}`
+
+// DemoClient is a ProtocolClient that answers from canned responses instead of a backend
+type DemoClient struct {
+	mutex     sync.RWMutex
+	connected bool
+}
+
+// NewDemoClient creates a demo client
+func NewDemoClient() *DemoClient {
+	return &DemoClient{}
+}
+
+// demoCommands is the command catalog the demo client publishes in its handshake
+var demoCommands = []interfaces.CommandInfo{
+	{Name: "help", Description: "List the demo commands"},
+	{Name: "status", Description: "Service overview with a table and progress"},
+	{Name: "code", Description: "Syntax-highlighted source"},
+	{Name: "details", Description: "Labeled fields and collapsible sections"},
+	{Name: "users", Description: "A paginated list (use Show more)"},
+	{Name: "files", Description: "An interactive tree"},
+	{Name: "logs", Description: "Pre-formatted ANSI output with the full log fetched on expand"},
+	{Name: "invite", Description: "A form that sends its values with an action"},
+	{Name: "deploy", Description: "A three-step workflow with confirmation"},
+	{Name: "broken", Description: "A failing command with recovery actions"},
+}
+
+// demoSpec is the handshake reported by the demo client
+func demoSpec() *interfaces.SpecResponse {
+	return &interfaces.SpecResponse{
+		AppName:         "Demo Application",
+		AppVersion:      "1.0.0",
+		ProtocolVersion: "2.0",
+		Features:        map[string]bool{"cancel": true, "suggest": true},
+		Commands:        append([]interfaces.CommandInfo(nil), demoCommands...),
+	}
+}
+
+// Connect always succeeds
+func (d *DemoClient) Connect(ctx context.Context, host string, auth *interfaces.AuthConfig) (*interfaces.SpecResponse, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.connected = true
+	return demoSpec(), nil
+}
+
+// ExecuteCommand answers a command from the canned responses
+func (d *DemoClient) ExecuteCommand(ctx context.Context, request interfaces.CommandRequest) (*interfaces.CommandResponse, error) {
+	return d.respond(ctx, strings.ToLower(strings.TrimSpace(request.Command)))
+}
+
+// ExecuteAction answers an action from the canned responses
+func (d *DemoClient) ExecuteAction(ctx context.Context, request interfaces.ActionRequest) (*interfaces.CommandResponse, error) {
+	return d.respond(ctx, request.Command)
+}
+
+// GetSuggestions suggests the demo commands that start with the current input
+func (d *DemoClient) GetSuggestions(ctx context.Context, request interfaces.SuggestRequest) (*interfaces.SuggestResponse, error) {
+	response := &interfaces.SuggestResponse{}
+	for _, command := range demoCommands {
+		if strings.HasPrefix(command.Name, request.CurrentInput) {
+			response.Suggestions = append(response.Suggestions, interfaces.SuggestionItem{Text: command.Name, Type: "command"})
+		}
+	}
+	return response, nil
+}
+
+// GetProgress reports every operation as complete, including those of a batched request
+func (d *DemoClient) GetProgress(ctx context.Context, request interfaces.ProgressRequest) (*interfaces.ProgressResponse, error) {
+	response := &interfaces.ProgressResponse{Progress: 100, Status: "complete", Message: "Demo operation complete"}
+	if len(request.OperationIDs) > 0 {
+		response.Operations = make(map[string]interfaces.ProgressResponse, len(request.OperationIDs))
+		for _, id := range request.OperationIDs {
+			response.Operations[id] = interfaces.ProgressResponse{Progress: 100, Status: "complete", Message: "Demo operation complete"}
+		}
+	}
+	return response, nil
+}
+
+// CancelOperation accepts every cancellation
+func (d *DemoClient) CancelOperation(ctx context.Context, request interfaces.CancelRequest) (*interfaces.CancelResponse, error) {
+	return &interfaces.CancelResponse{Cancelled: true, Message: "Demo operation cancelled"}, nil
+}
+
+// FetchReference serves the full log referenced by the "logs" response and fails for any other URL
+func (d *DemoClient) FetchReference(ctx context.Context, url string) (interface{}, error) {
+	if !d.IsConnected() {
+		return nil, fmt.Errorf("not connected to any application")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(demoLatency):
+	}
+
+	if url != "/demo/logs/full" {
+		return nil, demoError(fmt.Sprintf(`{"error": {"message": %q, "code": "NOT_FOUND"}}`,
+			fmt.Sprintf("No demo content at %s", url)))
+	}
+
+	levels := []string{"\u001b[32mINFO\u001b[0m ", "\u001b[32mINFO\u001b[0m ", "\u001b[33mWARN\u001b[0m ", "\u001b[32mINFO\u001b[0m "}
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%s request %d served in %dms", levels[i%len(levels)], 1000+i, 8+i*7%50)
+	}
+	return map[string]interface{}{"type": "ansi", "content": strings.Join(lines, "\n")}, nil
+}
+
+// IsConnected returns whether Connect has been called since the last Disconnect
+func (d *DemoClient) IsConnected() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.connected
+}
+
+// Disconnect marks the client as disconnected
+func (d *DemoClient) Disconnect() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.connected = false
+	return nil
+}
+
+// GetLastError always returns nil; demo requests only fail on purpose
+func (d *DemoClient) GetLastError() error {
+	return nil
+}
+
+// SetRateLimit is accepted and ignored
+func (d *DemoClient) SetRateLimit(requestsPerSecond float64, burst int) {}
+
+// SetCircuitBreaker is accepted and ignored
+func (d *DemoClient) SetCircuitBreaker(failureThreshold int, cooldown time.Duration) {}
+
+// CircuitState always reports a closed circuit
+func (d *DemoClient) CircuitState() string {
+	return "closed"
+}
+
+// SetIdlePolicy is accepted and ignored
+func (d *DemoClient) SetIdlePolicy(keepAlive, idleTimeout time.Duration) {}
+
+// SetConnectionPool is accepted and ignored
+func (d *DemoClient) SetConnectionPool(poolSize, maxConnections int, disableHTTP2 bool) {}
+
+// SetPushHandler is accepted and ignored; the demo application pushes nothing
+func (d *DemoClient) SetPushHandler(handler PushHandler) {}
+
+// GetConnectionState reports the demo application as connected
+func (d *DemoClient) GetConnectionState() *ConnectionState {
+	spec := demoSpec()
+	return &ConnectionState{
+		Connected:  d.IsConnected(),
+		Host:       DemoHost,
+		AppName:    spec.AppName,
+		AppVersion: spec.AppVersion,
+		Features:   spec.Features,
+		Commands:   spec.Commands,
+	}
+}
+
+// GetAppInfo returns the demo application's details while connected
+func (d *DemoClient) GetAppInfo() *interfaces.SpecResponse {
+	if !d.IsConnected() {
+		return nil
+	}
+	return demoSpec()
+}
+
+// respond waits for the simulated latency and returns the canned response for a command. Unknown
+// commands and the "broken" command fail the way a real application reports a structured error.
+func (d *DemoClient) respond(ctx context.Context, command string) (*interfaces.CommandResponse, error) {
+	if !d.IsConnected() {
+		return nil, fmt.Errorf("not connected to any application")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(demoLatency):
+	}
+
+	body, exists := demoResponses[command]
+	if !exists {
+		if command == "broken" {
+			return nil, demoError(demoBrokenError)
+		}
+		return nil, demoError(fmt.Sprintf(`{"error": {"message": %q, "code": "UNKNOWN_COMMAND",
+			"recoveryActions": [{"name": "Show help", "command": "help", "type": "info", "icon": "❓"}]}}`,
+			fmt.Sprintf("Unknown demo command '%s'", command)))
+	}
+
+	var response interfaces.CommandResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return nil, fmt.Errorf("invalid demo response for '%s': %w", command, err)
+	}
+	return &response, nil
+}
+
+// demoError wraps a structured error body in the HTTP error a real client would return
+func demoError(body string) error {
+	return &ProtocolError{
+		Type:    "http",
+		Message: "HTTP 400: Bad Request",
+		HTTPDetails: &HTTPErrorDetails{
+			StatusCode:  400,
+			StatusText:  "Bad Request",
+			Body:        body,
+			ContentType: "application/json",
+		},
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,90 @@
+// Package protocol implements readable summaries of error bodies that are not protocol JSON.
+// A request that never reaches the application can be answered by a proxy or load balancer with an
+// HTML error page, or by a framework with a plain-text stack trace. Rather than showing that markup,
+// the error message carries a short summary: the page title or its visible text for HTML, and the
+// first line for text. The full body stays in the error's HTTP details for /inspect.
+package protocol
+
+import (
+	"html"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorSummaryLength is the longest error body summary, in characters, shown in an error message
+const maxErrorSummaryLength = 200
+
+var (
+	htmlTitlePattern     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlInvisiblePattern = regexp.MustCompile(`(?is)<(head|script|style)[^>]*>.*?</(head|script|style)>`)
+	htmlTagPattern       = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// errorBodyMediaType returns the media type of an error body from its Content-Type header,
+// sniffing the body when the header is missing or invalid
+func errorBodyMediaType(contentType string, body []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+	return mediaType
+}
+
+// summarizeErrorBody returns a short, single-line description of an error body, or an empty string when
+// the body is empty or not text
+func summarizeErrorBody(mediaType string, body []byte) string {
+	var summary string
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		summary = summarizeHTML(string(body))
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		summary = firstLine(string(body))
+	default:
+		return ""
+	}
+
+	if !utf8.ValidString(summary) {
+		return ""
+	}
+	return truncateSummary(summary)
+}
+
+// summarizeHTML returns an HTML page's title, or its visible text when it has none
+func summarizeHTML(page string) string {
+	if match := htmlTitlePattern.FindStringSubmatch(page); match != nil {
+		if title := collapseWhitespace(html.UnescapeString(match[1])); title != "" {
+			return title
+		}
+	}
+
+	text := htmlInvisiblePattern.ReplaceAllString(page, " ")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	return collapseWhitespace(html.UnescapeString(text))
+}
+
+// firstLine returns the first non-blank line of text, trimmed
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// collapseWhitespace joins the words of text with single spaces
+func collapseWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// truncateSummary shortens a summary to maxErrorSummaryLength characters, marking the cut with an ellipsis
+func truncateSummary(summary string) string {
+	runes := []rune(summary)
+	if len(runes) <= maxErrorSummaryLength {
+		return summary
+	}
+	return strings.TrimSpace(string(runes[:maxErrorSummaryLength-1])) + "…"
+}
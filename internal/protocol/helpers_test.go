@@ -0,0 +1,30 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/universal-console/console/internal/auth"
+	"github.com/universal-console/console/internal/config"
+)
+
+// newTestClient returns a client with its configuration in a temporary directory, pointed at host
+func newTestClient(t testing.TB, host string) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	configManager, err := config.NewManager()
+	if err != nil {
+		t.Fatalf("config.NewManager failed: %v", err)
+	}
+	authManager, err := auth.NewManager(configManager)
+	if err != nil {
+		t.Fatalf("auth.NewManager failed: %v", err)
+	}
+	client, err := NewClient(configManager, authManager)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	client.connectionState.Host = host
+	return client
+}
@@ -0,0 +1,233 @@
+// Package protocol implements the connection idle policy for the protocol client.
+// Firewalls and proxies often drop idle TCP connections without telling either end, so the next
+// command hangs until it times out. While connected, a background monitor sends a lightweight
+// spec request after a period without traffic, so a dead connection is noticed early and shown as
+// disconnected. After a longer period without user requests, the monitor closes the underlying
+// connections, and the next request reconnects before it is sent.
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// KeepAliveTimeout bounds a single keep-alive probe
+const KeepAliveTimeout = 10 * time.Second
+
+// minIdleCheckInterval keeps the idle monitor from waking more often than needed
+const minIdleCheckInterval = time.Second
+
+// idlePolicy holds the keep-alive and idle timeout settings and the activity they are measured from
+type idlePolicy struct {
+	keepAlive    time.Duration // Probe after this long without traffic; 0 disables keep-alives
+	idleTimeout  time.Duration // Close connections after this long without requests; 0 disables
+	lastActivity time.Time     // Last request made on the user's behalf
+	lastTraffic  time.Time     // Last request or keep-alive probe
+	stop         chan struct{} // Closed to stop the running monitor
+}
+
+// checkInterval returns how often the monitor checks for idleness: half the shortest enabled period
+func (p *idlePolicy) checkInterval() time.Duration {
+	interval := p.keepAlive
+	if p.idleTimeout > 0 && (interval == 0 || p.idleTimeout < interval) {
+		interval = p.idleTimeout
+	}
+
+	interval /= 2
+	if interval < minIdleCheckInterval {
+		interval = minIdleCheckInterval
+	}
+	return interval
+}
+
+// SetIdlePolicy configures the idle policy. A keep-alive probe is sent after keepAlive without
+// traffic, and connections are closed after idleTimeout without requests, to be reopened by the
+// next request. Zero disables either behavior.
+func (c *Client) SetIdlePolicy(keepAlive, idleTimeout time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.idle.keepAlive = keepAlive
+	c.idle.idleTimeout = idleTimeout
+
+	if c.connectionState.Connected {
+		c.startIdleMonitorUnsafe()
+	}
+}
+
+// startIdleMonitorUnsafe restarts the idle monitor for a fresh connection (caller must hold lock)
+func (c *Client) startIdleMonitorUnsafe() {
+	c.stopIdleMonitorUnsafe()
+
+	now := time.Now()
+	c.idle.lastActivity = now
+	c.idle.lastTraffic = now
+
+	if c.idle.keepAlive <= 0 && c.idle.idleTimeout <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	c.idle.stop = stop
+	go c.runIdleMonitor(stop, c.idle.checkInterval())
+}
+
+// stopIdleMonitorUnsafe stops the idle monitor if one is running (caller must hold lock)
+func (c *Client) stopIdleMonitorUnsafe() {
+	if c.idle.stop != nil {
+		close(c.idle.stop)
+		c.idle.stop = nil
+	}
+}
+
+// runIdleMonitor checks the connection for idleness until stopped
+func (c *Client) runIdleMonitor(stop chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.checkIdle(stop)
+		}
+	}
+}
+
+// checkIdle closes connections that have been idle too long, or probes one that has been quiet
+func (c *Client) checkIdle(stop chan struct{}) {
+	c.mutex.Lock()
+
+	// The monitor may have been replaced while waiting for the lock
+	select {
+	case <-stop:
+		c.mutex.Unlock()
+		return
+	default:
+	}
+
+	if !c.connectionState.Connected || c.connectionState.Idle {
+		c.mutex.Unlock()
+		return
+	}
+
+	now := time.Now()
+	if c.idle.idleTimeout > 0 && now.Sub(c.idle.lastActivity) >= c.idle.idleTimeout {
+		c.closeIdleConnectionsUnsafe()
+		c.mutex.Unlock()
+		return
+	}
+
+	probe := c.idle.keepAlive > 0 && now.Sub(c.idle.lastTraffic) >= c.idle.keepAlive
+	c.mutex.Unlock()
+
+	if probe {
+		c.sendKeepAlive(stop)
+	}
+}
+
+// closeIdleConnectionsUnsafe releases the network connections while keeping the session, so the
+// next request reconnects (caller must hold lock)
+func (c *Client) closeIdleConnectionsUnsafe() {
+	if c.ws != nil {
+		c.ws.Close()
+		c.ws = nil
+	}
+	c.httpClient.CloseIdleConnections()
+	c.connectionState.Idle = true
+
+	c.logger.Info("Closed idle connection",
+		"host", c.connectionState.Host,
+		"idle_timeout", c.idle.idleTimeout)
+}
+
+// sendKeepAlive probes the application with a spec request, marking the client disconnected if it fails
+func (c *Client) sendKeepAlive(stop chan struct{}) {
+	c.mutex.RLock()
+	host := c.connectionState.Host
+	auth := c.connectionState.Auth
+	transport := c.ws
+	c.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), KeepAliveTimeout)
+	defer cancel()
+
+	var err error
+	if transport != nil {
+		var reply wsMessage
+		reply, err = transport.roundTrip(ctx, wsEndpointTypes[EndpointSpec], struct{}{})
+		if err == nil && reply.Type != "response" {
+			err = fmt.Errorf("server returned status %d", reply.Status)
+		}
+	} else {
+		var req *http.Request
+		req, err = c.createHandshakeRequest(ctx, c.buildURL(host, EndpointSpec), auth)
+		if err == nil {
+			var resp *http.Response
+			resp, err = c.httpClient.Do(req)
+			if err == nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					err = fmt.Errorf("server returned status %s", resp.Status)
+				}
+			}
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Ignore the result if the connection changed while the probe was in flight
+	select {
+	case <-stop:
+		return
+	default:
+	}
+
+	if err != nil {
+		c.logger.Warn("Keep-alive failed, marking connection lost", "host", host, "error", err.Error())
+		c.connectionState.Connected = false
+		c.connectionState.LastError = &ProtocolError{
+			Type:          "network",
+			Message:       fmt.Sprintf("keep-alive failed: %v", err),
+			OriginalError: err,
+			Timestamp:     time.Now(),
+			Recoverable:   true,
+		}
+		c.stopIdleMonitorUnsafe()
+		return
+	}
+
+	now := time.Now()
+	c.idle.lastTraffic = now
+	c.connectionState.LastKeepAlive = now
+	c.logger.Debug("Keep-alive succeeded", "host", host)
+}
+
+// recordActivity resets the idle clock and reopens the connection if it was closed for inactivity
+func (c *Client) recordActivity(ctx context.Context) error {
+	c.mutex.Lock()
+	now := time.Now()
+	c.idle.lastActivity = now
+	c.idle.lastTraffic = now
+
+	idle := c.connectionState.Idle
+	host := c.connectionState.Host
+	auth := c.connectionState.Auth
+	c.mutex.Unlock()
+
+	if !idle {
+		return nil
+	}
+
+	c.logger.Info("Reconnecting after idle timeout", "host", host)
+	if _, err := ConnectWithRetry(ctx, c, host, auth, idleReconnectPolicy, nil); err != nil {
+		return err
+	}
+	return nil
+}
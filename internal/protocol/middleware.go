@@ -0,0 +1,53 @@
+// Package protocol implements the response middleware chain for the protocol client.
+// This file lets callers register transformers that run on every parsed command and action
+// response, for example to normalize legacy response shapes, inject actions, or strip fields.
+package protocol
+
+import (
+	"fmt"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// ResponseMiddleware transforms a parsed command or action response. It may modify the
+// response in place or return a replacement. Returning an error fails the request.
+type ResponseMiddleware func(*interfaces.CommandResponse) (*interfaces.CommandResponse, error)
+
+// Use registers a response middleware.
+//
+// Ordering guarantees:
+//   - Middlewares run in registration order, each receiving the previous one's result.
+//   - The chain runs once per request, after retries have produced a parsed response
+//     and before the response is validated, so middlewares can repair invalid shapes.
+//   - The chain applies to ExecuteCommand and ExecuteAction only; suggestion, progress,
+//     and cancel responses are not passed through it.
+//
+// Middlewares registered while a request is in flight take effect from the next request.
+func (c *Client) Use(middleware ResponseMiddleware) {
+	if middleware == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.middlewares = append(c.middlewares, middleware)
+}
+
+// applyMiddleware runs the registered middleware chain over a response
+func (c *Client) applyMiddleware(response *interfaces.CommandResponse) (*interfaces.CommandResponse, error) {
+	c.mutex.RLock()
+	chain := make([]ResponseMiddleware, len(c.middlewares))
+	copy(chain, c.middlewares)
+	c.mutex.RUnlock()
+
+	for i, middleware := range chain {
+		transformed, err := middleware(response)
+		if err != nil {
+			return nil, fmt.Errorf("response middleware %d failed: %w", i+1, err)
+		}
+		response = transformed
+	}
+
+	return response, nil
+}
@@ -0,0 +1,78 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// newLegacyClient returns a client connected to a server that answers every command with a legacy "markdown" response
+func newLegacyClient(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": {"type": "markdown", "content": "# Deployed"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, server.URL)
+	client.connectionState.Connected = true
+	return client
+}
+
+func TestMiddlewareRewritesContentType(t *testing.T) {
+	client := newLegacyClient(t)
+
+	var seen []string
+	client.Use(func(response *interfaces.CommandResponse) (*interfaces.CommandResponse, error) {
+		seen = append(seen, response.Response.Type)
+		if response.Response.Type == "markdown" {
+			response.Response.Type = "text"
+		}
+		return response, nil
+	})
+	client.Use(func(response *interfaces.CommandResponse) (*interfaces.CommandResponse, error) {
+		seen = append(seen, response.Response.Type)
+		response.Actions = append(response.Actions, interfaces.Action{Name: "Refresh", Command: "status", Type: "info"})
+		return response, nil
+	})
+
+	response, err := client.ExecuteCommand(context.Background(), interfaces.CommandRequest{Command: "deploy"})
+	if err != nil {
+		t.Fatalf("ExecuteCommand failed: %v", err)
+	}
+	if response.Response.Type != "text" || response.Response.Content != "# Deployed" {
+		t.Errorf("response = %+v, want the content as text", response.Response)
+	}
+	if len(response.Actions) != 1 || response.Actions[0].Name != "Refresh" {
+		t.Errorf("actions = %+v, want the injected Refresh action", response.Actions)
+	}
+
+	// The second middleware ran after the first, on its result
+	if len(seen) != 2 || seen[0] != "markdown" || seen[1] != "text" {
+		t.Errorf("middlewares saw content types %v, want [markdown text]", seen)
+	}
+}
+
+func TestMiddlewareErrorFailsRequest(t *testing.T) {
+	client := newLegacyClient(t)
+
+	ran := false
+	client.Use(func(response *interfaces.CommandResponse) (*interfaces.CommandResponse, error) {
+		return nil, fmt.Errorf("unsupported content type %q", response.Response.Type)
+	})
+	client.Use(func(response *interfaces.CommandResponse) (*interfaces.CommandResponse, error) {
+		ran = true
+		return response, nil
+	})
+
+	if _, err := client.ExecuteCommand(context.Background(), interfaces.CommandRequest{Command: "deploy"}); err == nil {
+		t.Error("ExecuteCommand succeeded although a middleware failed")
+	}
+	if ran {
+		t.Error("a middleware ran after an earlier one failed")
+	}
+}
@@ -0,0 +1,145 @@
+// Package protocol implements client-side request throttling for the protocol client.
+// This file provides a token-bucket limiter applied to every JSON request, so rapid commands and
+// action clicks are spaced out before they reach rate-limited gateways, and parses the server's
+// Retry-After header when a request is rejected with 429 Too Many Requests.
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRetryAfterWait is the longest Retry-After delay the client waits for automatically.
+// Longer delays are reported to the user instead of blocking the interface.
+const maxRetryAfterWait = 30 * time.Second
+
+// rateLimiter is a token bucket that refills at a fixed rate up to a burst capacity. It is safe for concurrent use.
+type rateLimiter struct {
+	mutex    sync.Mutex
+	rate     float64 // Tokens added per second
+	burst    float64 // Maximum tokens held
+	tokens   float64
+	lastFill time.Time
+}
+
+// newRateLimiter creates a full token bucket. A burst below one is treated as one.
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:     requestsPerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context ends. It fails fast, without consuming a token,
+// when the context deadline would expire before the next token becomes available.
+func (rl *rateLimiter) Wait(ctx context.Context) error {
+	delay := rl.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		rl.cancel()
+		return fmt.Errorf("rate limit exceeded: next request allowed in %s", delay.Round(time.Millisecond))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		rl.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the caller must wait for it
+func (rl *rateLimiter) reserve() time.Duration {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := time.Now()
+	rl.tokens += now.Sub(rl.lastFill).Seconds() * rl.rate
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.lastFill = now
+
+	rl.tokens--
+	if rl.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-rl.tokens / rl.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (rl *rateLimiter) cancel() {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.tokens++
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+}
+
+// SetRateLimit limits outgoing requests to requestsPerSecond, allowing bursts of up to burst requests.
+// A rate of zero or less disables throttling.
+func (c *Client) SetRateLimit(requestsPerSecond float64, burst int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if requestsPerSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(requestsPerSecond, burst)
+}
+
+// waitForRateLimit blocks until the configured rate limit allows another request
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	c.mutex.RLock()
+	limiter := c.limiter
+	c.mutex.RUnlock()
+
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+// parseRetryAfter reads a Retry-After header given as delay seconds or an HTTP date.
+// It returns zero when the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := newRateLimiter(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait %d failed: %v", i+1, err)
+		}
+	}
+
+	// The burst of two passes at once, and the other four wait 50ms each
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("six requests at 20/s with a burst of 2 took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestRateLimiterFailsFastPastDeadline(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatal("Wait succeeded although the next token is a second away")
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait blocked for %s before failing, want it to fail at once", elapsed)
+	}
+
+	// The refused request did not consume the token that becomes available next
+	if delay := limiter.reserve(); delay > time.Second {
+		t.Errorf("next token is %s away, want at most 1s", delay)
+	}
+}
+
+func TestClientRateLimitSpacesRequests(t *testing.T) {
+	var mutex sync.Mutex
+	var arrivals []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		arrivals = append(arrivals, time.Now())
+		mutex.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetRateLimit(10, 1)
+	for i := 0; i < 4; i++ {
+		if _, err := client.executeJSONRequest(context.Background(), EndpointCommand, struct{}{}); err != nil {
+			t.Fatalf("request %d failed: %v", i+1, err)
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for i := 1; i < len(arrivals); i++ {
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap < 90*time.Millisecond {
+			t.Errorf("requests %d and %d arrived %s apart, want at least 100ms at 10/s", i, i+1, gap)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package protocol implements connecting with retries and backoff.
+// A backend started moments before the console may not be listening yet, and a connection reopened
+// after an idle timeout may meet a server that is restarting. ConnectWithRetry is the single path for
+// both: it retries handshakes that failed at the network level, doubling the delay between attempts up
+// to a limit, and gives up at once on errors that retrying cannot fix, such as invalid configuration,
+// rejected credentials, or an incompatible server.
+package protocol
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/universal-console/console/internal/errors"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// ConnectPolicy controls how often a connection is attempted and how long to wait between attempts
+type ConnectPolicy struct {
+	Attempts     int           // Total connection attempts, including the first; values below 1 mean one
+	InitialDelay time.Duration // Wait before the second attempt, doubled for each attempt after it
+	MaxDelay     time.Duration // Longest wait between attempts
+}
+
+// DefaultConnectPolicy retries the initial connection for about eight seconds
+var DefaultConnectPolicy = ConnectPolicy{
+	Attempts:     5,
+	InitialDelay: 500 * time.Millisecond,
+	MaxDelay:     8 * time.Second,
+}
+
+// idleReconnectPolicy reconnects after an idle timeout with one quick retry, as a request is waiting
+var idleReconnectPolicy = ConnectPolicy{
+	Attempts:     2,
+	InitialDelay: 500 * time.Millisecond,
+	MaxDelay:     500 * time.Millisecond,
+}
+
+// delay returns the wait after the given failed attempt, counting from 1
+func (p ConnectPolicy) delay(attempt int) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// ConnectRetryFunc is told about each failed attempt that will be retried: the attempt that failed,
+// counting from 1, the total number of attempts, the error, and the wait before the next attempt
+type ConnectRetryFunc func(attempt, attempts int, err error, wait time.Duration)
+
+// ConnectWithRetry connects to the application, retrying network failures according to the policy.
+// onRetry may be nil.
+func ConnectWithRetry(ctx context.Context, client interfaces.ProtocolClient, host string, auth *interfaces.AuthConfig,
+	policy ConnectPolicy, onRetry ConnectRetryFunc) (*interfaces.SpecResponse, error) {
+	attempts := policy.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		spec, err := client.Connect(ctx, host, auth)
+		if err == nil || attempt >= attempts || !isTransientConnectError(err) {
+			return spec, err
+		}
+
+		wait := policy.delay(attempt)
+		if onRetry != nil {
+			onRetry(attempt, attempts, err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isTransientConnectError reports whether a connection failed at the network level, such as a refused
+// connection or a timeout, so that a later attempt may succeed
+func isTransientConnectError(err error) bool {
+	var contextual *errors.ContextualError
+	return stderrors.As(err, &contextual) && contextual.Type == errors.ErrorTypeNetwork
+}
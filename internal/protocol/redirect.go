@@ -0,0 +1,62 @@
+// Package protocol implements the redirect policy for the protocol client.
+// net/http follows redirects on its own, which would let a misconfigured proxy quietly turn a command's POST
+// into a GET, or send the profile's credentials to another host. Redirects are only followed while they stay
+// on the same scheme and host and keep the request method, as 307 and 308 redirects do; anything else is
+// refused with a RedirectError that says where the redirect led and why it was not followed, so the profile's
+// host can be corrected. Because a redirect never leaves the original host, credentials never do either.
+package protocol
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxRedirects is how many redirects are followed for one request, as net/http does by default
+const maxRedirects = 10
+
+// RedirectError reports a redirect the client refused to follow
+type RedirectError struct {
+	From   *url.URL
+	To     *url.URL
+	Reason string
+}
+
+// Error implements the error interface for RedirectError
+func (re *RedirectError) Error() string {
+	return fmt.Sprintf("refused redirect from %s to %s: %s", re.From.Redacted(), re.To.Redacted(), re.Reason)
+}
+
+// canonicalHost returns a URL's lowercase host with the scheme's default port made explicit
+func canonicalHost(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return strings.ToLower(u.Hostname()) + ":" + port
+}
+
+// CheckRedirect is the http.Client redirect policy: it follows a redirect only when it keeps the original
+// request's scheme, host, and method
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	original := via[0]
+	refuse := func(reason string) error {
+		return &RedirectError{From: via[len(via)-1].URL, To: req.URL, Reason: reason}
+	}
+
+	switch {
+	case len(via) >= maxRedirects:
+		return refuse(fmt.Sprintf("stopped after %d redirects", maxRedirects))
+	case req.URL.Scheme != original.URL.Scheme:
+		return refuse(fmt.Sprintf("it changes the protocol from %s to %s", original.URL.Scheme, req.URL.Scheme))
+	case canonicalHost(req.URL) != canonicalHost(original.URL):
+		return refuse(fmt.Sprintf("it leads to a different host, %s", req.URL.Host))
+	case req.Method != original.Method:
+		return refuse(fmt.Sprintf("it would turn the %s request into a %s", original.Method, req.Method))
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package protocol implements fetching the content of reference blocks for the protocol client.
+// A "reference" content block names a URL where the application serves content too large to send inline.
+// The content is fetched with a GET request carrying the session's credentials, so a reference must stay on
+// the application's host: a path is resolved against the host, and a URL on any other host or scheme,
+// including a scheme-relative one, is refused rather than sent the credentials. The resource may be a
+// command response, whose content is used, bare response content such as a block or an array of blocks,
+// or plain text, up to MaxReferenceSize bytes.
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxReferenceSize is the largest referenced resource fetched, in bytes. A reference names content too large
+// to send inline, but it is still held in memory and rendered, so a larger one is refused rather than read.
+const MaxReferenceSize = 16 << 20
+
+// resolveReferenceURL resolves a reference against the application's host, refusing URLs on other hosts
+func resolveReferenceURL(host, reference string) (*url.URL, error) {
+	if isWebSocketHost(host) {
+		host = httpHostFor(host)
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	base, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %s: %w", host, err)
+	}
+
+	target, err := url.Parse(reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference URL %s: %w", reference, err)
+	}
+	// Check the resolved URL, since a scheme-relative reference such as "//other.example/x" names its own host
+	resolved := base.ResolveReference(target)
+	if resolved.Scheme != base.Scheme || canonicalHost(resolved) != canonicalHost(base) {
+		return nil, fmt.Errorf("reference %s is not on the application's host %s", resolved.Redacted(), base.Host)
+	}
+	return resolved, nil
+}
+
+// FetchReference fetches the content of a reference block with an authenticated GET request.
+func (c *Client) FetchReference(ctx context.Context, reference string) (interface{}, error) {
+	if !c.IsConnected() {
+		return nil, fmt.Errorf("not connected to any application")
+	}
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultRequestTimeout)
+		defer cancel()
+	}
+
+	c.mutex.RLock()
+	host := c.connectionState.Host
+	auth := c.connectionState.Auth
+	c.mutex.RUnlock()
+
+	target, err := resolveReferenceURL(host, reference)
+	if err != nil {
+		return nil, c.wrapProtocolError("reference not fetched", err)
+	}
+
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, c.wrapProtocolError("request not sent", err)
+	}
+	if err := c.recordActivity(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target.String(), nil)
+	if err != nil {
+		return nil, c.wrapProtocolError("failed to create request", err)
+	}
+	c.setStandardHeaders(req)
+	req.Header.Set("Accept", "application/json, text/plain")
+	if auth != nil && auth.Type != "none" {
+		if err := c.setAuthenticationHeaders(req, auth); err != nil {
+			return nil, c.wrapProtocolError("failed to set authentication headers", err)
+		}
+	}
+
+	if err := c.checkCircuit(); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	resp, err := c.httpClient.Do(req)
+	duration := time.Since(startTime)
+	c.updateRequestStatistics(duration, err == nil)
+
+	var redirectErr *RedirectError
+	if stderrors.As(err, &redirectErr) {
+		c.releaseCircuitTrial()
+		c.logger.Warn("Refused redirect", "reference", target.Redacted(), "error", redirectErr.Error())
+		return nil, c.wrapProtocolError("request was redirected", redirectErr)
+	}
+	if err != nil {
+		c.recordCircuitResult(true)
+		c.logger.Error("Reference request failed", "reference", target.Redacted(), "error", err.Error())
+		return nil, c.wrapNetworkError("request execution failed", err)
+	}
+	defer resp.Body.Close()
+
+	c.logger.LogHTTPRequest(req.Method, target.Redacted(), resp.StatusCode, duration)
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxReferenceSize+1))
+	c.recordCircuitResult(err != nil || resp.StatusCode >= 500)
+	if err != nil {
+		return nil, c.wrapNetworkError("failed to read response body", err)
+	}
+	if len(body) > MaxReferenceSize {
+		return nil, c.wrapProtocolError("reference not fetched",
+			fmt.Errorf("referenced content is larger than %d bytes", MaxReferenceSize))
+	}
+	if resp.StatusCode >= 300 {
+		return nil, c.handleHTTPError(resp, body)
+	}
+
+	return c.parseReferenceBody(resp.Header.Get("Content-Type"), body)
+}
+
+// parseReferenceBody returns the content of a fetched reference: the content of a command response, bare
+// JSON content, or plain text
+func (c *Client) parseReferenceBody(contentType string, body []byte) (interface{}, error) {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == "text/plain" {
+		return string(body), nil
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, c.wrapProtocolError("failed to parse referenced content", err)
+	}
+
+	if envelope, ok := content.(map[string]interface{}); ok {
+		if response, ok := envelope["response"].(map[string]interface{}); ok {
+			if inner, ok := response["content"]; ok {
+				return inner, nil
+			}
+		}
+	}
+	return content, nil
+}
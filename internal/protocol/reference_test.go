@@ -0,0 +1,72 @@
+package protocol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveReferenceURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		reference string
+		want      string
+		wantErr   bool
+	}{
+		{"path", "https://app.example", "/logs/full", "https://app.example/logs/full", false},
+		{"relative path", "https://app.example/api/", "logs", "https://app.example/api/logs", false},
+		{"same host", "https://app.example", "https://APP.example:443/logs", "https://APP.example:443/logs", false},
+		{"bare host", "localhost:8080", "/logs", "http://localhost:8080/logs", false},
+		{"other host", "https://app.example", "https://evil.example/x", "", true},
+		{"other scheme", "https://app.example", "http://app.example/x", "", true},
+		{"other port", "https://app.example", "https://app.example:8443/x", "", true},
+		{"scheme-relative", "https://app.example", "//evil.example/x", "", true},
+		{"scheme-relative same host", "https://app.example", "//app.example/x", "https://app.example/x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveReferenceURL(tt.host, tt.reference)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveReferenceURL(%q, %q) = %s, want error", tt.host, tt.reference, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveReferenceURL(%q, %q) failed: %v", tt.host, tt.reference, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("resolveReferenceURL(%q, %q) = %s, want %s", tt.host, tt.reference, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchReferenceRefusesOversizedContent(t *testing.T) {
+	size := MaxReferenceSize
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Repeat("x", size)))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.connectionState.Connected = true
+
+	content, err := client.FetchReference(context.Background(), "/logs")
+	if err != nil {
+		t.Fatalf("FetchReference of %d bytes failed: %v", size, err)
+	}
+	if text, ok := content.(string); !ok || len(text) != size {
+		t.Fatalf("FetchReference returned %T of the wrong size, want all %d bytes", content, size)
+	}
+
+	size++
+	if _, err := client.FetchReference(context.Background(), "/logs"); err == nil {
+		t.Errorf("FetchReference of %d bytes succeeded, want it refused", size)
+	}
+}
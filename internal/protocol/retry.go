@@ -0,0 +1,85 @@
+// Package protocol implements observing and limiting the automatic retries of requests.
+// Commands and actions that fail with a transient error are retried a few times before the error is
+// reported, which looks like a hang from the user's point of view. A caller can attach a RequestRetryFunc
+// to a request's context to be told about each retry as it is scheduled, and a RetryBudget shared by many
+// requests to cap how many retries they may make in total; once the budget is spent, failures are returned
+// at once instead of being retried.
+package protocol
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RequestRetryFunc is told about each retry of a request before waiting for it: the retry number,
+// counting from 1, the most retries the request may make, the error that is retried, and the wait
+type RequestRetryFunc func(retry, retries int, err error, wait time.Duration)
+
+// RetryBudget caps the automatic retries made by the requests sharing it. It is safe for concurrent use.
+type RetryBudget struct {
+	mutex     sync.Mutex
+	remaining int
+	exhausted bool // A retry was refused since the budget was last reset
+}
+
+// NewRetryBudget creates a budget allowing the given number of retries in total
+func NewRetryBudget(retries int) *RetryBudget {
+	return &RetryBudget{remaining: retries}
+}
+
+// take spends one retry, reporting whether the budget allowed it
+func (b *RetryBudget) take() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.remaining <= 0 {
+		b.exhausted = true
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+// Remaining returns the number of retries left
+func (b *RetryBudget) Remaining() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.remaining
+}
+
+// Exhausted reports whether a retry has been refused since the budget was last reset
+func (b *RetryBudget) Exhausted() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.exhausted
+}
+
+// Reset restores the budget to the given number of retries
+func (b *RetryBudget) Reset(retries int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.remaining = retries
+	b.exhausted = false
+}
+
+// retryContextKey identifies the retry observer and budget stored in a request context
+type retryContextKey struct{}
+
+// retryOptions holds what a request context says about retries
+type retryOptions struct {
+	notify RequestRetryFunc
+	budget *RetryBudget
+}
+
+// WithRetryObserver returns a context whose requests report each retry to notify, when not nil, and draw
+// their retries from budget, when not nil
+func WithRetryObserver(ctx context.Context, notify RequestRetryFunc, budget *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retryOptions{notify: notify, budget: budget})
+}
+
+// retryOptionsFrom returns the retry observer and budget of a request context, if any
+func retryOptionsFrom(ctx context.Context) retryOptions {
+	options, _ := ctx.Value(retryContextKey{}).(retryOptions)
+	return options
+}
@@ -0,0 +1,291 @@
+// Package protocol implements JSON Schema validation of outgoing requests.
+// This file embeds a JSON Schema for each request type and, in strict mode, checks the serialized
+// request against it before it is sent, so malformed context maps and action requests are reported
+// field by field instead of being rejected by the application. Only the schema keywords the embedded
+// schemas use are supported: type, required, properties, additionalProperties (as a boolean), items, anyOf,
+// enum, minLength, maxLength, pattern, and minimum.
+package protocol
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed schemas/*.json
+var requestSchemaFiles embed.FS
+
+// Request schema names, matching the files in schemas/
+const (
+	schemaCommand  = "command"
+	schemaAction   = "action"
+	schemaSuggest  = "suggest"
+	schemaProgress = "progress"
+	schemaCancel   = "cancel"
+)
+
+// requestSchema is the supported subset of a JSON Schema
+type requestSchema struct {
+	Type                 string                    `json:"type"`
+	Required             []string                  `json:"required"`
+	Properties           map[string]*requestSchema `json:"properties"`
+	AdditionalProperties *bool                     `json:"additionalProperties"`
+	Items                *requestSchema            `json:"items"`
+	AnyOf                []*requestSchema          `json:"anyOf"`
+	Enum                 []interface{}             `json:"enum"`
+	MinLength            *int                      `json:"minLength"`
+	MaxLength            *int                      `json:"maxLength"`
+	Pattern              string                    `json:"pattern"`
+	Minimum              *float64                  `json:"minimum"`
+
+	pattern *regexp.Regexp
+}
+
+// ValidationErrors reports every field of a request that failed validation
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface for ValidationErrors
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for i, err := range ve {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+var (
+	requestSchemas     map[string]*requestSchema
+	requestSchemasErr  error
+	requestSchemasOnce sync.Once
+)
+
+// loadRequestSchemas parses the embedded schemas once and compiles their patterns
+func loadRequestSchemas() (map[string]*requestSchema, error) {
+	requestSchemasOnce.Do(func() {
+		schemas := make(map[string]*requestSchema)
+		for _, name := range []string{schemaCommand, schemaAction, schemaSuggest, schemaProgress, schemaCancel} {
+			data, err := requestSchemaFiles.ReadFile("schemas/" + name + ".json")
+			if err != nil {
+				requestSchemasErr = fmt.Errorf("failed to read %s request schema: %w", name, err)
+				return
+			}
+
+			var schema requestSchema
+			if err := json.Unmarshal(data, &schema); err != nil {
+				requestSchemasErr = fmt.Errorf("failed to parse %s request schema: %w", name, err)
+				return
+			}
+			if err := schema.compile(); err != nil {
+				requestSchemasErr = fmt.Errorf("invalid %s request schema: %w", name, err)
+				return
+			}
+			schemas[name] = &schema
+		}
+		requestSchemas = schemas
+	})
+	return requestSchemas, requestSchemasErr
+}
+
+// compile compiles the schema's patterns, including those of nested schemas
+func (s *requestSchema) compile() error {
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = pattern
+	}
+	for _, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return err
+		}
+	}
+	for _, alternative := range s.AnyOf {
+		if err := alternative.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAgainstSchema serializes a request and checks it against the named schema, returning a
+// single ValidationError or, when several fields are wrong, ValidationErrors
+func validateAgainstSchema(name string, request interface{}) error {
+	schemas, err := loadRequestSchemas()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return &ValidationError{Field: "request", Message: fmt.Sprintf("cannot be encoded as JSON: %v", err)}
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return &ValidationError{Field: "request", Message: fmt.Sprintf("cannot be decoded as JSON: %v", err)}
+	}
+
+	errs := schemas[name].validate("", document)
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// validate checks a decoded JSON value against the schema. Field paths use dots and indexes, e.g.
+// "context.listId" or "operationIds[2]".
+func (s *requestSchema) validate(field string, value interface{}) ValidationErrors {
+	if s.Type != "" && !matchesSchemaType(s.Type, value) {
+		return ValidationErrors{{Field: schemaFieldName(field), Message: fmt.Sprintf("must be of type %s", s.Type), Value: value}}
+	}
+
+	var errs ValidationErrors
+	fail := func(message string) {
+		errs = append(errs, &ValidationError{Field: schemaFieldName(field), Message: message, Value: value})
+	}
+
+	if len(s.Enum) > 0 && !containsJSONValue(s.Enum, value) {
+		fail(fmt.Sprintf("must be one of %v", s.Enum))
+	}
+
+	switch typed := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(typed)
+		if s.MinLength != nil && length < *s.MinLength {
+			if *s.MinLength == 1 {
+				fail("cannot be empty")
+			} else {
+				fail(fmt.Sprintf("must be at least %d characters", *s.MinLength))
+			}
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail(fmt.Sprintf("exceeds maximum length of %d characters", *s.MaxLength))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(typed) {
+			fail(fmt.Sprintf("does not match the pattern %s", s.Pattern))
+		}
+
+	case float64:
+		if s.Minimum != nil && typed < *s.Minimum {
+			fail(fmt.Sprintf("must be at least %v", *s.Minimum))
+		}
+
+	case map[string]interface{}:
+		for _, required := range s.Required {
+			if _, exists := typed[required]; !exists {
+				errs = append(errs, &ValidationError{Field: joinSchemaField(field, required), Message: "is required"})
+			}
+		}
+
+		// Sorted so that errors are reported in a stable order
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if property, known := s.Properties[key]; known {
+				errs = append(errs, property.validate(joinSchemaField(field, key), typed[key])...)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				errs = append(errs, &ValidationError{Field: joinSchemaField(field, key), Message: "is not a known field"})
+			}
+		}
+
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range typed {
+				errs = append(errs, s.Items.validate(fmt.Sprintf("%s[%d]", schemaFieldName(field), i), item)...)
+			}
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, alternative := range s.AnyOf {
+			if len(alternative.validate(field, value)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail(describeAnyOf(s.AnyOf))
+		}
+	}
+
+	return errs
+}
+
+// matchesSchemaType reports whether a decoded JSON value has the given JSON Schema type
+func matchesSchemaType(schemaType string, value interface{}) bool {
+	switch typed := value.(type) {
+	case string:
+		return schemaType == "string"
+	case bool:
+		return schemaType == "boolean"
+	case float64:
+		return schemaType == "number" || (schemaType == "integer" && typed == math.Trunc(typed))
+	case map[string]interface{}:
+		return schemaType == "object"
+	case []interface{}:
+		return schemaType == "array"
+	case nil:
+		return schemaType == "null"
+	default:
+		return false
+	}
+}
+
+// containsJSONValue reports whether value equals one of the allowed values
+func containsJSONValue(allowed []interface{}, value interface{}) bool {
+	for _, candidate := range allowed {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// describeAnyOf explains an unmet anyOf, naming the required fields of each alternative when that is all they ask for
+func describeAnyOf(alternatives []*requestSchema) string {
+	var fields []string
+	for _, alternative := range alternatives {
+		if len(alternative.Required) != 1 {
+			return "does not match any of the allowed forms"
+		}
+		fields = append(fields, alternative.Required[0])
+	}
+	return fmt.Sprintf("either %s must be provided", strings.Join(fields, " or "))
+}
+
+// joinSchemaField appends a property name to a field path
+func joinSchemaField(field, property string) string {
+	if field == "" {
+		return property
+	}
+	return field + "." + property
+}
+
+// schemaFieldName names the request itself when the field path is empty
+func schemaFieldName(field string) string {
+	if field == "" {
+		return "request"
+	}
+	return field
+}
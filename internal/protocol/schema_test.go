@@ -0,0 +1,26 @@
+package protocol
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestProgressSchemaChecksOperationIDs(t *testing.T) {
+	request := interfaces.ProgressRequest{OperationID: "build", OperationIDs: []string{"upload", ""}}
+
+	err := validateAgainstSchema(schemaProgress, request)
+	var validationErr *ValidationError
+	if !stderrors.As(err, &validationErr) {
+		t.Fatalf("validateAgainstSchema = %v, want a ValidationError", err)
+	}
+	if validationErr.Field != "operationIds[1]" {
+		t.Errorf("failing field = %q, want operationIds[1]", validationErr.Field)
+	}
+
+	request.OperationIDs = []string{"upload", "test"}
+	if err := validateAgainstSchema(schemaProgress, request); err != nil {
+		t.Errorf("validateAgainstSchema of valid operation IDs = %v, want nil", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package protocol implements connection pool tuning for the protocol client.
+// Every HTTP request to an application reuses pooled keep-alive connections, so chatty sessions
+// are not slowed by a new TCP and TLS handshake per command. The pool sizes can be tuned per
+// profile, and over TLS the transport negotiates HTTP/2 through ALPN unless it is disabled, so a
+// session behind an HTTP/2 load balancer multiplexes its requests over a single connection. The
+// registry's health checks create their clients with the same tuning from the checked application's profile.
+package protocol
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Connection pool defaults
+const (
+	DefaultMaxIdleConns        = 10
+	DefaultMaxIdleConnsPerHost = 4
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// PoolConfig tunes the connection pool of an HTTP transport
+type PoolConfig struct {
+	MaxIdleConns        int           // Idle connections kept across all hosts; 0 uses the default
+	MaxIdleConnsPerHost int           // Idle connections kept per host; 0 uses the default
+	MaxConnsPerHost     int           // Connections per host, including active ones; 0 means no limit
+	IdleConnTimeout     time.Duration // How long an idle connection is kept; 0 uses the default
+	DisableHTTP2        bool          // Use HTTP/1.1 even when the server offers HTTP/2 over TLS
+}
+
+// ProfilePoolConfig returns the pool tuning a profile sets with poolSize, maxConnections, and disableHTTP2
+func ProfilePoolConfig(profile *interfaces.Profile) PoolConfig {
+	return PoolConfig{
+		MaxIdleConnsPerHost: profile.PoolSize,
+		MaxConnsPerHost:     profile.MaxConnections,
+		DisableHTTP2:        profile.DisableHTTP2,
+	}
+}
+
+// withDefaults fills unset pool sizes with the defaults
+func (pc PoolConfig) withDefaults() PoolConfig {
+	if pc.MaxIdleConns <= 0 {
+		pc.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if pc.MaxIdleConnsPerHost <= 0 {
+		pc.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if pc.MaxIdleConns < pc.MaxIdleConnsPerHost {
+		pc.MaxIdleConns = pc.MaxIdleConnsPerHost
+	}
+	if pc.IdleConnTimeout <= 0 {
+		pc.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	return pc
+}
+
+// NewTransport creates an HTTP transport with the given pool tuning
+func NewTransport(config PoolConfig) *http.Transport {
+	config = config.withDefaults()
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   DefaultConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:        config.MaxIdleConns,
+		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     config.MaxConnsPerHost,
+		IdleConnTimeout:     config.IdleConnTimeout,
+		TLSHandshakeTimeout: 10 * time.Second,
+		ForceAttemptHTTP2:   !config.DisableHTTP2,
+	}
+
+	// A non-nil, empty TLSNextProto map keeps the transport from upgrading to HTTP/2
+	if config.DisableHTTP2 {
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return transport
+}
+
+// SetConnectionPool replaces the HTTP transport with one keeping up to poolSize idle connections to
+// the application and opening at most maxConnections; zero uses the default pool size and no limit.
+// It should be called before connecting; connections pooled by the previous transport are closed.
+func (c *Client) SetConnectionPool(poolSize, maxConnections int, disableHTTP2 bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous := c.httpClient.Transport
+	c.httpClient.Transport = NewTransport(PoolConfig{
+		MaxIdleConnsPerHost: poolSize,
+		MaxConnsPerHost:     maxConnections,
+		DisableHTTP2:        disableHTTP2,
+	})
+	if transport, ok := previous.(*http.Transport); ok {
+		transport.CloseIdleConnections()
+	}
+}
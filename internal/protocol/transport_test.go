@@ -0,0 +1,83 @@
+package protocol
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// newCountingServer returns a test server that answers every request with an empty object and counts the
+// connections clients open to it
+func newCountingServer(tb testing.TB) (*httptest.Server, *atomic.Int64) {
+	tb.Helper()
+	var connections atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	tb.Cleanup(server.Close)
+	return server, &connections
+}
+
+func TestRequestsReusePooledConnections(t *testing.T) {
+	server, connections := newCountingServer(t)
+	client := newTestClient(t, server.URL)
+
+	for i := 0; i < 20; i++ {
+		if _, err := client.executeJSONRequest(context.Background(), EndpointCommand, struct{}{}); err != nil {
+			t.Fatalf("request %d failed: %v", i+1, err)
+		}
+	}
+	if opened := connections.Load(); opened != 1 {
+		t.Errorf("20 sequential requests opened %d connections, want 1", opened)
+	}
+}
+
+func TestProfilePoolConfig(t *testing.T) {
+	pool := ProfilePoolConfig(&interfaces.Profile{PoolSize: 8, MaxConnections: 2, DisableHTTP2: true})
+	if pool.MaxIdleConnsPerHost != 8 || pool.MaxConnsPerHost != 2 || !pool.DisableHTTP2 {
+		t.Errorf("ProfilePoolConfig = %+v, want 8 idle, 2 connections, and HTTP/2 disabled", pool)
+	}
+
+	transport := NewTransport(pool)
+	if transport.MaxIdleConnsPerHost != 8 || transport.MaxConnsPerHost != 2 || transport.TLSNextProto == nil {
+		t.Errorf("NewTransport(%+v) did not apply the tuning", pool)
+	}
+}
+
+// BenchmarkConcurrentRequests sends requests from parallel goroutines and reports the connections opened
+// per request; with pooling, connections are opened only up to the parallelism and then reused
+func BenchmarkConcurrentRequests(b *testing.B) {
+	server, connections := newCountingServer(b)
+	client := newTestClient(b, server.URL)
+	client.SetConnectionPool(16, 0, false)
+
+	var failures sync.Map
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.executeJSONRequest(context.Background(), EndpointCommand, struct{}{}); err != nil {
+				failures.Store(err.Error(), true)
+			}
+		}
+	})
+	b.StopTimer()
+
+	failures.Range(func(message, _ interface{}) bool {
+		b.Errorf("request failed: %s", message)
+		return false
+	})
+	b.ReportMetric(float64(connections.Load())/float64(b.N), "conns/op")
+	b.ReportMetric(float64(connections.Load()), "conns")
+}
@@ -126,15 +126,19 @@ type ErrorResponseInternal struct {
 
 // ConnectionState represents the current state of the protocol client connection
 type ConnectionState struct {
-	Connected     bool                   `json:"connected"`
-	Host          string                 `json:"host"`
-	AppName       string                 `json:"appName,omitempty"`
-	AppVersion    string                 `json:"appVersion,omitempty"`
-	LastHandshake time.Time              `json:"lastHandshake,omitempty"`
-	Features      map[string]bool        `json:"features,omitempty"`
-	Auth          *interfaces.AuthConfig `json:"-"` // Add this field to store current auth config
-	LastError     error                  `json:"lastError,omitempty"`
-	Statistics    ConnectionStatistics   `json:"statistics"`
+	Connected       bool                     `json:"connected"`
+	Host            string                   `json:"host"`
+	AppName         string                   `json:"appName,omitempty"`
+	AppVersion      string                   `json:"appVersion,omitempty"`
+	ProtocolVersion string                   `json:"protocolVersion,omitempty"`
+	LastHandshake   time.Time                `json:"lastHandshake,omitempty"`
+	Features        map[string]bool          `json:"features,omitempty"`
+	Commands        []interfaces.CommandInfo `json:"commands,omitempty"` // Command catalog from the handshake
+	Auth            *interfaces.AuthConfig   `json:"-"`                  // Add this field to store current auth config
+	LastError       error                    `json:"lastError,omitempty"`
+	Statistics      ConnectionStatistics     `json:"statistics"`
+	Idle            bool                     `json:"idle,omitempty"`          // Connections closed for inactivity; the next request reconnects
+	LastKeepAlive   time.Time                `json:"lastKeepAlive,omitempty"` // Last successful keep-alive probe
 }
 
 // ConnectionStatistics tracks communication metrics for monitoring and debugging
@@ -142,6 +146,7 @@ type ConnectionStatistics struct {
 	TotalRequests       int           `json:"totalRequests"`
 	SuccessfulRequests  int           `json:"successfulRequests"`
 	FailedRequests      int           `json:"failedRequests"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"` // Failed requests since the last success
 	AverageResponseTime time.Duration `json:"averageResponseTime"`
 	LastRequestTime     time.Time     `json:"lastRequestTime"`
 	BytesSent           int64         `json:"bytesSent"`
@@ -172,6 +177,7 @@ type HTTPErrorDetails struct {
 	Body          string            `json:"body,omitempty"`
 	ContentType   string            `json:"contentType,omitempty"`
 	ContentLength int64             `json:"contentLength"`
+	RetryAfter    time.Duration     `json:"retryAfter,omitempty"` // Parsed Retry-After header, zero if absent
 }
 
 // NetworkErrorDetails provides information about network-level connection errors
@@ -210,7 +216,14 @@ func (pe *ProtocolError) IsRetryable() bool {
 	case "network":
 		return pe.NetworkDetails != nil && pe.NetworkDetails.ErrorType == "timeout"
 	case "http":
-		return pe.HTTPDetails != nil && (pe.HTTPDetails.StatusCode == 429 || pe.HTTPDetails.StatusCode >= 500)
+		if pe.HTTPDetails == nil {
+			return false
+		}
+		if pe.HTTPDetails.StatusCode == 429 {
+			// Waiting longer than this would freeze the interface; let the user decide instead
+			return pe.HTTPDetails.RetryAfter <= maxRetryAfterWait
+		}
+		return pe.HTTPDetails.StatusCode >= 500
 	case "authentication":
 		return false // Authentication errors typically require user intervention
 	case "protocol":
@@ -236,6 +249,9 @@ func (pe *ProtocolError) GetRetryDelay() time.Duration {
 	case "http":
 		if pe.HTTPDetails != nil && pe.HTTPDetails.StatusCode == 429 {
 			// Honor Retry-After header if present, otherwise use default
+			if pe.HTTPDetails.RetryAfter > 0 {
+				return pe.HTTPDetails.RetryAfter
+			}
 			return 5 * time.Second
 		}
 	}
@@ -255,7 +271,8 @@ func (ve *ValidationError) Error() string {
 	return fmt.Sprintf("validation error for field '%s': %s", ve.Field, ve.Message)
 }
 
-// RequestValidator provides validation for protocol requests before transmission
+// RequestValidator provides validation for protocol requests before transmission. Strict mode also
+// checks each serialized request against its embedded JSON Schema.
 type RequestValidator struct {
 	strictMode bool
 }
@@ -278,10 +295,7 @@ func (rv *RequestValidator) ValidateCommandRequest(req *interfaces.CommandReques
 	}
 
 	if rv.strictMode {
-		// Additional strict mode validations
-		if len(req.Command) > 1000 {
-			return &ValidationError{Field: "command", Message: "command exceeds maximum length of 1000 characters"}
-		}
+		return validateAgainstSchema(schemaCommand, req)
 	}
 
 	return nil
@@ -298,10 +312,8 @@ func (rv *RequestValidator) ValidateActionRequest(req *interfaces.ActionRequest)
 	}
 
 	if rv.strictMode {
-		// Validate workflow ID format if present
-		if req.WorkflowID != "" && !isValidWorkflowID(req.WorkflowID) {
-			return &ValidationError{Field: "workflowId", Message: "invalid workflow ID format"}
-		}
+		// The schema also checks the workflow ID format and the types of known context entries
+		return validateAgainstSchema(schemaAction, req)
 	}
 
 	return nil
@@ -315,9 +327,7 @@ func (rv *RequestValidator) ValidateSuggestRequest(req *interfaces.SuggestReques
 
 	// Current input can be empty for suggestions
 	if rv.strictMode {
-		if len(req.CurrentInput) > 500 {
-			return &ValidationError{Field: "current_input", Message: "input exceeds maximum length of 500 characters"}
-		}
+		return validateAgainstSchema(schemaSuggest, req)
 	}
 
 	return nil
@@ -332,6 +342,15 @@ func (rv *RequestValidator) ValidateProgressRequest(req *interfaces.ProgressRequ
 	if strings.TrimSpace(req.OperationID) == "" {
 		return &ValidationError{Field: "operationId", Message: "operation ID cannot be empty"}
 	}
+	for i, id := range req.OperationIDs {
+		if strings.TrimSpace(id) == "" {
+			return &ValidationError{Field: fmt.Sprintf("operationIds[%d]", i), Message: "operation ID cannot be empty"}
+		}
+	}
+
+	if rv.strictMode {
+		return validateAgainstSchema(schemaProgress, req)
+	}
 
 	return nil
 }
@@ -346,17 +365,9 @@ func (rv *RequestValidator) ValidateCancelRequest(req *interfaces.CancelRequest)
 		return &ValidationError{Field: "identifiers", Message: "either operationId or workflowId must be provided"}
 	}
 
-	return nil
-}
-
-// isValidWorkflowID performs basic validation on workflow ID format
-func isValidWorkflowID(workflowID string) bool {
-	// Basic validation: alphanumeric characters, hyphens, and underscores
-	for _, char := range workflowID {
-		if !((char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') ||
-			(char >= '0' && char <= '9') || char == '-' || char == '_') {
-			return false
-		}
+	if rv.strictMode {
+		return validateAgainstSchema(schemaCancel, req)
 	}
-	return len(workflowID) > 0 && len(workflowID) <= 100
+
+	return nil
 }
@@ -0,0 +1,307 @@
+// Package protocol implements the WebSocket transport for the protocol client.
+// When a profile's host uses a ws:// or wss:// scheme, the client keeps one persistent connection
+// and sends each request as a framed JSON message carrying the same payloads as the HTTP endpoints.
+// Responses are matched to requests by ID; messages the server sends on its own, such as progress
+// updates or streamed content, are passed to a push handler. If the upgrade fails, the client
+// falls back to HTTP against the same host.
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// EndpointWebSocket is the path of the WebSocket transport endpoint
+const EndpointWebSocket = "/console/ws"
+
+// wsEndpointTypes maps HTTP endpoints to WebSocket message types
+var wsEndpointTypes = map[string]string{
+	EndpointSpec:     "spec",
+	EndpointCommand:  "command",
+	EndpointAction:   "action",
+	EndpointSuggest:  "suggest",
+	EndpointProgress: "progress",
+	EndpointCancel:   "cancel",
+}
+
+// wsMessage is the envelope for every WebSocket message in either direction.
+// Requests carry the endpoint's request payload; replies have type "response" or "error".
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Status  int             `json:"status,omitempty"` // HTTP-equivalent status for "error" replies
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// PushHandler receives messages the server sends without a matching request, e.g. progress updates
+type PushHandler = interfaces.PushHandler
+
+// wsTransport multiplexes requests over a single WebSocket connection
+type wsTransport struct {
+	conn    *wsConn
+	mutex   sync.Mutex
+	pending map[string]chan wsMessage
+	done    chan struct{}
+	err     error // Why the connection closed, set before done is closed
+	onPush  func(wsMessage)
+}
+
+// isWebSocketHost reports whether a profile host selects the WebSocket transport
+func isWebSocketHost(host string) bool {
+	return strings.HasPrefix(host, "ws://") || strings.HasPrefix(host, "wss://")
+}
+
+// httpHostFor converts a ws:// or wss:// host to its HTTP equivalent for fallback
+func httpHostFor(host string) string {
+	if strings.HasPrefix(host, "wss://") {
+		return "https://" + strings.TrimPrefix(host, "wss://")
+	}
+	return "http://" + strings.TrimPrefix(host, "ws://")
+}
+
+// newWSTransport starts reading from an open connection
+func newWSTransport(conn *wsConn, onPush func(wsMessage)) *wsTransport {
+	t := &wsTransport{
+		conn:    conn,
+		pending: make(map[string]chan wsMessage),
+		done:    make(chan struct{}),
+		onPush:  onPush,
+	}
+	go t.readLoop()
+	return t
+}
+
+// roundTrip sends a request and waits for the reply with the same ID
+func (t *wsTransport) roundTrip(ctx context.Context, messageType string, payload interface{}) (wsMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return wsMessage{}, fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	request := wsMessage{ID: generateRequestID(), Type: messageType, Payload: data}
+	frame, err := json.Marshal(request)
+	if err != nil {
+		return wsMessage{}, fmt.Errorf("failed to marshal WebSocket message: %w", err)
+	}
+
+	reply := make(chan wsMessage, 1)
+	t.mutex.Lock()
+	t.pending[request.ID] = reply
+	t.mutex.Unlock()
+
+	defer func() {
+		t.mutex.Lock()
+		delete(t.pending, request.ID)
+		t.mutex.Unlock()
+	}()
+
+	if err := t.conn.WriteText(frame); err != nil {
+		return wsMessage{}, err
+	}
+
+	select {
+	case message := <-reply:
+		return message, nil
+	case <-t.done:
+		return wsMessage{}, t.err
+	case <-ctx.Done():
+		return wsMessage{}, ctx.Err()
+	}
+}
+
+// readLoop delivers replies to waiting requests and everything else to the push handler
+func (t *wsTransport) readLoop() {
+	for {
+		data, err := t.conn.ReadMessage()
+		if err != nil {
+			t.err = fmt.Errorf("WebSocket connection closed: %w", err)
+			close(t.done)
+			return
+		}
+
+		var message wsMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue // Malformed messages cannot be matched to a request
+		}
+
+		t.mutex.Lock()
+		reply, waiting := t.pending[message.ID]
+		t.mutex.Unlock()
+
+		if waiting && message.ID != "" {
+			// The reply channel holds one message; a duplicate reply is dropped rather than stalling reads
+			select {
+			case reply <- message:
+			default:
+			}
+		} else if t.onPush != nil {
+			t.onPush(message)
+		}
+	}
+}
+
+// closed reports whether the connection has dropped
+func (t *wsTransport) closed() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the underlying connection
+func (t *wsTransport) Close() error {
+	return t.conn.Close()
+}
+
+// SetPushHandler registers a handler for messages the server pushes without a request,
+// such as progress updates and streamed content. It applies only to WebSocket connections.
+func (c *Client) SetPushHandler(handler PushHandler) {
+	c.pushMutex.Lock()
+	defer c.pushMutex.Unlock()
+
+	c.pushHandler = handler
+}
+
+// dispatchPush passes an unsolicited server message to the registered push handler. It runs on the read
+// loop, so it does not take the client lock, which Connect holds while waiting for the handshake reply.
+func (c *Client) dispatchPush(message wsMessage) {
+	c.pushMutex.RLock()
+	handler := c.pushHandler
+	c.pushMutex.RUnlock()
+
+	if handler == nil {
+		c.logger.Debug("Ignoring unsolicited WebSocket message", "type", message.Type)
+		return
+	}
+	handler(message.Type, message.Payload)
+}
+
+// connectWebSocket upgrades to the WebSocket transport and performs the handshake over it
+// (caller must hold lock)
+func (c *Client) connectWebSocket(ctx context.Context, host string, auth *interfaces.AuthConfig) (*SpecResponseInternal, error) {
+	base, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebSocket host: %w", err)
+	}
+	wsURL := base.JoinPath(strings.TrimPrefix(EndpointWebSocket, "/")).String()
+
+	req, _ := http.NewRequest("GET", wsURL, nil)
+	c.setStandardHeaders(req)
+	if auth != nil && auth.Type != "none" {
+		if err := c.setAuthenticationHeaders(req, auth); err != nil {
+			return nil, fmt.Errorf("failed to set authentication headers: %w", err)
+		}
+	}
+
+	conn, err := dialWebSocket(ctx, wsURL, req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := newWSTransport(conn, c.dispatchPush)
+	reply, err := transport.roundTrip(ctx, wsEndpointTypes[EndpointSpec], struct{}{})
+	if err != nil {
+		transport.Close()
+		return nil, fmt.Errorf("WebSocket handshake failed: %w", err)
+	}
+	if reply.Type != "response" {
+		transport.Close()
+		return nil, fmt.Errorf("WebSocket handshake failed with status %d", reply.Status)
+	}
+
+	var specResp SpecResponseInternal
+	if err := json.Unmarshal(reply.Payload, &specResp); err != nil {
+		transport.Close()
+		return nil, fmt.Errorf("failed to parse handshake response JSON: %w", err)
+	}
+	if specResp.ProtocolVersion != ProtocolVersion {
+		transport.Close()
+		return nil, fmt.Errorf("incompatible protocol version: server=%s, client=%s", specResp.ProtocolVersion, ProtocolVersion)
+	}
+
+	specResp.Commands = normalizeCommandCatalog(specResp.Commands)
+	specResp.ReceivedAt = time.Now()
+	c.ws = transport
+	return &specResp, nil
+}
+
+// executeWebSocketRequest sends a request over the WebSocket transport and returns the reply payload
+func (c *Client) executeWebSocketRequest(ctx context.Context, transport *wsTransport, endpoint string, payload interface{}) ([]byte, error) {
+	messageType, ok := wsEndpointTypes[endpoint]
+	if !ok {
+		return nil, c.wrapProtocolError("request not sent", fmt.Errorf("no WebSocket message type for endpoint %s", endpoint))
+	}
+
+	c.logger.Debug("Executing WebSocket request", "type", messageType)
+
+	startTime := time.Now()
+	reply, err := transport.roundTrip(ctx, messageType, payload)
+	duration := time.Since(startTime)
+	c.updateRequestStatistics(duration, err == nil)
+
+	background := isBackgroundEndpoint(endpoint)
+	if err != nil {
+		if !background {
+			c.recordCircuitResult(true)
+		}
+		if transport.closed() {
+			c.mutex.Lock()
+			c.connectionState.Connected = false
+			c.mutex.Unlock()
+		}
+		c.logger.Error("WebSocket request failed", "type", messageType, "error", err.Error(), "duration", duration)
+		return nil, c.wrapNetworkError("request execution failed", err)
+	}
+
+	if !background {
+		c.recordCircuitResult(reply.Type == "error" && reply.Status >= 500)
+	}
+
+	if reply.Type == "error" {
+		c.logger.Warn("WebSocket error response", "type", messageType, "status", reply.Status)
+		return nil, c.handleWebSocketError(reply)
+	}
+
+	return reply.Payload, nil
+}
+
+// handleWebSocketError converts an error reply into a ProtocolError, mirroring handleHTTPError
+func (c *Client) handleWebSocketError(reply wsMessage) error {
+	status := reply.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	statusText := fmt.Sprintf("%d %s", status, http.StatusText(status))
+
+	protocolErr := &ProtocolError{
+		Type:          "http",
+		Message:       fmt.Sprintf("HTTP error %s", statusText),
+		OriginalError: fmt.Errorf("server returned status %s", statusText),
+		Timestamp:     time.Now(),
+		Recoverable:   status >= 500,
+		HTTPDetails:   &HTTPErrorDetails{StatusCode: status, StatusText: statusText, Body: string(reply.Payload)},
+	}
+
+	var errorResp ErrorResponseInternal
+	if err := json.Unmarshal(reply.Payload, &errorResp); err == nil && errorResp.Error.Message != "" {
+		protocolErr.Type = "http_structured"
+		protocolErr.Message = errorResp.Error.Message
+		protocolErr.OriginalError = fmt.Errorf("server returned status %s with code %s", statusText, errorResp.Error.Code)
+	}
+
+	c.mutex.Lock()
+	c.connectionState.LastError = protocolErr
+	c.mutex.Unlock()
+
+	return protocolErr
+}
@@ -0,0 +1,68 @@
+package protocol
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+// pipeWebSocket returns the two ends of an in-memory WebSocket connection
+func pipeWebSocket() (client, server *wsConn) {
+	clientEnd, serverEnd := net.Pipe()
+	return &wsConn{conn: clientEnd, reader: bufio.NewReader(clientEnd)},
+		&wsConn{conn: serverEnd, reader: bufio.NewReader(serverEnd)}
+}
+
+func TestHandshakeSurvivesPushBeforeReply(t *testing.T) {
+	clientConn, serverConn := pipeWebSocket()
+	defer serverConn.conn.Close()
+
+	client := &Client{}
+	pushed := make(chan string, 1)
+	client.SetPushHandler(func(messageType string, payload json.RawMessage) {
+		pushed <- messageType
+	})
+
+	go func() {
+		data, err := serverConn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var request wsMessage
+		if json.Unmarshal(data, &request) != nil {
+			return
+		}
+		push, _ := json.Marshal(wsMessage{Type: "progress", Payload: json.RawMessage(`{}`)})
+		serverConn.WriteText(push)
+		reply, _ := json.Marshal(wsMessage{ID: request.ID, Type: "response", Payload: json.RawMessage(`{}`)})
+		serverConn.WriteText(reply)
+	}()
+
+	// Connect holds the client lock for the whole handshake
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	transport := newWSTransport(clientConn, client.dispatchPush)
+	reply, err := transport.roundTrip(ctx, wsEndpointTypes[EndpointSpec], struct{}{})
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	if reply.Type != "response" {
+		t.Errorf("reply type = %q, want response", reply.Type)
+	}
+
+	select {
+	case messageType := <-pushed:
+		if messageType != "progress" {
+			t.Errorf("pushed message type = %q, want progress", messageType)
+		}
+	case <-ctx.Done():
+		t.Error("push handler was not called")
+	}
+}
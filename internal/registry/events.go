@@ -0,0 +1,106 @@
+// Package registry implements registry event listeners for the Universal Application Console.
+// Every registry event is delivered to registered listeners as it happens. Health events carry the
+// application's new health, so interfaces such as the Console Menu can show status changes live
+// instead of polling. Listeners are called synchronously, often while the registry is locked, so
+// they must return quickly and must not call back into the manager.
+package registry
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// healthEventBuffer is how many undelivered health events a subscriber may fall behind by before
+// further events are dropped
+const healthEventBuffer = 32
+
+// EventListener receives registry events
+type EventListener func(event RegistryEvent)
+
+// AddEventListener registers a listener for all registry events and returns a function that removes it
+func (m *Manager) AddEventListener(listener EventListener) func() {
+	m.listenersMutex.Lock()
+	defer m.listenersMutex.Unlock()
+
+	id := m.nextListenerID
+	m.nextListenerID++
+	m.listeners[id] = listener
+
+	return func() {
+		m.listenersMutex.Lock()
+		defer m.listenersMutex.Unlock()
+		delete(m.listeners, id)
+	}
+}
+
+// SubscribeHealth delivers an event each time a health check completes or a status is set, until the
+// returned cancel function is called, which also closes the channel. Events are dropped rather than
+// blocking the registry when the subscriber falls behind.
+func (m *Manager) SubscribeHealth() (<-chan interfaces.AppHealthEvent, func()) {
+	events := make(chan interfaces.AppHealthEvent, healthEventBuffer)
+
+	remove := m.AddEventListener(func(event RegistryEvent) {
+		if event.Health == nil {
+			return
+		}
+		select {
+		case events <- interfaces.AppHealthEvent{
+			AppName:    event.AppName,
+			PrevStatus: event.PrevStatus,
+			Health:     *event.Health,
+		}:
+		default:
+		}
+	})
+
+	// Listeners run under the listeners lock, so once removal returns no send can race with the close
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			remove()
+			close(events)
+		})
+	}
+
+	return events, cancel
+}
+
+// dispatchEvent delivers an event to every listener
+func (m *Manager) dispatchEvent(event RegistryEvent) {
+	m.listenersMutex.Lock()
+	defer m.listenersMutex.Unlock()
+
+	for _, listener := range m.listeners {
+		listener(event)
+	}
+}
+
+// logHealthEvent reports a new health result, as a status change when the status differs from the
+// previous one and otherwise as a passed or failed check
+func (m *Manager) logHealthEvent(appName, previousStatus string, health *interfaces.AppHealth) {
+	event := RegistryEvent{
+		Type:       EventHealthCheckPass,
+		AppName:    appName,
+		Timestamp:  health.LastChecked,
+		Details:    "Health check passed",
+		PrevStatus: previousStatus,
+		NewStatus:  health.Status,
+		Error:      health.Error,
+		Duration:   health.ResponseTime,
+	}
+
+	switch {
+	case previousStatus != health.Status:
+		event.Type = EventAppStatusChange
+		event.Details = fmt.Sprintf("Status changed from %s to %s", previousStatus, health.Status)
+	case health.Status != "ready":
+		event.Type = EventHealthCheckFail
+		event.Details = "Health check failed"
+	}
+
+	healthCopy := *health
+	event.Health = &healthCopy
+	m.dispatchEvent(event)
+}
@@ -8,23 +8,33 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"net/http"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
 )
 
 // HealthMonitor provides comprehensive health monitoring capabilities for registered applications
 type HealthMonitor struct {
-	httpClient      *http.Client
 	checkTimeouts   map[string]time.Duration
 	retryPolicies   map[string]RetryPolicy
 	healthHistory   map[string][]HealthSnapshot
 	alertThresholds map[string]AlertThreshold
 	mutex           sync.RWMutex
 	maxHistorySize  int
+
+	clients      map[string]*healthClient // Client checking each application, by application name
+	clientsMutex sync.Mutex
+}
+
+// healthClient is the client an application's health checks reuse, with its connection pool, circuit breaker,
+// and rate limiter, for as long as the application's profile stays the same
+type healthClient struct {
+	client  interfaces.ProtocolClient
+	profile interfaces.Profile // Profile the client was created for
 }
 
 // HealthCheckType represents different types of health checks that can be performed
@@ -96,29 +106,13 @@ type CheckResult struct {
 
 // NewHealthMonitor creates a new health monitor with optimized settings
 func NewHealthMonitor() *HealthMonitor {
-	// Configure HTTP client with appropriate timeouts and connection pooling
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			MaxIdleConns:        10,
-			IdleConnTimeout:     30 * time.Second,
-			DisableCompression:  false,
-			DisableKeepAlives:   false,
-			MaxIdleConnsPerHost: 2,
-		},
-	}
-
 	return &HealthMonitor{
-		httpClient:      httpClient,
 		checkTimeouts:   make(map[string]time.Duration),
 		retryPolicies:   make(map[string]RetryPolicy),
 		healthHistory:   make(map[string][]HealthSnapshot),
 		alertThresholds: make(map[string]AlertThreshold),
 		maxHistorySize:  100,
+		clients:         make(map[string]*healthClient),
 	}
 }
 
@@ -127,8 +121,26 @@ func (hm *HealthMonitor) CheckApplicationHealth(
 	ctx context.Context,
 	app *interfaces.RegisteredApp,
 	configManager interfaces.ConfigManager,
-	protocolClient interfaces.ProtocolClient,
+	newClient ClientFactory,
 ) (*interfaces.AppHealth, error) {
+	result, err := hm.CheckApplicationHealthDetailed(ctx, app, configManager, newClient)
+	if err != nil {
+		return nil, err
+	}
+
+	health := result.Overall
+	return &health, nil
+}
+
+// CheckApplicationHealthDetailed performs comprehensive health assessment for an application, returning
+// the individual check results, server information, and recommendations along with the overall health.
+// The checks use a client of their own from newClient, which later checks of the application reuse.
+func (hm *HealthMonitor) CheckApplicationHealthDetailed(
+	ctx context.Context,
+	app *interfaces.RegisteredApp,
+	configManager interfaces.ConfigManager,
+	newClient ClientFactory,
+) (*HealthCheckResult, error) {
 	startTime := time.Now()
 
 	// Load application profile for connection details
@@ -137,16 +149,24 @@ func (hm *HealthMonitor) CheckApplicationHealth(
 		return nil, fmt.Errorf("failed to load profile '%s': %w", app.Profile, err)
 	}
 
+	protocolClient, err := hm.clientFor(app.Name, profile, newClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a health check client: %w", err)
+	}
+
 	// Perform comprehensive health check
 	result, err := hm.performComprehensiveHealthCheck(ctx, app, profile, protocolClient)
 	if err != nil {
 		// Create error health status
-		health := &interfaces.AppHealth{
-			Name:         app.Name,
-			Status:       "error",
-			LastChecked:  time.Now(),
-			ResponseTime: time.Since(startTime),
-			Error:        err.Error(),
+		result = &HealthCheckResult{
+			Overall: interfaces.AppHealth{
+				Name:         app.Name,
+				Status:       "error",
+				LastChecked:  time.Now(),
+				ResponseTime: time.Since(startTime),
+				Error:        err.Error(),
+			},
+			CheckResults: make(map[HealthCheckType]CheckResult),
 		}
 
 		hm.recordHealthSnapshot(app.Name, HealthSnapshot{
@@ -157,28 +177,57 @@ func (hm *HealthMonitor) CheckApplicationHealth(
 			Error:        err.Error(),
 		})
 
-		return health, nil
+		return result, nil
 	}
 
-	// Convert comprehensive result to AppHealth
-	health := &interfaces.AppHealth{
-		Name:         app.Name,
-		Status:       result.Overall.Status,
-		LastChecked:  time.Now(),
-		ResponseTime: result.Overall.ResponseTime,
-		Error:        result.Overall.Error,
-	}
+	// The checks leave identification to the caller
+	result.Overall.Name = app.Name
+	result.Overall.LastChecked = time.Now()
 
 	// Record successful health snapshot
 	hm.recordHealthSnapshot(app.Name, HealthSnapshot{
 		Timestamp:    time.Now(),
-		Status:       health.Status,
-		ResponseTime: health.ResponseTime,
+		Status:       result.Overall.Status,
+		ResponseTime: result.Overall.ResponseTime,
 		CheckType:    HealthCheckProtocol,
 		ServerInfo:   result.ServerInfo,
 	})
 
-	return health, nil
+	return result, nil
+}
+
+// clientFor returns the client that checks an application. It is created with newClient for the first check,
+// and created again when the application's profile has changed since, disconnecting the previous one.
+func (hm *HealthMonitor) clientFor(appName string, profile *interfaces.Profile, newClient ClientFactory) (interfaces.ProtocolClient, error) {
+	hm.clientsMutex.Lock()
+	defer hm.clientsMutex.Unlock()
+
+	if cached, exists := hm.clients[appName]; exists {
+		if reflect.DeepEqual(cached.profile, *profile) {
+			return cached.client, nil
+		}
+		cached.client.Disconnect()
+		delete(hm.clients, appName)
+	}
+
+	client, err := newClient(protocol.ProfilePoolConfig(profile))
+	if err != nil {
+		return nil, err
+	}
+	hm.clients[appName] = &healthClient{client: client, profile: *profile}
+	return client, nil
+}
+
+// ForgetApplication disconnects and drops the health check client of an application that is no longer
+// registered
+func (hm *HealthMonitor) ForgetApplication(appName string) {
+	hm.clientsMutex.Lock()
+	defer hm.clientsMutex.Unlock()
+
+	if cached, exists := hm.clients[appName]; exists {
+		cached.client.Disconnect()
+		delete(hm.clients, appName)
+	}
 }
 
 // performComprehensiveHealthCheck executes all health check types
@@ -11,20 +11,28 @@ import (
 	"time"
 
 	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
 )
 
 // Manager implements the RegistryManager interface with comprehensive application management capabilities
 type Manager struct {
 	configManager    interfaces.ConfigManager
-	protocolClient   interfaces.ProtocolClient
+	newHealthClient  ClientFactory
 	healthMonitor    *HealthMonitor
 	registeredApps   map[string]*interfaces.RegisteredApp
 	appHealth        map[string]*interfaces.AppHealth
 	mutex            sync.RWMutex
 	monitoringActive bool
 	monitoringCancel context.CancelFunc
+	intervalUpdates  chan time.Duration // New check intervals for the running monitoring loop
+	activeInterval   time.Duration      // Check interval the running monitoring loop uses
 	preferences      RegistryPreferences
 	statistics       RegistryStatistics
+
+	// Event listeners, guarded separately because events are raised while mutex is held
+	listeners      map[int]EventListener
+	nextListenerID int
+	listenersMutex sync.Mutex
 }
 
 // RegistryPreferences defines configuration options for application registry behavior
@@ -69,13 +77,14 @@ type AppMetrics struct {
 type RegistryEventType string
 
 const (
-	EventAppRegistered   RegistryEventType = "app_registered"
-	EventAppUnregistered RegistryEventType = "app_unregistered"
-	EventAppStatusChange RegistryEventType = "app_status_change"
-	EventHealthCheckFail RegistryEventType = "health_check_fail"
-	EventHealthCheckPass RegistryEventType = "health_check_pass"
-	EventMonitoringStart RegistryEventType = "monitoring_start"
-	EventMonitoringStop  RegistryEventType = "monitoring_stop"
+	EventAppRegistered    RegistryEventType = "app_registered"
+	EventAppUnregistered  RegistryEventType = "app_unregistered"
+	EventAppStatusChange  RegistryEventType = "app_status_change"
+	EventHealthCheckFail  RegistryEventType = "health_check_fail"
+	EventHealthCheckPass  RegistryEventType = "health_check_pass"
+	EventMonitoringStart  RegistryEventType = "monitoring_start"
+	EventMonitoringStop   RegistryEventType = "monitoring_stop"
+	EventMonitoringUpdate RegistryEventType = "monitoring_update"
 )
 
 // RegistryEvent represents an event in the application registry
@@ -88,16 +97,25 @@ type RegistryEvent struct {
 	NewStatus  string            `json:"newStatus,omitempty"`
 	Error      string            `json:"error,omitempty"`
 	Duration   time.Duration     `json:"duration,omitempty"`
+
+	Health *interfaces.AppHealth `json:"health,omitempty"` // New health, for health check and status events
 }
 
+// ClientFactory creates a protocol client of its own for health checks, with the connection pool tuning
+// of the checked application's profile. Each application is checked with a client of its own, kept
+// between checks until the application is unregistered or its profile changes, so that checks never touch
+// the client of the active session and concurrent checks of different applications cannot interfere with
+// each other.
+type ClientFactory func(pool protocol.PoolConfig) (interfaces.ProtocolClient, error)
+
 // NewManager creates a new application registry manager with injected dependencies
-func NewManager(configManager interfaces.ConfigManager, protocolClient interfaces.ProtocolClient) (*Manager, error) {
+func NewManager(configManager interfaces.ConfigManager, newHealthClient ClientFactory) (*Manager, error) {
 	if configManager == nil {
 		return nil, fmt.Errorf("configManager cannot be nil")
 	}
 
-	if protocolClient == nil {
-		return nil, fmt.Errorf("protocolClient cannot be nil")
+	if newHealthClient == nil {
+		return nil, fmt.Errorf("newHealthClient cannot be nil")
 	}
 
 	// Initialize health monitor
@@ -116,12 +134,13 @@ func NewManager(configManager interfaces.ConfigManager, protocolClient interface
 	}
 
 	manager := &Manager{
-		configManager:  configManager,
-		protocolClient: protocolClient,
-		healthMonitor:  healthMonitor,
-		registeredApps: make(map[string]*interfaces.RegisteredApp),
-		appHealth:      make(map[string]*interfaces.AppHealth),
-		preferences:    preferences,
+		configManager:   configManager,
+		newHealthClient: newHealthClient,
+		healthMonitor:   healthMonitor,
+		registeredApps:  make(map[string]*interfaces.RegisteredApp),
+		appHealth:       make(map[string]*interfaces.AppHealth),
+		listeners:       make(map[int]EventListener),
+		preferences:     preferences,
 		statistics: RegistryStatistics{
 			ApplicationMetrics: make(map[string]AppMetrics),
 			LastUpdateTime:     time.Now(),
@@ -219,6 +238,7 @@ func (m *Manager) UnregisterApp(name string) error {
 	delete(m.appHealth, name)
 	delete(m.statistics.ApplicationMetrics, name)
 	m.statistics.TotalApplications--
+	m.healthMonitor.ForgetApplication(name)
 
 	m.logEvent(EventAppUnregistered, name, "Application unregistered", "")
 
@@ -247,13 +267,7 @@ func (m *Manager) UpdateAppStatus(name string, status interfaces.AppHealth) erro
 
 	m.appHealth[name] = &status
 	m.updateStatistics(name, &status)
-
-	// Log status change if different
-	if previousStatus != status.Status {
-		m.logEvent(EventAppStatusChange, name,
-			fmt.Sprintf("Status changed from %s to %s", previousStatus, status.Status),
-			"")
-	}
+	m.logHealthEvent(name, previousStatus, &status)
 
 	return nil
 }
@@ -290,17 +304,22 @@ func (m *Manager) StartHealthMonitoring(ctx context.Context, interval time.Durat
 	if interval == 0 {
 		monitoringInterval = m.preferences.HealthCheckInterval
 	}
+	if monitoringInterval <= 0 {
+		return fmt.Errorf("health check interval must be positive, got %v", monitoringInterval)
+	}
 
 	// Create monitoring context
 	monitoringCtx, cancel := context.WithCancel(ctx)
 	m.monitoringCancel = cancel
 	m.monitoringActive = true
+	m.intervalUpdates = make(chan time.Duration, 1)
+	m.activeInterval = monitoringInterval
 
 	m.logEvent(EventMonitoringStart, "",
 		fmt.Sprintf("Health monitoring started with interval %v", monitoringInterval), "")
 
 	// Start monitoring goroutine
-	go m.runHealthMonitoring(monitoringCtx, monitoringInterval)
+	go m.runHealthMonitoring(monitoringCtx, monitoringInterval, m.intervalUpdates)
 
 	return nil
 }
@@ -321,6 +340,8 @@ func (m *Manager) StopHealthMonitoring() error {
 	}
 
 	m.monitoringActive = false
+	m.intervalUpdates = nil
+	m.activeInterval = 0
 	m.logEvent(EventMonitoringStop, "", "Health monitoring stopped", "")
 
 	return nil
@@ -328,6 +349,18 @@ func (m *Manager) StopHealthMonitoring() error {
 
 // CheckAppHealth performs an immediate health check for a specific application
 func (m *Manager) CheckAppHealth(ctx context.Context, appName string) (*interfaces.AppHealth, error) {
+	report, err := m.CheckAppHealthDetailed(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	health := report.Overall
+	return &health, nil
+}
+
+// CheckAppHealthDetailed performs an immediate health check for a specific application, returning the
+// full breakdown of the individual checks. The overall health is stored as with CheckAppHealth.
+func (m *Manager) CheckAppHealthDetailed(ctx context.Context, appName string) (*HealthCheckResult, error) {
 	m.mutex.RLock()
 	app, exists := m.registeredApps[appName]
 	m.mutex.RUnlock()
@@ -337,25 +370,27 @@ func (m *Manager) CheckAppHealth(ctx context.Context, appName string) (*interfac
 	}
 
 	// Perform health check using health monitor
-	healthResult, err := m.healthMonitor.CheckApplicationHealth(ctx, app, m.configManager, m.protocolClient)
+	report, err := m.healthMonitor.CheckApplicationHealthDetailed(ctx, app, m.configManager, m.newHealthClient)
 	if err != nil {
 		m.logEvent(EventHealthCheckFail, appName, "Health check failed", err.Error())
 		return nil, fmt.Errorf("health check failed for application '%s': %w", appName, err)
 	}
 
-	// Update stored health information
+	// Update stored health information, keeping a copy apart from the returned report
+	health := report.Overall
+	healthResult := &health
 	m.mutex.Lock()
+	previousStatus := "unknown"
+	if existingHealth, exists := m.appHealth[appName]; exists {
+		previousStatus = existingHealth.Status
+	}
 	m.appHealth[appName] = healthResult
 	m.updateStatistics(appName, healthResult)
 	m.mutex.Unlock()
 
-	if healthResult.Status == "ready" {
-		m.logEvent(EventHealthCheckPass, appName, "Health check passed", "")
-	} else {
-		m.logEvent(EventHealthCheckFail, appName, "Health check failed", healthResult.Error)
-	}
+	m.logHealthEvent(appName, previousStatus, healthResult)
 
-	return healthResult, nil
+	return report, nil
 }
 
 // GetAppByName retrieves application details by name
@@ -387,18 +422,34 @@ func (m *Manager) GetRegistryStatistics() RegistryStatistics {
 	return statsCopy
 }
 
-// UpdatePreferences updates the registry manager preferences
+// UpdatePreferences updates the registry manager preferences. Running health monitoring picks up the
+// changes without a restart: a new check interval resets its ticker, and the concurrency and timeout
+// apply from the next check cycle, so checks already in flight finish undisturbed.
 func (m *Manager) UpdatePreferences(preferences RegistryPreferences) error {
+	if preferences.ConcurrentChecks < 1 {
+		return fmt.Errorf("concurrent checks must be at least 1, got %d", preferences.ConcurrentChecks)
+	}
+	if preferences.HealthCheckInterval <= 0 {
+		return fmt.Errorf("health check interval must be positive, got %v", preferences.HealthCheckInterval)
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	m.preferences = preferences
 
-	// If monitoring is active and interval changed, restart monitoring
-	if m.monitoringActive && m.monitoringCancel != nil {
-		// Note: This would require restarting monitoring with new interval
-		// For simplicity, we'll just update the preferences
-		// In a production implementation, we'd restart the monitoring goroutine
+	// Hand a changed interval to the monitoring loop, replacing any update it has not yet taken. The
+	// comparison is with the loop's interval, which StartHealthMonitoring may have set apart from the preferences.
+	if m.monitoringActive && preferences.HealthCheckInterval != m.activeInterval {
+		select {
+		case <-m.intervalUpdates:
+		default:
+		}
+		m.intervalUpdates <- preferences.HealthCheckInterval
+		m.activeInterval = preferences.HealthCheckInterval
+
+		m.logEvent(EventMonitoringUpdate, "",
+			fmt.Sprintf("Health monitoring interval changed to %v", preferences.HealthCheckInterval), "")
 	}
 
 	return nil
@@ -466,8 +517,8 @@ func (m *Manager) persistRegisteredApps() error {
 	return nil
 }
 
-// runHealthMonitoring executes the health monitoring loop
-func (m *Manager) runHealthMonitoring(ctx context.Context, interval time.Duration) {
+// runHealthMonitoring executes the health monitoring loop, switching to new intervals as they arrive
+func (m *Manager) runHealthMonitoring(ctx context.Context, interval time.Duration, intervalUpdates <-chan time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
@@ -475,6 +526,8 @@ func (m *Manager) runHealthMonitoring(ctx context.Context, interval time.Duratio
 		select {
 		case <-ctx.Done():
 			return
+		case interval := <-intervalUpdates:
+			ticker.Reset(interval)
 		case <-ticker.C:
 			m.performHealthCheckCycle(ctx)
 		}
@@ -488,30 +541,41 @@ func (m *Manager) performHealthCheckCycle(ctx context.Context) {
 	for _, app := range m.registeredApps {
 		apps = append(apps, app)
 	}
+	concurrentChecks := m.preferences.ConcurrentChecks
+	timeout := m.preferences.HealthCheckTimeout
 	m.mutex.RUnlock()
 
-	// Use semaphore to limit concurrent checks
-	semaphore := make(chan struct{}, m.preferences.ConcurrentChecks)
+	// Use semaphore to limit concurrent checks, sized from the preferences at the start of the cycle
+	if concurrentChecks < 1 {
+		concurrentChecks = 1
+	}
+	semaphore := make(chan struct{}, concurrentChecks)
+
+	// Wait for the cycle's checks so that a slow cycle does not overlap the next and exceed the limit
+	var wg sync.WaitGroup
+	defer wg.Wait()
 
 	for _, app := range apps {
 		select {
 		case <-ctx.Done():
 			return
 		case semaphore <- struct{}{}:
+			wg.Add(1)
 			go func(appToCheck *interfaces.RegisteredApp) {
+				defer wg.Done()
 				defer func() { <-semaphore }()
-				m.performSingleHealthCheck(ctx, appToCheck)
+				m.performSingleHealthCheck(ctx, appToCheck, timeout)
 			}(app)
 		}
 	}
 }
 
 // performSingleHealthCheck checks the health of a single application
-func (m *Manager) performSingleHealthCheck(ctx context.Context, app *interfaces.RegisteredApp) {
-	healthCtx, cancel := context.WithTimeout(ctx, m.preferences.HealthCheckTimeout)
+func (m *Manager) performSingleHealthCheck(ctx context.Context, app *interfaces.RegisteredApp, timeout time.Duration) {
+	healthCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	healthResult, err := m.healthMonitor.CheckApplicationHealth(healthCtx, app, m.configManager, m.protocolClient)
+	healthResult, err := m.healthMonitor.CheckApplicationHealth(healthCtx, app, m.configManager, m.newHealthClient)
 
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -534,15 +598,7 @@ func (m *Manager) performSingleHealthCheck(ctx context.Context, app *interfaces.
 
 	m.appHealth[app.Name] = healthResult
 	m.updateStatistics(app.Name, healthResult)
-
-	// Log significant status changes
-	if previousStatus != healthResult.Status {
-		if healthResult.Status == "ready" {
-			m.logEvent(EventHealthCheckPass, app.Name, "Application is healthy", "")
-		} else {
-			m.logEvent(EventHealthCheckFail, app.Name, "Application health check failed", healthResult.Error)
-		}
-	}
+	m.logHealthEvent(app.Name, previousStatus, healthResult)
 }
 
 // performImmediateHealthCheck performs an immediate health check for a specific application
@@ -630,6 +686,5 @@ func (m *Manager) logEvent(eventType RegistryEventType, appName, details, errorM
 		Error:     errorMsg,
 	}
 
-	// This could be enhanced to support event listeners or persistent event storage
-	_ = event
+	m.dispatchEvent(event)
 }
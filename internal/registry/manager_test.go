@@ -0,0 +1,415 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/universal-console/console/internal/config"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// fakeClient is a protocol client that records its connection state without a network. Methods the
+// health checks do not call are left to the embedded interface and panic if called.
+type fakeClient struct {
+	interfaces.ProtocolClient
+
+	mutex        sync.Mutex
+	host         string
+	auth         *interfaces.AuthConfig
+	connected    bool
+	connects     int
+	disconnects  int
+	requestCount int
+}
+
+func (f *fakeClient) Connect(ctx context.Context, host string, auth *interfaces.AuthConfig) (*interfaces.SpecResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.host, f.auth, f.connected = host, auth, true
+	f.connects++
+	return &interfaces.SpecResponse{AppName: "fake", AppVersion: "1.0", ProtocolVersion: "2.0"}, nil
+}
+
+func (f *fakeClient) GetSuggestions(ctx context.Context, request interfaces.SuggestRequest) (*interfaces.SuggestResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.requestCount++
+	return &interfaces.SuggestResponse{}, nil
+}
+
+func (f *fakeClient) IsConnected() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.connected
+}
+
+func (f *fakeClient) Disconnect() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.connected = false
+	f.disconnects++
+	return nil
+}
+
+// connectionState returns what a client is connected to
+func (f *fakeClient) connectionState() (string, *interfaces.AuthConfig, bool, int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.host, f.auth, f.connected, f.requestCount
+}
+
+// newTestConfig returns a configuration manager with its files in a temporary directory
+func newTestConfig(t *testing.T) interfaces.ConfigManager {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	configManager, err := config.NewManager()
+	if err != nil {
+		t.Fatalf("config.NewManager failed: %v", err)
+	}
+	return configManager
+}
+
+// saveTestProfile saves a profile whose host accepts TCP connections, which is all the connectivity check
+// needs, until the test ends
+func saveTestProfile(t *testing.T, configManager interfaces.ConfigManager, name string) *interfaces.Profile {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	profile := &interfaces.Profile{Name: name, Host: listener.Addr().String(), Auth: interfaces.AuthConfig{Type: "none"}}
+	if err := configManager.SaveProfile(profile); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	return profile
+}
+
+func TestHealthCycleLeavesSessionClientUntouched(t *testing.T) {
+	configManager := newTestConfig(t)
+	profile := saveTestProfile(t, configManager, "monitored")
+
+	// The session is connected to another application with its own credentials
+	sessionAuth := &interfaces.AuthConfig{Type: "bearer", Token: "session-token"}
+	session := &fakeClient{}
+	session.Connect(context.Background(), "session.example:443", sessionAuth)
+
+	var factoryMutex sync.Mutex
+	var healthClients []*fakeClient
+	newHealthClient := func(protocol.PoolConfig) (interfaces.ProtocolClient, error) {
+		factoryMutex.Lock()
+		defer factoryMutex.Unlock()
+		client := &fakeClient{}
+		healthClients = append(healthClients, client)
+		return client, nil
+	}
+
+	manager, err := NewManager(configManager, newHealthClient)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := manager.RegisterApp(interfaces.RegisteredApp{Name: "monitored", Profile: "monitored"}); err != nil {
+		t.Fatalf("RegisterApp failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	manager.performHealthCheckCycle(ctx)
+
+	host, auth, connected, requests := session.connectionState()
+	if host != "session.example:443" || auth != sessionAuth || !connected || requests != 0 {
+		t.Errorf("session client changed by the health cycle: host=%s auth=%+v connected=%v requests=%d",
+			host, auth, connected, requests)
+	}
+	if session.connects != 1 || session.disconnects != 0 {
+		t.Errorf("session client was connected %d times and disconnected %d times, want 1 and 0",
+			session.connects, session.disconnects)
+	}
+
+	factoryMutex.Lock()
+	defer factoryMutex.Unlock()
+	if len(healthClients) != 1 {
+		t.Fatalf("health cycle created %d clients, want 1", len(healthClients))
+	}
+	checked := healthClients[0]
+	if host, _, _, _ := checked.connectionState(); host != profile.Host {
+		t.Errorf("health client host=%s, want %s", host, profile.Host)
+	}
+
+	health, err := manager.GetAppHealth("monitored")
+	if err != nil {
+		t.Fatalf("GetAppHealth failed: %v", err)
+	}
+	if health.Status != "ready" {
+		t.Errorf("health status = %s (%s), want ready", health.Status, health.Error)
+	}
+}
+
+func TestHealthClientsUseProfilePoolTuning(t *testing.T) {
+	configManager := newTestConfig(t)
+	profile := saveTestProfile(t, configManager, "tuned")
+	profile.PoolSize, profile.MaxConnections, profile.DisableHTTP2 = 8, 2, true
+	if err := configManager.SaveProfile(profile); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+
+	var pools []protocol.PoolConfig
+	newHealthClient := func(pool protocol.PoolConfig) (interfaces.ProtocolClient, error) {
+		pools = append(pools, pool)
+		return &fakeClient{}, nil
+	}
+	manager, err := NewManager(configManager, newHealthClient)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := manager.RegisterApp(interfaces.RegisteredApp{Name: "tuned", Profile: "tuned"}); err != nil {
+		t.Fatalf("RegisterApp failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	manager.performHealthCheckCycle(ctx)
+
+	want := protocol.PoolConfig{MaxIdleConnsPerHost: 8, MaxConnsPerHost: 2, DisableHTTP2: true}
+	if len(pools) != 1 || pools[0] != want {
+		t.Errorf("health clients were created with %+v, want [%+v]", pools, want)
+	}
+}
+
+func TestHealthClientIsKeptUntilProfileChangesOrAppIsUnregistered(t *testing.T) {
+	configManager := newTestConfig(t)
+	profile := saveTestProfile(t, configManager, "kept")
+
+	var healthClients []*fakeClient
+	newHealthClient := func(protocol.PoolConfig) (interfaces.ProtocolClient, error) {
+		client := &fakeClient{}
+		healthClients = append(healthClients, client)
+		return client, nil
+	}
+	manager, err := NewManager(configManager, newHealthClient)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := manager.RegisterApp(interfaces.RegisteredApp{Name: "kept", Profile: "kept"}); err != nil {
+		t.Fatalf("RegisterApp failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	manager.performHealthCheckCycle(ctx)
+	manager.performHealthCheckCycle(ctx)
+	if len(healthClients) != 1 || healthClients[0].connects != 2 || healthClients[0].disconnects != 0 {
+		t.Fatalf("two cycles created %d clients, want one client connected twice and never disconnected", len(healthClients))
+	}
+
+	// A changed profile gets a new client, and the old one is disconnected
+	profile.PoolSize = 8
+	if err := configManager.SaveProfile(profile); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	manager.performHealthCheckCycle(ctx)
+	if len(healthClients) != 2 || healthClients[0].disconnects != 1 {
+		t.Fatalf("after a profile change there are %d clients, want a second one replacing the disconnected first", len(healthClients))
+	}
+
+	if err := manager.UnregisterApp("kept"); err != nil {
+		t.Fatalf("UnregisterApp failed: %v", err)
+	}
+	if healthClients[1].disconnects != 1 {
+		t.Error("the health client of an unregistered application was not disconnected")
+	}
+}
+
+// checkTracker counts the health checks in flight, recording the most that ran at once in each check cycle.
+// Cycles do not overlap and check every application once, so a check's cycle follows from its number.
+type checkTracker struct {
+	mutex        sync.Mutex
+	appsPerCycle int
+	active       int
+	checks       int
+	peaks        map[int]int // Most checks at once, by cycle number from 0
+}
+
+func (ct *checkTracker) start() {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	cycle := ct.checks / ct.appsPerCycle
+	ct.checks++
+	ct.active++
+	if ct.active > ct.peaks[cycle] {
+		ct.peaks[cycle] = ct.active
+	}
+}
+
+func (ct *checkTracker) finish() {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	ct.active--
+}
+
+// state returns the checks started so far and the peak of a cycle
+func (ct *checkTracker) state(cycle int) (int, int) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	return ct.checks, ct.peaks[cycle]
+}
+
+// waitForCycle waits until every check of a cycle has started, and the one after it too, so that the
+// cycle has finished
+func (ct *checkTracker) waitForCycle(t *testing.T, cycle int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		checks, _ := ct.state(cycle)
+		if checks > (cycle+1)*ct.appsPerCycle {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cycle %d did not finish: %d checks started", cycle, checks)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// slowClient is a fakeClient whose connections take a while, reported to a checkTracker
+type slowClient struct {
+	fakeClient
+	tracker *checkTracker
+	delay   time.Duration
+}
+
+func (s *slowClient) Connect(ctx context.Context, host string, auth *interfaces.AuthConfig) (*interfaces.SpecResponse, error) {
+	s.tracker.start()
+	defer s.tracker.finish()
+	time.Sleep(s.delay)
+	return s.fakeClient.Connect(ctx, host, auth)
+}
+
+// newSlowMonitoredRegistry returns a registry of apps applications whose health checks take delay each
+func newSlowMonitoredRegistry(t *testing.T, apps int, delay time.Duration) (*Manager, *checkTracker) {
+	t.Helper()
+	configManager := newTestConfig(t)
+	tracker := &checkTracker{appsPerCycle: apps, peaks: make(map[int]int)}
+	newHealthClient := func(protocol.PoolConfig) (interfaces.ProtocolClient, error) {
+		return &slowClient{tracker: tracker, delay: delay}, nil
+	}
+
+	manager, err := NewManager(configManager, newHealthClient)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	for i := 0; i < apps; i++ {
+		name := fmt.Sprintf("app-%d", i+1)
+		saveTestProfile(t, configManager, name)
+		if err := manager.RegisterApp(interfaces.RegisteredApp{Name: name, Profile: name}); err != nil {
+			t.Fatalf("RegisterApp failed: %v", err)
+		}
+	}
+	return manager, tracker
+}
+
+// stopMonitoring stops health monitoring and waits for the checks in flight to finish
+func stopMonitoring(t *testing.T, manager *Manager, tracker *checkTracker) {
+	t.Helper()
+	if err := manager.StopHealthMonitoring(); err != nil {
+		t.Errorf("StopHealthMonitoring failed: %v", err)
+	}
+	for {
+		tracker.mutex.Lock()
+		active := tracker.active
+		tracker.mutex.Unlock()
+		if active == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConcurrencyChangeAppliesToRunningMonitoring(t *testing.T) {
+	manager, tracker := newSlowMonitoredRegistry(t, 6, 20*time.Millisecond)
+
+	preferences := manager.preferences
+	preferences.ConcurrentChecks = 1
+	preferences.HealthCheckInterval = 10 * time.Millisecond
+	if err := manager.UpdatePreferences(preferences); err != nil {
+		t.Fatalf("UpdatePreferences failed: %v", err)
+	}
+	if err := manager.StartHealthMonitoring(context.Background(), 0); err != nil {
+		t.Fatalf("StartHealthMonitoring failed: %v", err)
+	}
+	defer stopMonitoring(t, manager, tracker)
+
+	tracker.waitForCycle(t, 0)
+	if _, peak := tracker.state(0); peak != 1 {
+		t.Errorf("%d checks ran at once with a limit of 1", peak)
+	}
+
+	// Each change applies from the first cycle that starts after it
+	for _, limit := range []int{3, 2} {
+		preferences.ConcurrentChecks = limit
+		if err := manager.UpdatePreferences(preferences); err != nil {
+			t.Fatalf("UpdatePreferences failed: %v", err)
+		}
+		checks, _ := tracker.state(0)
+		cycle := checks/tracker.appsPerCycle + 1
+
+		tracker.waitForCycle(t, cycle)
+		if _, peak := tracker.state(cycle); peak != limit {
+			t.Errorf("%d checks ran at once in cycle %d, want the new limit of %d", peak, cycle, limit)
+		}
+	}
+}
+
+func TestShorterIntervalSpeedsUpRunningMonitoring(t *testing.T) {
+	manager, tracker := newSlowMonitoredRegistry(t, 1, 0)
+
+	preferences := manager.preferences
+	preferences.HealthCheckInterval = time.Hour
+	if err := manager.UpdatePreferences(preferences); err != nil {
+		t.Fatalf("UpdatePreferences failed: %v", err)
+	}
+	if err := manager.StartHealthMonitoring(context.Background(), 0); err != nil {
+		t.Fatalf("StartHealthMonitoring failed: %v", err)
+	}
+	defer stopMonitoring(t, manager, tracker)
+
+	time.Sleep(100 * time.Millisecond)
+	if checks, _ := tracker.state(0); checks != 0 {
+		t.Fatalf("%d checks ran within 100ms at an hourly interval", checks)
+	}
+
+	preferences.HealthCheckInterval = 20 * time.Millisecond
+	if err := manager.UpdatePreferences(preferences); err != nil {
+		t.Fatalf("UpdatePreferences failed: %v", err)
+	}
+
+	// At 20ms, five checks take about 100ms; allow for a slow machine
+	tracker.waitForCycle(t, 4)
+}
+
+func TestUpdatePreferencesRejectsNonPositiveInterval(t *testing.T) {
+	manager, _ := newSlowMonitoredRegistry(t, 0, 0)
+
+	preferences := manager.preferences
+	preferences.HealthCheckInterval = 0
+	if err := manager.UpdatePreferences(preferences); err == nil {
+		t.Error("UpdatePreferences accepted a zero interval")
+	}
+}
@@ -1,7 +1,7 @@
 // Package actions implements the Actions Pane system for the Universal Application Console.
 // This file creates numbered action lists with different visual themes for standard, confirmation,
 // and error recovery options, as specified in section 3.2.1 of the design specification.
-// It supports both direct number key execution and focused navigation.
+// It supports both direct shortcut key execution and focused navigation.
 package actions
 
 import (
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/content"
+	"github.com/universal-console/console/internal/contrast"
 	"github.com/universal-console/console/internal/interfaces"
 )
 
@@ -39,12 +41,17 @@ var (
 	}
 )
 
+// actionsPaneHeaderRows is the number of rendered rows above the first action:
+// the top margin, the top border, and the pane title.
+const actionsPaneHeaderRows = 3
+
 // Pane represents the state and logic for the interactive Actions Pane.
 type Pane struct {
 	actions       []interfaces.Action
 	selectedIndex int
 	width         int
 	visible       bool
+	highContrast  bool // Action colors too dim to read fall back to their type's
 }
 
 // NewPane creates a new Actions Pane component.
@@ -68,6 +75,12 @@ func (p *Pane) SetActions(actions []interfaces.Action) {
 	}
 }
 
+// SetHighContrast enables or disables high-contrast mode, in which action colors too dim to read on a dark
+// background are replaced by their type's, as in rendered responses.
+func (p *Pane) SetHighContrast(enabled bool) {
+	p.highContrast = enabled
+}
+
 // Reset hides the pane and clears its actions.
 func (p *Pane) Reset() {
 	p.visible = false
@@ -99,6 +112,35 @@ func (p *Pane) Previous() {
 	}
 }
 
+// Select moves the selection to the action at the given index.
+func (p *Pane) Select(index int) bool {
+	if !p.visible || index < 0 || index >= len(p.actions) {
+		return false
+	}
+	p.selectedIndex = index
+	return true
+}
+
+// ActionIndexAt maps a row offset from the top of the rendered pane to an action index.
+// Rows for the top margin, border, and title do not correspond to any action.
+func (p *Pane) ActionIndexAt(row int) (int, bool) {
+	if !p.visible {
+		return -1, false
+	}
+	index := row - actionsPaneHeaderRows
+	if index < 0 || index >= len(p.actions) {
+		return -1, false
+	}
+	return index, true
+}
+
+// Actions returns a copy of the actions in the pane, in their numbered order.
+func (p *Pane) Actions() []interfaces.Action {
+	actions := make([]interfaces.Action, len(p.actions))
+	copy(actions, p.actions)
+	return actions
+}
+
 // Selected returns the currently selected action.
 func (p *Pane) Selected() (*interfaces.Action, error) {
 	if p.selectedIndex < 0 || p.selectedIndex >= len(p.actions) {
@@ -160,12 +202,16 @@ func (p *Pane) getPaneTitle() string {
 	return "Available Actions"
 }
 
-// renderActionItem creates a single numbered action with appropriate styling.
+// renderActionItem creates a single action labelled with its shortcut key, with appropriate styling.
+// Actions beyond the last shortcut are reached by moving the selection.
 func (p *Pane) renderActionItem(index int, action interfaces.Action, isFocused bool) string {
-	number := fmt.Sprintf("[%d]", index+1)
+	number := ""
+	if key := content.ShortcutKey(index); key != "" {
+		number = fmt.Sprintf("[%s]", key)
+	}
 
-	// Determine icon based on action type, using defaults if not provided.
-	icon := p.getActionIcon(action)
+	// Use the action's own icon, or its type's default.
+	icon := content.ActionIcon(action)
 	actionText := fmt.Sprintf("%-4s %s %s", number, icon, action.Name)
 
 	// Apply styling based on action type and focus state.
@@ -186,24 +232,27 @@ func (p *Pane) renderActionItem(index int, action interfaces.Action, isFocused b
 		}
 	}
 
+	// An action's own color replaces its type's: as the text color, or the highlight when focused
+	if color, ok := content.ReadableActionColor(action, p.highContrast); ok {
+		if isFocused {
+			style = style.Background(color).Foreground(focusedTextColor(color))
+		} else {
+			style = style.Foreground(color)
+		}
+	}
+
 	return style.Render(actionText)
 }
 
-// getActionIcon returns the appropriate icon for a given action.
-func (p *Pane) getActionIcon(action interfaces.Action) string {
-	if action.Icon != "" {
-		return action.Icon
+// focusedTextColor picks dark or white text, whichever is easier to read on a focused action's highlight
+func focusedTextColor(highlight lipgloss.Color) lipgloss.Color {
+	dark, white := lipgloss.Color("#181825"), lipgloss.Color("#FFFFFF")
+	onDark, err := contrast.Ratio(string(highlight), string(dark))
+	if err != nil {
+		return white // ANSI numbers have no fixed value to measure
 	}
-	switch action.Type {
-	case "confirmation":
-		return "✅"
-	case "cancel":
-		return "❌"
-	case "info":
-		return "📋"
-	case "alternative":
-		return "🔄"
-	default:
-		return "▶️"
+	if onWhite, _ := contrast.Ratio(string(highlight), string(white)); onDark > onWhite {
+		return dark
 	}
+	return white
 }
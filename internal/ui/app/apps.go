@@ -0,0 +1,136 @@
+// Package app implements the registered application picker for Application Mode in the Universal Application Console.
+// /apps lists the applications in the registry with their current health, the one this session is connected to
+// marked, so the registry can be reached without returning to the Console Menu. Enter switches to the highlighted
+// application's profile as /switch does; /apps <name> switches to a registered application directly.
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/ui/components"
+)
+
+// maxAppPickerRows limits how many applications the picker shows at once
+const maxAppPickerRows = 8
+
+// appPicker holds the state of an open application picker
+type appPicker struct {
+	apps     []interfaces.RegisteredApp
+	selected int
+}
+
+// showApps handles /apps [name], opening the picker or switching to the named application
+func (m *AppModel) showApps(args []string) tea.Cmd {
+	if m.registryManager == nil {
+		return m.showError("The application registry is not available in this session")
+	}
+
+	if name := strings.Join(args, " "); name != "" {
+		app, err := m.registryManager.GetAppByName(name)
+		if err != nil {
+			return m.showError(fmt.Sprintf("Application '%s' is not registered", name))
+		}
+		return m.switchProfile([]string{app.Profile})
+	}
+
+	apps, err := m.registryManager.GetRegisteredApps()
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to list registered applications: %v", err))
+	}
+	if len(apps) == 0 {
+		return m.showError("No applications are registered; use /register to add this one")
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+
+	m.appPicker = &appPicker{apps: apps}
+	for i, app := range apps {
+		if app.Profile == m.profile.Name {
+			m.appPicker.selected = i
+		}
+	}
+	return nil
+}
+
+// handleAppPickerKeys processes keyboard input while the application picker is open
+func (m *AppModel) handleAppPickerKeys(msg tea.KeyMsg) tea.Cmd {
+	picker := m.appPicker
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.appPicker = nil
+
+	case tea.KeyEnter:
+		m.appPicker = nil
+		app := picker.apps[picker.selected]
+		if app.Profile == m.profile.Name {
+			m.statusMessage = fmt.Sprintf("Already connected to '%s'", app.Name)
+			return nil
+		}
+		return m.switchProfile([]string{app.Profile})
+
+	case tea.KeyUp, tea.KeyShiftTab:
+		if picker.selected > 0 {
+			picker.selected--
+		}
+
+	case tea.KeyDown, tea.KeyTab:
+		if picker.selected < len(picker.apps)-1 {
+			picker.selected++
+		}
+	}
+	return nil
+}
+
+// appHealthIndicator renders a registered application's health in its status color
+func appHealthIndicator(status string) string {
+	switch status {
+	case "ready":
+		return components.RenderStatus("success", "Ready")
+	case "degraded":
+		return components.RenderStatus("warning", "Degraded")
+	case "offline":
+		return components.RenderStatus("error", "Offline")
+	case "error":
+		return components.RenderStatus("error", "Error")
+	default:
+		return components.RenderStatus("pending", "Checking...")
+	}
+}
+
+// renderAppPicker creates the application listing, scrolled to keep the highlighted application visible
+func (m *AppModel) renderAppPicker() string {
+	picker := m.appPicker
+	lines := []string{paletteTitleStyle.Render("Registered Applications")}
+
+	start := 0
+	if picker.selected >= maxAppPickerRows {
+		start = picker.selected - maxAppPickerRows + 1
+	}
+	end := start + maxAppPickerRows
+	if end > len(picker.apps) {
+		end = len(picker.apps)
+	}
+
+	for i := start; i < end; i++ {
+		app := picker.apps[i]
+		marker := " "
+		if app.Profile == m.profile.Name {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s %s (%s)", marker, app.Name, app.Profile)
+		if i == picker.selected {
+			line = paletteSelectedStyle.Render(line)
+		}
+		lines = append(lines, line+" - "+appHealthIndicator(app.Status))
+	}
+	lines = append(lines, "", "↑/↓ select  Enter switch  Esc close")
+
+	width := m.terminalWidth - 2
+	if width < 20 {
+		width = 20
+	}
+	return paletteStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,183 @@
+// Package app implements the operation audit log for Application Mode in the Universal Application Console.
+// Every command, action, and meta command result is recorded as an OperationRecord with its duration,
+// outcome, and error, separately from the command history, which exists for display and can be cleared.
+// /audit lists the most recent records. When AuditLogPath is set (--audit-log), each record is also appended
+// to that file as a single JSON line, so a compliance trail survives the session.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// AuditLogPath is the file operation records are appended to as JSON lines. Empty disables the file.
+var AuditLogPath string
+
+const (
+	// maxOperationHistory is the number of operation records kept in memory
+	maxOperationHistory = 1000
+
+	// defaultAuditCount is the number of records /audit lists without an argument
+	defaultAuditCount = 20
+)
+
+// auditLog appends operation records to a JSON lines file
+type auditLog struct {
+	path string
+	file *os.File
+}
+
+// newAuditLog creates an audit log at the given path, or a disabled one when the path is empty.
+// The file is created on first use.
+func newAuditLog(path string) *auditLog {
+	return &auditLog{path: path}
+}
+
+// Append writes a record to the audit log as a single JSON line
+func (al *auditLog) Append(record OperationRecord) error {
+	if al.path == "" {
+		return nil
+	}
+
+	if al.file == nil {
+		if err := os.MkdirAll(filepath.Dir(al.path), 0700); err != nil {
+			return fmt.Errorf("failed to create audit log directory: %w", err)
+		}
+
+		file, err := os.OpenFile(al.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		al.file = file
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation record: %w", err)
+	}
+
+	if _, err := al.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the audit log file if it is open
+func (al *auditLog) Close() error {
+	if al.file == nil {
+		return nil
+	}
+	err := al.file.Close()
+	al.file = nil
+	return err
+}
+
+// recordOperation adds an operation record to the audit trail and appends it to the audit log file
+func (m *AppModel) recordOperation(opType, content string, duration time.Duration, success bool, errMsg string, details map[string]interface{}) {
+	m.operationCount++
+
+	recordContext := map[string]interface{}{
+		"profile": m.profile.Name,
+		"host":    m.profile.Host,
+	}
+	for key, value := range details {
+		recordContext[key] = value
+	}
+
+	record := OperationRecord{
+		ID:        fmt.Sprintf("%s-%d", m.connectionStats.SessionStartTime.Format("20060102-150405"), m.operationCount),
+		Type:      opType,
+		Content:   content,
+		Timestamp: time.Now(),
+		Duration:  duration,
+		Success:   success,
+		Error:     errMsg,
+		Context:   recordContext,
+	}
+
+	m.operationHistory = append(m.operationHistory, record)
+	if len(m.operationHistory) > maxOperationHistory {
+		m.operationHistory = m.operationHistory[len(m.operationHistory)-maxOperationHistory:]
+	}
+
+	if err := m.auditLog.Append(record); err != nil {
+		m.statusMessage = fmt.Sprintf("Audit log: %v", err)
+	}
+}
+
+// operationType classifies a command for the audit trail
+func operationType(command string) string {
+	if strings.HasPrefix(command, "/") {
+		return "meta"
+	}
+	return "command"
+}
+
+// operationError returns the message of a failed operation, preferring the structured error
+func operationError(message string, structured *interfaces.ErrorResponse) string {
+	if structured != nil && structured.Error.Message != "" {
+		return structured.Error.Message
+	}
+	return message
+}
+
+// showAudit handles /audit, listing the most recent operation records
+func (m *AppModel) showAudit(args []string) tea.Cmd {
+	count := defaultAuditCount
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return m.showError(fmt.Sprintf("Invalid record count '%s': expected a positive number", args[0]))
+		}
+		count = n
+	}
+
+	records := m.operationHistory
+	if len(records) > count {
+		records = records[len(records)-count:]
+	}
+
+	lines := []string{fmt.Sprintf("--- Audit Trail (%d of %d operations) ---", len(records), len(m.operationHistory))}
+	if len(records) == 0 {
+		lines = append(lines, "No operations recorded yet")
+	}
+	for _, record := range records {
+		result := "ok"
+		if !record.Success {
+			result = "failed"
+		}
+		lines = append(lines, fmt.Sprintf("%s  %-7s  %-6s  %8v  %s",
+			record.Timestamp.Format("15:04:05"), record.Type, result, record.Duration.Round(time.Millisecond), record.Content))
+		if record.Error != "" {
+			lines = append(lines, "          "+record.Error)
+		}
+	}
+	if AuditLogPath != "" {
+		lines = append(lines, "", "Audit log: "+AuditLogPath)
+	}
+	auditText := strings.Join(lines, "\n")
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/audit",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: auditText,
+				},
+			},
+			success: true,
+		}
+	})
+}
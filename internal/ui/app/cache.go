@@ -0,0 +1,100 @@
+// Package app implements response caching for Application Mode in the Universal Application Console.
+// Read-only commands such as "status" are often run again and again. A response is kept for reuse when the
+// application marks it cacheable with a TTL, or when the command's first word is in the profile's
+// cacheCommands list, and running the same command again within that time shows the kept response, marked
+// "(cached)", without a request. Entries are keyed by the command string and dropped once expired. /refresh
+// clears the cache, or runs one command past it; changing context variables clears it too.
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// defaultCacheTTL is how long responses of the profile's cacheCommands are reused when it sets no cacheTTL
+const defaultCacheTTL = 30 * time.Second
+
+// cacheEntry is a response kept for reuse until it expires
+type cacheEntry struct {
+	response *interfaces.CommandResponse
+	expires  time.Time
+}
+
+// cachedResponse returns the kept response of a command, dropping it if it has expired
+func (m *AppModel) cachedResponse(command string) (*interfaces.CommandResponse, bool) {
+	entry, ok := m.responseCache[command]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(m.responseCache, command)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+// cacheTTL returns how long a command's response may be reused: the application's TTL when it gives one,
+// otherwise the profile's when the command is listed in cacheCommands, otherwise zero
+func (m *AppModel) cacheTTL(command string, response *interfaces.CommandResponse) time.Duration {
+	if response.CacheTTL > 0 {
+		return time.Duration(response.CacheTTL) * time.Second
+	}
+
+	fields := strings.Fields(command)
+	if len(fields) == 0 || !slices.Contains(m.profile.CacheCommands, fields[0]) {
+		return 0
+	}
+	if m.profile.CacheTTL > 0 {
+		return time.Duration(m.profile.CacheTTL) * time.Second
+	}
+	return defaultCacheTTL
+}
+
+// cacheResponse keeps a command's response for reuse when it is cacheable
+func (m *AppModel) cacheResponse(command string, response *interfaces.CommandResponse) {
+	ttl := m.cacheTTL(command, response)
+	if ttl <= 0 {
+		return
+	}
+	m.responseCache[command] = cacheEntry{
+		response: response,
+		expires:  time.Now().Add(ttl),
+	}
+}
+
+// clearResponseCache drops every kept response, returning how many there were
+func (m *AppModel) clearResponseCache() int {
+	count := len(m.responseCache)
+	m.responseCache = make(map[string]cacheEntry)
+	return count
+}
+
+// showCachedResponse shows a kept response as the result of a command, without a request
+func (m *AppModel) showCachedResponse(display string, response *interfaces.CommandResponse, redirect *outputRedirect) tea.Cmd {
+	return func() tea.Msg {
+		return commandExecutedMsg{
+			command:  display,
+			response: response,
+			success:  true,
+			redirect: redirect,
+			cached:   true,
+		}
+	}
+}
+
+// refresh handles /refresh. Without arguments it clears the cache; otherwise it runs the command with the
+// cache bypassed, keeping the new response in place of the old one.
+func (m *AppModel) refresh(args []string) tea.Cmd {
+	if len(args) == 0 {
+		count := m.clearResponseCache()
+		m.statusMessage = fmt.Sprintf("Cleared %d cached response(s)", count)
+		return nil
+	}
+
+	return m.executeCommand(strings.Join(args, " "), false)
+}
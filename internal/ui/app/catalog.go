@@ -0,0 +1,76 @@
+// Package app implements the application command catalog for Application Mode in the Universal Application Console.
+// An application may publish its commands, with descriptions and argument hints, in the handshake. The catalog
+// completes command names as soon as they are typed, without waiting on the suggest endpoint or needing it at
+// all, and /commands lists it so that an unfamiliar application's commands can be discovered.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// suggestCatalogCommand completes a command name from the published catalog, in catalog order
+func (m *AppModel) suggestCatalogCommand(input string) string {
+	if strings.ContainsAny(input, " \t") {
+		return ""
+	}
+	for _, command := range m.commandCatalog {
+		if len(command.Name) > len(input) && strings.HasPrefix(command.Name, input) {
+			return command.Name
+		}
+	}
+	return ""
+}
+
+// catalogSynopsis returns a catalog command's name and argument hint, e.g. "deploy <service>"
+func catalogSynopsis(command interfaces.CommandInfo) string {
+	return strings.TrimSpace(command.Name + " " + command.Args)
+}
+
+// showCommands handles /commands, listing the application's published commands aligned in two columns
+func (m *AppModel) showCommands() tea.Cmd {
+	if len(m.commandCatalog) == 0 {
+		return m.showError("The application does not publish a command catalog; try its help command")
+	}
+
+	width := 0
+	for _, command := range m.commandCatalog {
+		if w := runewidth.StringWidth(catalogSynopsis(command)); w > width {
+			width = w
+		}
+	}
+
+	lines := []string{"--- Application Commands ---"}
+	for _, command := range m.commandCatalog {
+		synopsis := catalogSynopsis(command)
+		if command.Description == "" {
+			lines = append(lines, synopsis)
+			continue
+		}
+		padding := strings.Repeat(" ", width-runewidth.StringWidth(synopsis))
+		lines = append(lines, fmt.Sprintf("%s%s - %s", synopsis, padding, command.Description))
+	}
+	lines = append(lines, "----------------------------")
+	commandsText := strings.Join(lines, "\n")
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/commands",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: commandsText,
+				},
+			},
+			success:  true,
+			duration: 0,
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Package app implements editing the configuration file from Application Mode in the Universal Application Console.
+// /config, or Ctrl+O, opens the configuration file in $VISUAL or $EDITOR, suspending the console while the editor
+// has the terminal. When the editor exits the file is validated and the session's profile reloaded, so changes to
+// themes, labels, variables, display preferences, and client limits take effect without restarting. Problems in
+// the edited file are reported and the session keeps its current settings. The connection itself is unchanged:
+// host, authentication, and connection pool changes apply the next time the profile connects.
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/config"
+)
+
+// configEditedMsg reports that the editor opened by /config has exited
+type configEditedMsg struct {
+	err error
+}
+
+// configFileValidator is implemented by configuration managers that can check the whole file
+type configFileValidator interface {
+	ValidateConfigFile() ([]config.ValidationIssue, error)
+}
+
+// editConfig handles /config and Ctrl+O, opening the configuration file in the user's editor
+func (m *AppModel) editConfig() tea.Cmd {
+	path := m.configManager.GetConfigPath()
+	if path == "" {
+		return m.showError("No configuration file to edit")
+	}
+
+	editor := editorCommand(path)
+	m.statusMessage = fmt.Sprintf("Editing %s...", path)
+	return tea.ExecProcess(editor, func(err error) tea.Msg {
+		return configEditedMsg{err: err}
+	})
+}
+
+// editorCommand builds the command that opens a file in $VISUAL or $EDITOR, which may include arguments
+// such as "code --wait". A value naming an executable as a whole is used as it is, so a path with spaces
+// like "C:\Program Files\Notepad++\notepad++.exe" works unquoted; otherwise the value is split into words,
+// keeping quoted words such as a quoted path together. Without either, notepad is used on Windows and vi
+// elsewhere.
+func editorCommand(path string) *exec.Cmd {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	if _, err := exec.LookPath(editor); err == nil {
+		return exec.Command(editor, path)
+	}
+	fields := splitEditorCommand(editor)
+	if len(fields) == 0 {
+		return exec.Command(editor, path)
+	}
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// splitEditorCommand splits an editor command into words at unquoted spaces. Single or double quotes group
+// a word and are removed; backslashes are kept, since they separate directories in Windows paths.
+func splitEditorCommand(command string) []string {
+	var fields []string
+	var word strings.Builder
+	var quote rune
+	inWord := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				fields = append(fields, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		fields = append(fields, word.String())
+	}
+	return fields
+}
+
+// handleConfigEdited validates the edited configuration file and reloads the session's profile from it
+func (m *AppModel) handleConfigEdited(msg configEditedMsg) tea.Cmd {
+	m.statusMessage = ""
+	if msg.err != nil {
+		return m.showError(fmt.Sprintf("Editor failed: %v", msg.err))
+	}
+
+	m.configManager.InvalidateCache()
+
+	if validator, ok := m.configManager.(configFileValidator); ok {
+		issues, err := validator.ValidateConfigFile()
+		if err != nil {
+			return m.showError(fmt.Sprintf("Configuration not reloaded: %v", err))
+		}
+
+		var problems []string
+		for _, issue := range issues {
+			if !issue.IsWarning() {
+				problems = append(problems, issue.String())
+			}
+		}
+		if len(problems) > 0 {
+			return m.showError(fmt.Sprintf("Configuration not reloaded, %d problem(s) found: %s",
+				len(problems), strings.Join(problems, "; ")))
+		}
+	}
+
+	profile, err := m.configManager.LoadProfile(m.profile.Name)
+	if err != nil {
+		return m.showError(fmt.Sprintf("Configuration not reloaded: %v", err))
+	}
+
+	// The session stays connected to the host it was opened with
+	reconnectNeeded := profile.Host != m.profile.Host || profile.Auth != m.profile.Auth ||
+		profile.PoolSize != m.profile.PoolSize || profile.MaxConnections != m.profile.MaxConnections ||
+		profile.DisableHTTP2 != m.profile.DisableHTTP2
+	profile.Host = m.profile.Host
+	profile.Auth = m.profile.Auth
+
+	m.applyProfile(profile)
+	m.protocolClient.SetRateLimit(profile.RateLimit, profile.RateBurst)
+	m.protocolClient.SetCircuitBreaker(profile.CircuitThreshold, time.Duration(profile.CircuitCooldown)*time.Second)
+	m.protocolClient.SetIdlePolicy(time.Duration(profile.KeepAlive)*time.Second, time.Duration(profile.IdleTimeout)*time.Minute)
+
+	m.statusMessage = fmt.Sprintf("Reloaded profile '%s'", profile.Name)
+	if reconnectNeeded {
+		m.statusMessage += "; host, authentication, and connection pool changes apply when the profile next connects"
+	}
+	return nil
+}
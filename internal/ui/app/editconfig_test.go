@@ -0,0 +1,36 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	editor := filepath.Join(t.TempDir(), "my editor", "edit")
+	if err := os.MkdirAll(filepath.Dir(editor), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(editor, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", "")
+
+	tests := []struct {
+		visual string
+		want   []string
+	}{
+		{editor, []string{editor, "config.yaml"}},
+		{`"` + editor + `" --wait`, []string{editor, "--wait", "config.yaml"}},
+		{"code --wait", []string{"code", "--wait", "config.yaml"}},
+		{"emacs -nw '+set tw=80'", []string{"emacs", "-nw", "+set tw=80", "config.yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("VISUAL", tt.visual)
+		if got := editorCommand("config.yaml").Args; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("VISUAL=%s runs %q, want %q", tt.visual, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package app implements the empty history pane for Application Mode in the Universal Application Console.
+// Until the first command is sent, the history pane introduces the session instead of standing blank: a few
+// of the application's own commands when it publishes a catalog, the meta commands a new user needs first,
+// and the keys for moving around. It is built from state the model already holds, so rendering it costs no
+// requests, and it is replaced by the history as soon as there is any.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// emptyStateExamples is how many catalog commands the empty history pane suggests
+const emptyStateExamples = 4
+
+// emptyStateMetaCommands are the meta commands introduced in the empty history pane, in order.
+// /commands is only listed when the application publishes a catalog.
+var emptyStateMetaCommands = []string{"/help", "/commands", "/theme", "/quit"}
+
+// emptyStateKeys are the navigation keys introduced in the empty history pane
+var emptyStateKeys = []keyHint{
+	{"tab", "complete or move focus"},
+	{"ctrl+p", "command palette"},
+	{"ctrl+↑↓", "input history"},
+	{"ctrl+c", "quit"},
+}
+
+var (
+	emptyStateHeadingStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#89B4FA"))
+
+	emptyStateNameStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#CDD6F4"))
+
+	emptyStateDescriptionStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#6C7086"))
+)
+
+// emptyStateEntry is a command or key and what it does
+type emptyStateEntry struct {
+	name        string
+	description string
+}
+
+// renderEmptyState returns the lines shown in the history pane before any command has been sent, cut to
+// the given width and number of rows
+func (m *AppModel) renderEmptyState(width, rows int) string {
+	var lines []string
+
+	title := "Connected and ready."
+	if m.appName != "" {
+		title = fmt.Sprintf("Connected to %s", m.appName)
+		if m.appVersion != "" {
+			title += " v" + m.appVersion
+		}
+		title += "."
+	}
+	lines = append(lines, statusStyle.Render(truncateEmptyState(title+" Type a command to get started.", width)))
+
+	if examples := m.emptyStateExamples(); len(examples) > 0 {
+		lines = append(lines, "", emptyStateHeadingStyle.Render("Try"))
+		lines = append(lines, renderEmptyStateEntries(examples, width)...)
+	} else {
+		hint := "Type a command and press Enter to send it to the application."
+		if m.features[FeatureSuggest] {
+			hint = "Type a command and press Enter; suggestions appear as you type."
+		}
+		lines = append(lines, "", emptyStateDescriptionStyle.Render(truncateEmptyState(hint, width)))
+	}
+
+	lines = append(lines, "", emptyStateHeadingStyle.Render("Console commands"))
+	lines = append(lines, renderEmptyStateEntries(m.emptyStateMetaCommands(), width)...)
+
+	keys := make([]emptyStateEntry, 0, len(emptyStateKeys))
+	for _, hint := range emptyStateKeys {
+		keys = append(keys, emptyStateEntry{name: hint.key, description: hint.action})
+	}
+	lines = append(lines, "", emptyStateHeadingStyle.Render("Keys"))
+	lines = append(lines, renderEmptyStateEntries(keys, width)...)
+
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// emptyStateExamples returns the first few commands of the application's catalog
+func (m *AppModel) emptyStateExamples() []emptyStateEntry {
+	var examples []emptyStateEntry
+	for _, command := range m.commandCatalog {
+		if len(examples) == emptyStateExamples {
+			break
+		}
+		examples = append(examples, emptyStateEntry{name: catalogSynopsis(command), description: command.Description})
+	}
+	return examples
+}
+
+// emptyStateMetaCommands returns the introduced meta commands that are registered, with their descriptions
+func (m *AppModel) emptyStateMetaCommands() []emptyStateEntry {
+	var entries []emptyStateEntry
+	for _, name := range emptyStateMetaCommands {
+		if name == "/commands" && len(m.commandCatalog) == 0 {
+			continue
+		}
+		command, ok := m.metaCommands.Lookup(name)
+		if !ok {
+			continue
+		}
+		entries = append(entries, emptyStateEntry{name: command.Name, description: command.Description})
+	}
+	return entries
+}
+
+// renderEmptyStateEntries renders entries as an indented two-column list, with descriptions aligned
+func renderEmptyStateEntries(entries []emptyStateEntry, width int) []string {
+	nameWidth := 0
+	for _, entry := range entries {
+		if w := runewidth.StringWidth(entry.name); w > nameWidth {
+			nameWidth = w
+		}
+	}
+
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := truncateEmptyState(entry.name, width-2)
+		line := "  " + emptyStateNameStyle.Render(name)
+
+		descriptionWidth := width - 2 - nameWidth - 2
+		if entry.description != "" && descriptionWidth > 0 {
+			padding := strings.Repeat(" ", nameWidth-runewidth.StringWidth(name)+2)
+			line += padding + emptyStateDescriptionStyle.Render(truncateEmptyState(entry.description, descriptionWidth))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncateEmptyState cuts text to width, so that no line of the empty state wraps
+func truncateEmptyState(text string, width int) string {
+	if width < 1 {
+		return ""
+	}
+	return runewidth.Truncate(text, width, "…")
+}
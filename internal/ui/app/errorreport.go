@@ -0,0 +1,225 @@
+// Package app implements diagnostic error reports for Application Mode in the Universal Application Console.
+// When a command fails, support teams need more than the message on screen. /error-report, also offered as
+// an action on every error, bundles the failed command, the processed error, the last communication error
+// with its raw response body, session and connection statistics, the application's name and version, and the
+// console's version and platform into one JSON document. It is copied to the clipboard, or written to a file
+// when one is named. Credentials are redacted by default: authentication and cookie headers are masked, and
+// the profile's token is removed wherever it appears; --no-redact keeps them.
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// ConsoleVersion is the console's version, included in error reports
+var ConsoleVersion = "unknown"
+
+// errorReportCommand is the meta command run by the error report action
+const errorReportCommand = "/error-report"
+
+// redactedValue replaces credentials in redacted error reports
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeader matches the names of headers that carry credentials
+var sensitiveHeader = regexp.MustCompile(`(?i)authorization|cookie|token|api-?key|secret|session`)
+
+// errorReport is the diagnostic bundle for a failed command
+type errorReport struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Redacted    bool                 `json:"redacted"`
+	Console     errorReportConsole   `json:"console"`
+	Application errorReportApp       `json:"application"`
+	Command     string               `json:"command,omitempty"`
+	Error       errorReportError     `json:"error"`
+	LastFailure *errorReportFailure  `json:"lastCommunicationError,omitempty"`
+	Session     ConnectionStatistics `json:"session"`
+	Circuit     string               `json:"circuit"`
+}
+
+// errorReportConsole describes the console and the platform it runs on
+type errorReportConsole struct {
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Go      string `json:"go"`
+}
+
+// errorReportApp describes the connected application
+type errorReportApp struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ProtocolVersion string `json:"protocolVersion"`
+	Host            string `json:"host"`
+	Profile         string `json:"profile"`
+}
+
+// errorReportError is the processed error shown to the user
+type errorReportError struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Message   string      `json:"message"`
+	Code      string      `json:"code,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// errorReportFailure is the last communication error reported by the protocol client
+type errorReportFailure struct {
+	Message    string            `json:"message"`
+	Type       string            `json:"type,omitempty"`
+	Timestamp  time.Time         `json:"timestamp,omitempty"`
+	StatusCode int               `json:"statusCode,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// errorReportAction returns the action that copies a report of the current error
+func errorReportAction() interfaces.Action {
+	return interfaces.Action{
+		Name:    "Copy error report",
+		Command: errorReportCommand,
+		Type:    "info",
+		Icon:    "📋",
+	}
+}
+
+// showErrorReport handles /error-report [--no-redact] [file]
+func (m *AppModel) showErrorReport(args []string) tea.Cmd {
+	if m.currentError == nil {
+		return m.showError("No error to report")
+	}
+
+	redact := true
+	var pathParts []string
+	for _, arg := range args {
+		if arg == "--no-redact" {
+			redact = false
+			continue
+		}
+		pathParts = append(pathParts, arg)
+	}
+
+	data, err := json.MarshalIndent(m.buildErrorReport(redact), "", "  ")
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to encode the error report: %v", err))
+	}
+
+	if path := strings.Join(pathParts, " "); path != "" {
+		if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+			return m.showError(fmt.Sprintf("Failed to write the error report: %v", err))
+		}
+		m.statusMessage = fmt.Sprintf("Wrote the error report to %s", path)
+		return nil
+	}
+
+	termenv.Copy(string(data))
+	m.statusMessage = "Copied the error report to the clipboard"
+	return nil
+}
+
+// buildErrorReport assembles the diagnostic bundle for the current error
+func (m *AppModel) buildErrorReport(redact bool) errorReport {
+	session := m.connectionStats
+	session.SessionDuration = time.Since(session.SessionStartTime)
+
+	report := errorReport{
+		GeneratedAt: time.Now(),
+		Redacted:    redact,
+		Console: errorReportConsole{
+			Version: ConsoleVersion,
+			OS:      runtime.GOOS,
+			Arch:    runtime.GOARCH,
+			Go:      runtime.Version(),
+		},
+		Application: errorReportApp{
+			Name:            m.appName,
+			Version:         m.appVersion,
+			ProtocolVersion: m.protocolVersion,
+			Host:            m.profile.Host,
+			Profile:         m.profile.Name,
+		},
+		Command: m.failedCommand(),
+		Error: errorReportError{
+			Timestamp: m.currentError.Timestamp,
+			Message:   m.currentError.Message,
+			Code:      m.currentError.Code,
+		},
+		Session: session,
+		Circuit: m.protocolClient.CircuitState(),
+	}
+	if m.currentError.Details != nil {
+		report.Error.Details = m.currentError.Details
+	}
+
+	if lastErr := m.protocolClient.GetLastError(); lastErr != nil {
+		failure := &errorReportFailure{Message: lastErr.Error()}
+		var protoErr *protocol.ProtocolError
+		if errors.As(lastErr, &protoErr) {
+			failure.Type = protoErr.Type
+			failure.Timestamp = protoErr.Timestamp
+			if details := protoErr.HTTPDetails; details != nil {
+				failure.StatusCode = details.StatusCode
+				failure.Status = details.StatusText
+				failure.Body = details.Body
+				if len(details.Headers) > 0 {
+					failure.Headers = make(map[string]string, len(details.Headers))
+					for name, value := range details.Headers {
+						failure.Headers[name] = value
+					}
+				}
+			}
+		}
+		report.LastFailure = failure
+	}
+
+	if redact {
+		m.redactErrorReport(&report)
+	}
+	return report
+}
+
+// failedCommand returns the command whose failure is the current error, if it is still in history
+func (m *AppModel) failedCommand() string {
+	for i := len(m.commandHistory) - 1; i >= 0; i-- {
+		if m.commandHistory[i].Error == m.currentError {
+			return m.commandHistory[i].Command
+		}
+	}
+	return ""
+}
+
+// redactErrorReport masks credential headers and removes the profile's token from every text of the report
+func (m *AppModel) redactErrorReport(report *errorReport) {
+	scrub := func(text string) string {
+		if token := m.profile.Auth.Token; token != "" {
+			text = strings.ReplaceAll(text, token, redactedValue)
+		}
+		return text
+	}
+
+	report.Command = scrub(report.Command)
+	report.Error.Message = scrub(report.Error.Message)
+
+	if failure := report.LastFailure; failure != nil {
+		failure.Message = scrub(failure.Message)
+		failure.Body = scrub(failure.Body)
+		for name, value := range failure.Headers {
+			if sensitiveHeader.MatchString(name) {
+				failure.Headers[name] = redactedValue
+			} else {
+				failure.Headers[name] = scrub(value)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package app implements transcript export for Application Mode in the Universal Application Console.
+// This file writes the full session history, including entries spilled to the session log, to a file
+// as plain text, Markdown, or a standalone HTML page so results can be shared outside the terminal. The HTML
+// page renders structured responses with the content renderer and inlines a stylesheet built from the theme,
+// so it keeps its formatting when attached to a ticket or pasted into a wiki.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// exportHistory writes the session transcript to the given path, choosing the format from its extension
+func (m *AppModel) exportHistory(path string) tea.Cmd {
+	entries, err := m.fullHistory()
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to read session log: %v", err))
+	}
+	if len(entries) == 0 {
+		return m.showError("No command history to export")
+	}
+
+	var transcript string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		transcript = m.formatMarkdownTranscript(entries)
+	case ".html", ".htm":
+		transcript = m.formatHTMLTranscript(entries)
+	default:
+		transcript = m.formatPlainTranscript(entries)
+	}
+
+	if err := os.WriteFile(path, []byte(transcript), 0600); err != nil {
+		return m.showError(fmt.Sprintf("Failed to export history: %v", err))
+	}
+
+	m.statusMessage = fmt.Sprintf("Exported %d entries to %s", len(entries), path)
+	return nil
+}
+
+// formatPlainTranscript renders history entries as plain text
+func (m *AppModel) formatPlainTranscript(entries []HistoryEntry) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Session transcript: %s (%s)\n", m.profile.Name, m.profile.Host)
+	fmt.Fprintf(&b, "Exported: %s\n\n", time.Now().Format(time.RFC3339))
+
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "[%s] %s %s\n", entry.Timestamp.Format("15:04:05"), m.userLabel(), entry.Command)
+		if entry.Error != nil {
+			fmt.Fprintf(&b, "ERROR: %s\n", entry.Error.Message)
+		} else if entry.Response != nil {
+			for _, warning := range entry.Response.Warnings {
+				fmt.Fprintf(&b, "WARNING: %s\n", warning.Message)
+			}
+			fmt.Fprintf(&b, "%s %s\n", m.appLabel(), transcriptContent(entry.Response))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatMarkdownTranscript renders history entries as a Markdown document
+func (m *AppModel) formatMarkdownTranscript(entries []HistoryEntry) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Session Transcript: %s\n\n", m.profile.Name)
+	fmt.Fprintf(&b, "- Host: `%s`\n", m.profile.Host)
+	fmt.Fprintf(&b, "- Exported: %s\n\n", time.Now().Format(time.RFC3339))
+
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "## `%s`\n\n", entry.Command)
+		fmt.Fprintf(&b, "_%s · %v_\n\n", entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Duration.Truncate(time.Millisecond))
+
+		if entry.Error != nil {
+			fmt.Fprintf(&b, "> **Error:** %s\n\n", entry.Error.Message)
+		} else if entry.Response != nil {
+			for _, warning := range entry.Response.Warnings {
+				fmt.Fprintf(&b, "> **Warning:** %s\n\n", warning.Message)
+			}
+
+			content := transcriptContent(entry.Response)
+			if entry.Response.Response.Type == "text" {
+				fmt.Fprintf(&b, "%s\n\n", content)
+			} else {
+				fmt.Fprintf(&b, "```json\n%s\n```\n\n", content)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// htmlTranscriptStyle is the stylesheet of exported HTML transcripts; the %s verbs take the theme's
+// success, error, warning, and info colors in that order
+const htmlTranscriptStyle = `body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; color: #24292e; }
+.meta { color: #6a737d; }
+.entry { border-top: 1px solid #e1e4e8; padding: 1em 0; }
+.command { font-family: monospace; font-size: 1.1em; font-weight: bold; }
+.time { color: #6a737d; font-size: 0.85em; }
+.text { white-space: pre-wrap; margin: 0.5em 0; }
+pre { padding: 0.75em; overflow-x: auto; }
+.code .filename { font-family: monospace; color: #6a737d; }
+.code .note { color: #6a737d; font-style: italic; }
+.form label { display: block; margin: 0.25em 0; }
+table { border-collapse: collapse; margin: 0.5em 0; }
+th, td { border: 1px solid #d0d7de; padding: 0.25em 0.75em; text-align: left; }
+caption { font-style: italic; }
+details { margin: 0.25em 0 0.25em 0.5em; }
+summary { cursor: pointer; font-weight: bold; }
+.separator { text-align: center; border-bottom: 1px solid #d0d7de; line-height: 0.1em; margin: 1em 0; }
+.separator span { background: #fff; padding: 0 0.5em; }
+.status-success, .status-complete { color: %s; }
+.error, .status-error { color: %s; }
+.warning, .status-warning, .status-pending, .status-paused { color: %s; }
+.status-info, .status-running { color: %s; }
+`
+
+// formatHTMLTranscript renders history entries as a standalone HTML page
+func (m *AppModel) formatHTMLTranscript(entries []HistoryEntry) string {
+	colors := [4]string{"#28a745", "#dc3545", "#ffc107", "#17a2b8"}
+	if m.theme != nil {
+		for i, color := range []string{m.theme.Success, m.theme.Error, m.theme.Warning, m.theme.Info} {
+			if color != "" {
+				colors[i] = color
+			}
+		}
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(fmt.Sprintf("Session Transcript: %s", m.profile.Name))
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", title)
+	fmt.Fprintf(&b, "<style>\n"+htmlTranscriptStyle+"</style>\n", colors[0], colors[1], colors[2], colors[3])
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&b, "<p class=\"meta\">Host: <code>%s</code> · Exported: %s</p>\n",
+		html.EscapeString(m.profile.Host), time.Now().Format(time.RFC3339))
+
+	for _, entry := range entries {
+		b.WriteString("<div class=\"entry\">\n")
+		fmt.Fprintf(&b, "<div class=\"command\">&gt; %s</div>\n", html.EscapeString(entry.Command))
+		fmt.Fprintf(&b, "<div class=\"time\">%s · %v</div>\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Duration.Truncate(time.Millisecond))
+
+		if entry.Error != nil {
+			fmt.Fprintf(&b, "<div class=\"text error\"><strong>Error:</strong> %s</div>\n", html.EscapeString(entry.Error.Message))
+		} else if entry.Response != nil {
+			for _, warning := range entry.Response.Warnings {
+				fmt.Fprintf(&b, "<div class=\"text warning\"><strong>Warning:</strong> %s</div>\n", html.EscapeString(warning.Message))
+			}
+			b.WriteString(m.transcriptHTML(entry.Response))
+		}
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// transcriptHTML renders a response with the content renderer, falling back to its JSON when the content cannot be rendered
+func (m *AppModel) transcriptHTML(response *interfaces.CommandResponse) string {
+	if m.contentRenderer != nil {
+		if fragment, err := m.contentRenderer.RenderHTML(response.Response.Content, m.theme); err == nil {
+			return fragment
+		}
+	}
+	return fmt.Sprintf("<pre>%s</pre>\n", html.EscapeString(transcriptContent(response)))
+}
+
+// transcriptContent returns response text as-is, and structured content as indented JSON
+func transcriptContent(response *interfaces.CommandResponse) string {
+	if text, ok := response.Response.Content.(string); ok && response.Response.Type == "text" {
+		return text
+	}
+
+	data, err := json.MarshalIndent(response.Response.Content, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", response.Response.Content)
+	}
+	return string(data)
+}
@@ -0,0 +1,196 @@
+// Package app implements interactive forms for Application Mode in the Universal Application Console.
+// A response with a form content block shows the form in history. Tab moves focus onto it like any other
+// element, the arrow keys and Tab move between its fields, and submitting sends the values as the context
+// of the form's action, together with the workflow context when a workflow is active. Each form keeps its
+// values in a form model keyed by content ID, which carries over when history is re-rendered.
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/ui/form"
+)
+
+// formIDs returns the content IDs of all forms in history order
+func (m *AppModel) formIDs() []string {
+	var ids []string
+	for _, entry := range m.commandHistory {
+		for _, content := range entry.Rendered {
+			if content.Form != nil {
+				ids = append(ids, content.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// formFor returns the form model of rendered form content, creating it on first use
+func (m *AppModel) formFor(content interfaces.RenderedContent) *form.Model {
+	if model, ok := m.forms[content.ID]; ok {
+		return model
+	}
+	model := form.New(*content.Form)
+	m.forms[content.ID] = model
+	return model
+}
+
+// findForm returns the form model of a form in history
+func (m *AppModel) findForm(formID string) *form.Model {
+	for _, entry := range m.commandHistory {
+		for _, content := range entry.Rendered {
+			if content.ID == formID && content.Form != nil {
+				return m.formFor(content)
+			}
+		}
+	}
+	return nil
+}
+
+// focusForm moves focus to a form, placing its cursor on the first field or on the submit button
+func (m *AppModel) focusForm(formID string, atEnd bool) {
+	model := m.findForm(formID)
+	if model == nil {
+		return
+	}
+	m.blurForm()
+	m.SetFocus(FocusForm)
+	m.focusedFormID = formID
+	model.Focus(atEnd)
+	m.invalidateHistoryBuffer()
+}
+
+// blurForm removes focus from the focused form, if any
+func (m *AppModel) blurForm() {
+	if model, ok := m.forms[m.focusedFormID]; ok {
+		model.Blur()
+		m.invalidateHistoryBuffer()
+	}
+}
+
+// handleFormKeys processes keyboard input when a form has focus
+func (m *AppModel) handleFormKeys(msg tea.KeyMsg) tea.Cmd {
+	model := m.findForm(m.focusedFormID)
+	if model == nil {
+		m.SetFocus(FocusInput)
+		return nil
+	}
+	defer m.invalidateHistoryBuffer()
+
+	switch msg.String() {
+	case "tab", "down":
+		if !model.Next() {
+			return m.leaveForm(1)
+		}
+		return nil
+
+	case "shift+tab", "up":
+		if !model.Previous() {
+			return m.leaveForm(-1)
+		}
+		return nil
+
+	case "enter":
+		// Enter moves through the fields and submits from the button
+		if model.OnSubmit() {
+			return m.submitForm(model)
+		}
+		model.Next()
+		return nil
+
+	case "ctrl+s":
+		return m.submitForm(model)
+
+	default:
+		return model.Update(msg)
+	}
+}
+
+// leaveForm moves focus past the edge of the focused form, into the adjacent form or the next focus area
+func (m *AppModel) leaveForm(direction int) tea.Cmd {
+	ids := m.formIDs()
+	for i, id := range ids {
+		if id != m.focusedFormID {
+			continue
+		}
+		if direction < 0 && i > 0 {
+			m.focusForm(ids[i-1], true)
+			return nil
+		}
+		if direction > 0 && i < len(ids)-1 {
+			m.focusForm(ids[i+1], false)
+			return nil
+		}
+		break
+	}
+
+	if direction < 0 {
+		return m.cycleFocusBackward()
+	}
+	return m.cycleFocusForward()
+}
+
+// submitForm checks the form's required fields and sends its values as the context of its action
+func (m *AppModel) submitForm(model *form.Model) tea.Cmd {
+	if err := model.Validate(); err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot submit: %v", err)
+		return nil
+	}
+
+	name := model.Title()
+	if name == "" {
+		name = "Submit form"
+	}
+	model.MarkSubmitted()
+
+	action := interfaces.Action{
+		Name:    name,
+		Command: model.Command(),
+		Type:    "primary",
+	}
+	return m.sendActionWithContext(action, model.Values())
+}
+
+// renderFormContent renders a form from its current values
+func (m *AppModel) renderFormContent(content interfaces.RenderedContent) []historyLine {
+	view := m.formFor(content).View(m.historyContentWidth())
+	return []historyLine{{text: contentStyle.Render(view)}}
+}
+
+// carryFormsOver moves the values of forms in re-rendered content to the new content IDs, matching
+// forms by their position within the entry
+func (m *AppModel) carryFormsOver(previous, rendered []interfaces.RenderedContent) {
+	var previousIDs []string
+	for _, content := range previous {
+		if content.Form != nil {
+			previousIDs = append(previousIDs, content.ID)
+		}
+	}
+
+	next := 0
+	for _, content := range rendered {
+		if content.Form == nil {
+			continue
+		}
+		if next < len(previousIDs) {
+			if model, ok := m.forms[previousIDs[next]]; ok {
+				delete(m.forms, previousIDs[next])
+				m.forms[content.ID] = model
+				if m.focusedFormID == previousIDs[next] {
+					m.focusedFormID = content.ID
+				}
+			}
+		}
+		next++
+	}
+}
+
+// dropForms forgets the form models of a history entry that is leaving history
+func (m *AppModel) dropForms(entry HistoryEntry) {
+	for _, content := range entry.Rendered {
+		if content.Form != nil {
+			delete(m.forms, content.ID)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package app implements expanding truncated responses for Application Mode in the Universal Application Console.
+// When a profile sets a complexityBudget, the content renderer cuts responses scoring above it short and ends
+// them with a notice. The latest response's notice comes with an "Expand full (heavy)" action, which renders
+// that history entry in full; the entry stays expanded when history is later re-rendered.
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// expandFullCommandPrefix marks synthetic "Expand full (heavy)" actions; the history entry's sequence number follows it
+const expandFullCommandPrefix = "internal_expand_full:"
+
+// expandFullAction returns the action that renders a truncated history entry in full
+func expandFullAction(seq uint64) interfaces.Action {
+	return interfaces.Action{
+		Name:    "Expand full (heavy)",
+		Command: fmt.Sprintf("%s%d", expandFullCommandPrefix, seq),
+		Type:    "info",
+		Icon:    "⚠️",
+	}
+}
+
+// expandFullContent renders a history entry truncated by the complexity budget in full. Rendering runs
+// outside the update loop, and the entry keeps its full rendering when history is re-rendered.
+func (m *AppModel) expandFullContent(id string) tea.Cmd {
+	seq, err := strconv.ParseUint(id, 10, 64)
+	entry := m.historyEntryBySequence(seq)
+	if err != nil || entry == nil || entry.Response == nil {
+		return m.showError("This response is no longer in history")
+	}
+
+	entry.fullContent = true
+
+	response, theme, width := entry.Response, m.theme, m.historyContentWidth()
+	return func() tea.Msg {
+		rendered, err := m.contentRenderer.RenderFullContent(response.Response.Content, theme)
+		if err != nil {
+			return contentRenderedMsg{seq: seq, error: err.Error()}
+		}
+		return contentRenderedMsg{seq: seq, rendered: rendered, width: width}
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/universal-console/console/internal/config"
+	"github.com/universal-console/console/internal/content"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// newTestModel returns a model connected to the demo client, with its configuration in a temporary directory
+func newTestModel(t *testing.T, profile *interfaces.Profile) *AppModel {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	configManager, err := config.NewManager()
+	if err != nil {
+		t.Fatalf("config.NewManager failed: %v", err)
+	}
+	renderer, err := content.NewRenderer()
+	if err != nil {
+		t.Fatalf("content.NewRenderer failed: %v", err)
+	}
+	client := protocol.NewDemoClient()
+	if _, err := client.Connect(context.Background(), profile.Host, nil); err != nil {
+		t.Fatalf("demo Connect failed: %v", err)
+	}
+
+	m := NewAppModel(profile, client, renderer, configManager, nil, nil)
+	m.connected = true
+	m.SetTerminalSize(100, 40)
+	return m
+}
@@ -0,0 +1,91 @@
+// Package app implements request sequencing for Application Mode in the Universal Application Console.
+// Every command and action is numbered when it is sent, and the number travels with its result into the
+// history entry it creates. Rendered content is delivered back to the entry carrying that number rather than
+// to whichever entry happens to be last, so responses that arrive out of order, or while other entries are
+// added, cannot overwrite each other. A profile's maxInFlight limits how many commands await a response at
+// once; commands submitted beyond the limit are queued and sent in order as earlier ones finish.
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// queuedCommand is a command held back by the profile's in-flight limit
+type queuedCommand struct {
+	command  string
+	useCache bool
+}
+
+// contentRenderedMsg carries the rendered content of the history entry with the given sequence number
+type contentRenderedMsg struct {
+	seq      uint64
+	rendered []interfaces.RenderedContent
+	width    int
+	error    string
+}
+
+// nextSequence returns a new sequence number for a request or history entry
+func (m *AppModel) nextSequence() uint64 {
+	m.sequence++
+	return m.sequence
+}
+
+// historyEntryBySequence finds the history entry with the given sequence number, or nil once it has been
+// cleared or spilled from memory
+func (m *AppModel) historyEntryBySequence(seq uint64) *HistoryEntry {
+	for i := len(m.commandHistory) - 1; i >= 0; i-- {
+		if m.commandHistory[i].seq == seq {
+			return &m.commandHistory[i]
+		}
+	}
+	return nil
+}
+
+// inFlightLimitReached reports whether the profile's maxInFlight commands are already awaiting a response
+func (m *AppModel) inFlightLimitReached() bool {
+	return m.profile.MaxInFlight > 0 && len(m.running) >= m.profile.MaxInFlight
+}
+
+// queueCommand holds a command back until an earlier one finishes
+func (m *AppModel) queueCommand(command string, useCache bool) tea.Cmd {
+	m.queuedCommands = append(m.queuedCommands, queuedCommand{command: command, useCache: useCache})
+	m.statusMessage = fmt.Sprintf("Queued '%s' (%d waiting)", command, len(m.queuedCommands))
+	return nil
+}
+
+// runQueuedCommand sends the oldest queued command once the in-flight limit allows it
+func (m *AppModel) runQueuedCommand() tea.Cmd {
+	if len(m.queuedCommands) == 0 || m.inFlightLimitReached() {
+		return nil
+	}
+
+	// The command was recorded in input history when it was queued, so it is sent as it stands
+	next := m.queuedCommands[0]
+	m.queuedCommands = m.queuedCommands[1:]
+	return m.sendCommand(next.command, next.useCache)
+}
+
+// handleContentRendered stores rendered content in the history entry it belongs to
+func (m *AppModel) handleContentRendered(msg contentRenderedMsg) tea.Cmd {
+	if msg.error != "" {
+		return m.showError(fmt.Sprintf("Content rendering failed: %s", msg.error))
+	}
+
+	entry := m.historyEntryBySequence(msg.seq)
+	if entry == nil {
+		return nil
+	}
+	entry.Rendered = msg.rendered
+	entry.renderedWidth = msg.width
+	m.applyDiff(entry)
+
+	m.invalidateHistoryBuffer()
+	m.refreshShowMoreActions()
+
+	// Update collapsible elements for focus management
+	m.updateCollapsibleElementsFromHistory()
+	return nil
+}
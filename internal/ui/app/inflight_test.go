@@ -0,0 +1,41 @@
+package app
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestQueuedCommandIsRecordedOnce(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example", MaxInFlight: 1})
+
+	m.executeCommand("status", false)
+	m.executeCommand("logs", false)
+	m.executeCommand("deploy", false)
+	if len(m.queuedCommands) != 2 {
+		t.Fatalf("queued %d commands, want 2", len(m.queuedCommands))
+	}
+
+	// The first command finishes, letting the oldest queued one through
+	m.finishRunning(m.running[0].seq)
+	m.runQueuedCommand()
+
+	want := []string{"status", "logs", "deploy"}
+	if !reflect.DeepEqual(m.inputHistory, want) {
+		t.Errorf("input history = %v, want %v", m.inputHistory, want)
+	}
+	if len(m.running) != 1 || m.running[0].command != "logs" {
+		t.Errorf("running = %v, want logs", m.running)
+	}
+
+	saved, err := os.ReadFile(m.inputHistoryPath)
+	if err != nil {
+		t.Fatalf("reading the history file failed: %v", err)
+	}
+	if count := strings.Count(string(saved), "logs"); count != 1 {
+		t.Errorf("history file records logs %d times, want 1:\n%s", count, saved)
+	}
+}
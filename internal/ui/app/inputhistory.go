@@ -0,0 +1,87 @@
+// Package app implements the persistent per-host command history for Application Mode in the Universal Application Console.
+// Like a shell history, commands sent to an application are appended to history/<host>.log next to the
+// configuration file and loaded into the input history when connecting to the same host again, so
+// Ctrl+↑ recalls commands from earlier sessions. Meta commands, empty lines, and immediate repeats are
+// not recorded, and the file is trimmed to the most recent entries when it is loaded.
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/universal-console/console/internal/config"
+)
+
+// maxInputHistory is the number of commands kept in memory and in the history file
+const maxInputHistory = 500
+
+// defaultInputHistoryPath returns the history file for a host in the history directory next to the configuration file
+func defaultInputHistoryPath(configPath, host string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, host)
+	if name == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "history", name+".log")
+}
+
+// loadInputHistory reads the most recent commands from a history file, rewriting the file when it has
+// grown beyond the cap. A missing file is an empty history.
+func loadInputHistory(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open command history: %w", err)
+	}
+	defer file.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if command := strings.TrimSpace(scanner.Text()); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read command history: %w", err)
+	}
+
+	if len(commands) > maxInputHistory {
+		commands = commands[len(commands)-maxInputHistory:]
+		data := strings.Join(commands, "\n") + "\n"
+		if err := config.WritePrivateFile(path, []byte(data)); err != nil {
+			return commands, fmt.Errorf("failed to trim command history: %w", err)
+		}
+	}
+
+	return commands, nil
+}
+
+// appendInputHistory adds a command to the end of a history file, creating it if needed
+func appendInputHistory(path, command string) error {
+	if err := config.MakePrivateDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create command history directory: %w", err)
+	}
+
+	file, err := config.OpenPrivateFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open command history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(command + "\n"); err != nil {
+		return fmt.Errorf("failed to write command history: %w", err)
+	}
+	return nil
+}
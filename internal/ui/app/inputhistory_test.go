@@ -0,0 +1,43 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestInputHistoryIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("access is restricted with an access control list on Windows")
+	}
+
+	dir := filepath.Join(t.TempDir(), "history")
+	path := filepath.Join(dir, "demo.example.log")
+	if err := appendInputHistory(path, "status"); err != nil {
+		t.Fatalf("appendInputHistory failed: %v", err)
+	}
+
+	// A file left readable by others is restricted the next time a command is appended
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendInputHistory(path, "deploy"); err != nil {
+		t.Fatalf("appendInputHistory failed: %v", err)
+	}
+
+	for name, want := range map[string]os.FileMode{dir: 0700, path: 0600} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s has permissions %o, want %o", name, got, want)
+		}
+	}
+
+	commands, err := loadInputHistory(path)
+	if err != nil || len(commands) != 2 || commands[0] != "status" || commands[1] != "deploy" {
+		t.Errorf("loadInputHistory = %v, %v, want [status deploy]", commands, err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package app implements inspection of the last communication error for Application Mode in the Universal
+// Application Console. Error messages only carry a one-line summary of what the server sent back, which keeps
+// proxy error pages from flooding the screen; /inspect shows the status, content type, and the full body.
+package app
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// inspectLastError shows the details of the last communication error, including the full HTTP response body
+func (m *AppModel) inspectLastError() tea.Cmd {
+	lastErr := m.protocolClient.GetLastError()
+	if lastErr == nil {
+		m.statusMessage = "No communication errors in this session"
+		return nil
+	}
+
+	blocks := []map[string]interface{}{
+		{"type": "text", "content": fmt.Sprintf("Last error: %v", lastErr), "status": "error"},
+	}
+
+	var protoErr *protocol.ProtocolError
+	if errors.As(lastErr, &protoErr) {
+		blocks = append(blocks, map[string]interface{}{
+			"type":    "text",
+			"content": fmt.Sprintf("Type: %s · at %s", protoErr.Type, protoErr.Timestamp.Format("15:04:05")),
+		})
+
+		if details := protoErr.HTTPDetails; details != nil {
+			contentType := details.ContentType
+			if contentType == "" {
+				contentType = "not given"
+			}
+			blocks = append(blocks, map[string]interface{}{
+				"type":    "text",
+				"content": fmt.Sprintf("Status: %s · Content-Type: %s · %d bytes", details.StatusText, contentType, details.ContentLength),
+			})
+			if details.Body != "" {
+				blocks = append(blocks, map[string]interface{}{
+					"type": "code",
+					"content": map[string]interface{}{
+						"code":     details.Body,
+						"language": bodyLanguage(details.ContentType),
+						"filename": "response body",
+					},
+				})
+			}
+		}
+	}
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/inspect",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "structured",
+					Content: blocks,
+				},
+			},
+			success: true,
+		}
+	})
+}
+
+// bodyLanguage returns the highlighting language for a response body's content type, or "text" when unknown
+func bodyLanguage(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/html", "application/xhtml+xml":
+		return "html"
+	case "application/json", "application/problem+json":
+		return "json"
+	case "application/xml", "text/xml":
+		return "xml"
+	default:
+		return "text"
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestCtrlLClearsHistoryAndCtrlNTogglesLineNumbers(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	m.confirmDestructive = true
+	m.addToHistory(HistoryEntry{Command: "status", Response: textResponse("ok")})
+
+	showing := m.showLineNumbers
+	m.handleKeyInput(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.showLineNumbers == showing {
+		t.Error("Ctrl+N did not toggle line numbers")
+	}
+	if m.pendingConfirmation != nil {
+		t.Error("Ctrl+N asked to clear the history")
+	}
+
+	m.handleKeyInput(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if m.pendingConfirmation == nil {
+		t.Fatal("Ctrl+L did not ask to clear the history")
+	}
+	if m.showLineNumbers == showing {
+		t.Error("Ctrl+L toggled line numbers")
+	}
+	m.handleKeyInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if len(m.commandHistory) != 0 {
+		t.Errorf("history has %d entries after confirming Ctrl+L, want none", len(m.commandHistory))
+	}
+}
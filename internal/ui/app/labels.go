@@ -0,0 +1,73 @@
+// Package app implements the history prompt labels for Application Mode in the Universal Application Console.
+// Commands and responses in history are prefixed with "YOU>" and "APP>" unless the profile names its own
+// labels and colors, for example the application's name for responses, which tells tabbed sessions apart
+// and reads better in demos. The response label may contain {app}, replaced with the connected
+// application's name once the handshake reports it.
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Default history prompt labels
+const (
+	defaultUserLabel = "YOU>"
+	defaultAppLabel  = "APP>"
+)
+
+// appNamePlaceholder is replaced with the application's name in the response label
+const appNamePlaceholder = "{app}"
+
+// promptLabels holds the history prefixes and their styles
+type promptLabels struct {
+	user      string
+	app       string // May contain appNamePlaceholder
+	userStyle lipgloss.Style
+	appStyle  lipgloss.Style
+}
+
+// newPromptLabels builds the labels from a profile, keeping the defaults for anything it leaves unset
+func newPromptLabels(profile *interfaces.Profile) promptLabels {
+	labels := promptLabels{
+		user:      defaultUserLabel,
+		app:       defaultAppLabel,
+		userStyle: userCommandStyle,
+		appStyle:  appResponseStyle,
+	}
+
+	if profile.UserLabel != "" {
+		labels.user = profile.UserLabel
+	}
+	if profile.AppLabel != "" {
+		labels.app = profile.AppLabel
+	}
+	if profile.UserLabelColor != "" {
+		labels.userStyle = labels.userStyle.Foreground(lipgloss.Color(profile.UserLabelColor))
+	}
+	if profile.AppLabelColor != "" {
+		labels.appStyle = labels.appStyle.Foreground(lipgloss.Color(profile.AppLabelColor))
+	}
+
+	return labels
+}
+
+// userLabel returns the prefix of commands in history
+func (m *AppModel) userLabel() string {
+	return m.labels.user
+}
+
+// appLabel returns the prefix of responses in history, with the application's name filled in
+func (m *AppModel) appLabel() string {
+	if !strings.Contains(m.labels.app, appNamePlaceholder) {
+		return m.labels.app
+	}
+
+	name := m.appName
+	if name == "" {
+		name = m.profile.Name
+	}
+	return strings.ReplaceAll(m.labels.app, appNamePlaceholder, name)
+}
@@ -0,0 +1,159 @@
+// Package app implements command latency tracking for Application Mode in the Universal Application Console.
+// This file keeps a histogram of command response times alongside the running average, so outliers are not
+// hidden, and flags commands that take well over the average so users notice a backend slowing down. The
+// /stats meta command shows the session statistics and the histogram.
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// latencyBucketBounds are the upper bounds of the histogram buckets; a final bucket holds anything slower
+var latencyBucketBounds = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Slow command detection: a command is slow when it takes slowCommandFactor times the running average,
+// once the average rests on minSlowCommandSamples commands. Responses under minSlowCommandDuration are
+// never flagged, since doubling a fast average is not noticeable.
+const (
+	slowCommandFactor      = 2.0
+	minSlowCommandSamples  = 3
+	minSlowCommandDuration = 250 * time.Millisecond
+)
+
+// histogramBarWidth is the length of the longest bar drawn by /stats
+const histogramBarWidth = 30
+
+// LatencyHistogram counts command response times in fixed buckets
+type LatencyHistogram struct {
+	Buckets []int         `json:"buckets"` // One count per bound in latencyBucketBounds, plus one for slower
+	Fastest time.Duration `json:"fastest"`
+	Slowest time.Duration `json:"slowest"`
+}
+
+// Record adds a response time to the histogram
+func (h *LatencyHistogram) Record(duration time.Duration) {
+	if h.Buckets == nil {
+		h.Buckets = make([]int, len(latencyBucketBounds)+1)
+	}
+
+	bucket := len(latencyBucketBounds)
+	for i, bound := range latencyBucketBounds {
+		if duration < bound {
+			bucket = i
+			break
+		}
+	}
+	h.Buckets[bucket]++
+
+	if h.Fastest == 0 || duration < h.Fastest {
+		h.Fastest = duration
+	}
+	if duration > h.Slowest {
+		h.Slowest = duration
+	}
+}
+
+// Count returns the number of recorded response times
+func (h *LatencyHistogram) Count() int {
+	total := 0
+	for _, count := range h.Buckets {
+		total += count
+	}
+	return total
+}
+
+// String draws the histogram as one labelled bar per bucket
+func (h *LatencyHistogram) String() string {
+	if h.Count() == 0 {
+		return "No timed commands yet"
+	}
+
+	largest := 0
+	for _, count := range h.Buckets {
+		if count > largest {
+			largest = count
+		}
+	}
+
+	lines := make([]string, 0, len(h.Buckets))
+	for i, count := range h.Buckets {
+		label := fmt.Sprintf("≥ %v", latencyBucketBounds[len(latencyBucketBounds)-1])
+		if i < len(latencyBucketBounds) {
+			label = fmt.Sprintf("< %v", latencyBucketBounds[i])
+		}
+		bar := strings.Repeat("█", count*histogramBarWidth/largest)
+		if count > 0 && bar == "" {
+			bar = "▏"
+		}
+		lines = append(lines, fmt.Sprintf("%8s │%s %d", label, bar, count))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordCommandLatency adds a command's response time to the statistics and warns in the status line
+// if it was much slower than the average of the commands before it
+func (m *AppModel) recordCommandLatency(command string, duration time.Duration) {
+	stats := &m.connectionStats
+	average := stats.AverageResponseTime
+	samples := stats.Latency.Count()
+
+	stats.Latency.Record(duration)
+
+	if samples >= minSlowCommandSamples && duration >= minSlowCommandDuration &&
+		float64(duration) > slowCommandFactor*float64(average) {
+		stats.SlowCommands++
+		m.statusMessage = fmt.Sprintf("⚠ Slow response: '%s' took %v, %.1f× the %v average",
+			command, duration.Round(time.Millisecond), float64(duration)/float64(average), average.Round(time.Millisecond))
+	}
+}
+
+// showStats displays session statistics and the response time histogram
+func (m *AppModel) showStats() tea.Cmd {
+	stats := m.connectionStats
+	sessionDuration := time.Since(stats.SessionStartTime).Round(time.Second)
+
+	lines := []string{
+		"--- Session Statistics ---",
+		fmt.Sprintf("Session duration:  %v", sessionDuration),
+		fmt.Sprintf("Commands:          %d (%d succeeded, %d failed)", stats.TotalCommands, stats.SuccessfulCommands, stats.FailedCommands),
+		fmt.Sprintf("Actions:           %d", stats.TotalActions),
+		fmt.Sprintf("Average response:  %v", stats.AverageResponseTime.Round(time.Millisecond)),
+		fmt.Sprintf("Fastest / slowest: %v / %v", stats.Latency.Fastest.Round(time.Millisecond), stats.Latency.Slowest.Round(time.Millisecond)),
+		fmt.Sprintf("Slow responses:    %d (over %.0f× the running average)", stats.SlowCommands, slowCommandFactor),
+		"",
+		"Response times:",
+		stats.Latency.String(),
+		"--------------------------",
+	}
+	statsText := strings.Join(lines, "\n")
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/stats",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: statsText,
+				},
+			},
+			success:  true,
+			duration: 0,
+		}
+	})
+}
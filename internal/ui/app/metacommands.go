@@ -0,0 +1,246 @@
+// Package app implements the meta command registry for Application Mode in the Universal Application Console.
+// Every slash command is registered once with its aliases, usage, argument limits, and description.
+// Dispatch, the /help listing, and the command palette all read from the registry so they cannot drift apart,
+// and invocations with the wrong number of arguments are answered with the command's usage. Plugins registered
+// with RegisterMetaCommandPlugin add their own commands to every session.
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MetaCommand describes one console meta command
+type MetaCommand struct {
+	Name        string   // Primary name, including the leading slash
+	Aliases     []string // Alternative names, including the leading slash
+	Usage       string   // Argument synopsis, e.g. "<file>"; empty if the command takes none
+	Description string
+	MinArgs     int                         // Fewest arguments accepted
+	MaxArgs     int                         // Most arguments accepted; -1 for no limit
+	Handler     func(args []string) tea.Cmd // Receives the whitespace-separated arguments
+}
+
+// Synopsis returns the command's names and usage as shown in help, e.g. "/export <file>"
+func (c *MetaCommand) Synopsis() string {
+	text := strings.Join(append([]string{c.Name}, c.Aliases...), ", ")
+	if c.Usage != "" {
+		text += " " + c.Usage
+	}
+	return text
+}
+
+// checkArgs reports the command's usage when it is given too few or too many arguments
+func (c *MetaCommand) checkArgs(args []string) error {
+	if len(args) >= c.MinArgs && (c.MaxArgs < 0 || len(args) <= c.MaxArgs) {
+		return nil
+	}
+	return fmt.Errorf("Usage: %s", strings.TrimSpace(c.Name+" "+c.Usage))
+}
+
+// MetaCommandRegistry holds the meta commands available in a session, in registration order
+type MetaCommandRegistry struct {
+	commands []MetaCommand
+	index    map[string]int // Lowercase names and aliases to positions in commands
+}
+
+// NewMetaCommandRegistry creates an empty registry
+func NewMetaCommandRegistry() *MetaCommandRegistry {
+	return &MetaCommandRegistry{
+		index: make(map[string]int),
+	}
+}
+
+// Register adds a command. Names and aliases must start with "/" and must not already be registered.
+func (r *MetaCommandRegistry) Register(command MetaCommand) error {
+	if command.Handler == nil {
+		return fmt.Errorf("meta command %s has no handler", command.Name)
+	}
+
+	names := append([]string{command.Name}, command.Aliases...)
+	for _, name := range names {
+		if !strings.HasPrefix(name, "/") || len(name) < 2 || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("invalid meta command name '%s'", name)
+		}
+		if _, exists := r.index[strings.ToLower(name)]; exists {
+			return fmt.Errorf("meta command %s is already registered", name)
+		}
+	}
+
+	for _, name := range names {
+		r.index[strings.ToLower(name)] = len(r.commands)
+	}
+	r.commands = append(r.commands, command)
+	return nil
+}
+
+// Lookup finds a command by name or alias, ignoring case
+func (r *MetaCommandRegistry) Lookup(name string) (*MetaCommand, bool) {
+	position, exists := r.index[strings.ToLower(name)]
+	if !exists {
+		return nil, false
+	}
+	return &r.commands[position], true
+}
+
+// Commands returns the registered commands in registration order
+func (r *MetaCommandRegistry) Commands() []MetaCommand {
+	return r.commands
+}
+
+// Help lists every command with its description, aligned in two columns
+func (r *MetaCommandRegistry) Help() string {
+	width := 0
+	for i := range r.commands {
+		if w := len(r.commands[i].Synopsis()); w > width {
+			width = w
+		}
+	}
+
+	lines := make([]string, 0, len(r.commands))
+	for i := range r.commands {
+		command := &r.commands[i]
+		lines = append(lines, fmt.Sprintf("%-*s - %s", width, command.Synopsis(), command.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MetaCommandPlugin supplies extra meta commands for a session. It receives the session's model so
+// that handlers can act on it.
+type MetaCommandPlugin func(m *AppModel) []MetaCommand
+
+var (
+	metaCommandPlugins      []MetaCommandPlugin
+	metaCommandPluginsMutex sync.RWMutex
+)
+
+// RegisterMetaCommandPlugin adds a plugin whose commands are registered in every Application Mode
+// session created afterwards
+func RegisterMetaCommandPlugin(plugin MetaCommandPlugin) {
+	metaCommandPluginsMutex.Lock()
+	defer metaCommandPluginsMutex.Unlock()
+
+	metaCommandPlugins = append(metaCommandPlugins, plugin)
+}
+
+// RegisterMetaCommand adds a command to this session's registry
+func (m *AppModel) RegisterMetaCommand(command MetaCommand) error {
+	return m.metaCommands.Register(command)
+}
+
+// newMetaCommandRegistry registers the built-in commands in help order, followed by plugin commands.
+// Plugin commands that conflict with existing ones are skipped and reported in the status line.
+func (m *AppModel) newMetaCommandRegistry() *MetaCommandRegistry {
+	registry := NewMetaCommandRegistry()
+
+	builtins := []MetaCommand{
+		{Name: "/quit", Aliases: []string{"/exit"}, Description: "Disconnect and return to Console Menu",
+			Handler: func([]string) tea.Cmd { return m.disconnectAndReturn() }},
+		{Name: "/clear", Description: "Clear command history",
+			Handler: func([]string) tea.Cmd { return m.confirmClearHistory() }},
+		{Name: "/help", Description: "Show this help message",
+			Handler: func([]string) tea.Cmd { return m.showHelp() }},
+		{Name: "/expand-all", Description: "Expand all collapsible sections",
+			Handler: func([]string) tea.Cmd { return m.expandAllSections() }},
+		{Name: "/collapse-all", Description: "Collapse all collapsible sections",
+			Handler: func([]string) tea.Cmd { return m.collapseAllSections() }},
+		{Name: "/retry", Description: "Retry the last command",
+			Handler: func([]string) tea.Cmd { return m.retryLastCommand() }},
+		{Name: "/edit-last", Description: "Put the last failed command back in the input to edit and resend",
+			Handler: func([]string) tea.Cmd { return m.editLastFailedCommand() }},
+		{Name: "/commands", Description: "List the commands the application publishes, with their arguments",
+			Handler: func([]string) tea.Cmd { return m.showCommands() }},
+		{Name: "/history", Description: "Show command history",
+			Handler: func([]string) tea.Cmd { return m.showCommandHistory() }},
+		{Name: "/stats", Description: "Show session statistics and response times",
+			Handler: func([]string) tea.Cmd { return m.showStats() }},
+		{Name: "/export", Usage: "<file>", Description: "Export the session transcript (.md for Markdown, .html for HTML)",
+			MinArgs: 1, MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.exportHistory(strings.Join(args, " ")) }},
+		{Name: "/audit", Usage: "[count]", Description: "Show the most recent executed operations from the audit trail",
+			MaxArgs: 1,
+			Handler: func(args []string) tea.Cmd { return m.showAudit(args) }},
+		{Name: "/nav-stats", Usage: "[export <file>]", Description: "Summarize focus navigation, or export an anonymized copy as JSON",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.navStats(args) }},
+		{Name: "/refresh", Usage: "[command]", Description: "Run a command past the response cache, or clear the cache",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.refresh(args) }},
+		{Name: "/diff", Usage: "<command>", Description: "Run a command again and show what changed since its last output",
+			MinArgs: 1, MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.diffCommand(args) }},
+		{Name: "/watch", Usage: "[<interval> <command>|stop]", Description: "Re-run a command every interval in place, diffed against the last run; Esc stops it",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.watchCommand(args) }},
+		{Name: "/set", Usage: "[name=value ...]", Description: "Set context variables sent with every request (name= removes one), or list them",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.setVariables(args) }},
+		{Name: "/theme", Usage: "[name|list]", Description: "Change visual theme, list available themes, or pick one with a live preview",
+			MaxArgs: 1,
+			Handler: func(args []string) tea.Cmd {
+				if len(args) == 0 {
+					return m.openThemePicker()
+				}
+				if args[0] == "list" {
+					return m.listThemes()
+				}
+				return m.changeTheme(args[0])
+			}},
+		{Name: "/contrast", Description: "Toggle high-contrast mode",
+			Handler: func([]string) tea.Cmd { return m.toggleHighContrast() }},
+		{Name: "/timestamps", Usage: "[save]", Description: "Show or hide timestamps (Ctrl+T); save keeps the choice in the profile",
+			MaxArgs: 1,
+			Handler: func(args []string) tea.Cmd { return m.toggleTimestamps(args) }},
+		{Name: "/linenumbers", Usage: "[save]", Description: "Show or hide line numbers in code blocks (Ctrl+N); save keeps the choice in the profile",
+			MaxArgs: 1,
+			Handler: func(args []string) tea.Cmd { return m.toggleLineNumbers(args) }},
+		{Name: "/config", Description: "Edit the configuration file in $EDITOR (Ctrl+O) and reload the profile",
+			Handler: func([]string) tea.Cmd { return m.editConfig() }},
+		{Name: "/show-all", Description: "Show or hide the content blocks the profile's hideBlocks and hideStatuses filter out",
+			Handler: func([]string) tea.Cmd { return m.toggleShowAll() }},
+		{Name: "/follow", Description: "Toggle following new output (F in content focus)",
+			Handler: func([]string) tea.Cmd { return m.toggleFollow() }},
+		{Name: "/inspect", Description: "Show the last communication error with the full response body",
+			Handler: func([]string) tea.Cmd { return m.inspectLastError() }},
+		{Name: "/copy-code", Description: "Copy the current error's code to the clipboard",
+			Handler: func([]string) tea.Cmd { return m.copyErrorCode() }},
+		{Name: errorReportCommand, Usage: "[--no-redact] [file]", Description: "Copy a diagnostic report of the current error, or write it to a file",
+			MaxArgs: -1, Handler: m.showErrorReport},
+		{Name: "/cancel", Description: "Cancel the active workflow (if supported by the application)",
+			Handler: func([]string) tea.Cmd { return m.cancelWorkflow() }},
+		{Name: "/register", Usage: "[name]", Description: "Add this application to the registry so the Console Menu lists it",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.registerApp(args) }},
+		{Name: "/switch", Usage: "[--keep-history] <profile>", Description: "Connect with another saved profile without returning to the menu",
+			MinArgs: 1, MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.switchProfile(args) }},
+		{Name: "/apps", Usage: "[name]", Description: "List registered applications with their health and switch to one",
+			MaxArgs: -1,
+			Handler: func(args []string) tea.Cmd { return m.showApps(args) }},
+		{Name: "/connect", Description: "Disconnect and return to menu",
+			Handler: func([]string) tea.Cmd {
+				m.statusMessage = "Disconnecting to switch connection. Please select from the menu."
+				return m.disconnectAndReturn()
+			}},
+	}
+	for _, command := range builtins {
+		registry.Register(command)
+	}
+
+	metaCommandPluginsMutex.RLock()
+	plugins := append([]MetaCommandPlugin(nil), metaCommandPlugins...)
+	metaCommandPluginsMutex.RUnlock()
+
+	for _, plugin := range plugins {
+		for _, command := range plugin(m) {
+			if err := registry.Register(command); err != nil {
+				m.statusMessage = fmt.Sprintf("Skipped plugin command: %v", err)
+			}
+		}
+	}
+
+	return registry
+}
@@ -10,16 +10,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
 	"github.com/universal-console/console/internal/errors"
 	"github.com/universal-console/console/internal/interfaces"
 	"github.com/universal-console/console/internal/protocol"
 	"github.com/universal-console/console/internal/ui/actions"
+	"github.com/universal-console/console/internal/ui/components"
+	"github.com/universal-console/console/internal/ui/form"
 	"github.com/universal-console/console/internal/ui/workflow"
 )
 
@@ -31,6 +35,7 @@ type AppModel struct {
 	contentRenderer interfaces.ContentRenderer
 	configManager   interfaces.ConfigManager
 	authManager     interfaces.AuthManager
+	registryManager interfaces.RegistryManager // May be nil when the session has no registry
 
 	// Integrated UI components
 	actionsPane     *actions.Pane
@@ -44,42 +49,88 @@ type AppModel struct {
 	appVersion      string
 	protocolVersion string
 	features        map[string]bool
+	commandCatalog  []interfaces.CommandInfo // Commands the application published in its handshake
 	connectionError string
 
 	// Command history and interaction state
 	commandHistory    []HistoryEntry
+	historySpill      *historySpill
 	historyIndex      int
 	commandInput      textinput.Model
+	suggestion        string // Top-ranked completion of the input, shown as ghost text
+	cancelSuggestion  context.CancelFunc // Cancels the suggestion request in flight; nil when there is none
+	warnedInput       string // Input whose argument problem was warned about; Enter again sends it
 	inputHistory      []string
 	inputHistoryIndex int
+	inputHistoryPath  string // Per-host history file; empty when the host is unknown
 
 	// Current response content and display state
 	currentResponse *interfaces.CommandResponse
 	renderedContent []interfaces.RenderedContent
 	scrollOffset    int
 	maxDisplayLines int
+	scrolledBack    bool // true when the user has scrolled away from the latest output
+	historyLines    int  // number of history lines available at the last render
+
+	// Flattened history line buffer, rebuilt only when its key changes
+	historyBuffer    []historyLine
+	historyBufferKey historyBufferKey
+	historyVersion   int
+
+	// Screen positions of interactive elements from the last render, for mouse input
+	layout viewLayout
 
 	// Focus management and keyboard navigation
 	focusState        FocusState
 	focusableElements []FocusableElement
 	currentFocusIndex int
 	navigationHistory []NavigationStep
+	navigationMethod  string // Method of the user navigation in progress, recorded by SetFocus
 
 	// Collapsible content management
 	expandedSections    map[string]bool
 	focusedSectionID    string
 	collapsibleElements []CollapsibleElement
 
+	// Tree navigation: the focused tree and the index of the focused visible node
+	focusedTreeID   string
+	focusedTreeNode int
+
+	// History prefixes for commands and responses
+	labels promptLabels
+
+	// Context variables merged into every request, changed with /set
+	variables map[string]string
+
+	// Reusable responses keyed by command, cleared with /refresh
+	responseCache map[string]cacheEntry
+
+	// Interactive forms by content ID, and the form that has focus
+	forms         map[string]*form.Model
+	focusedFormID string
+
 	// Workflow and operation context
 	operationHistory  []OperationRecord
-	pendingOperations map[string]*PendingOperation
+	operationCount    int       // Operations recorded this session, numbering record IDs
+	auditLog          *auditLog // Appends operation records to AuditLogPath
+	pendingOperations map[string]*PendingOperation // In-flight requests, guarded by pendingMutex
+	pendingMutex      sync.Mutex
+	nextOperationID   int
+	retryBudget       *protocol.RetryBudget // Automatic retries left to the requests of this session
+	retryBudgetShown  bool                  // The user has been told the retry budget is spent
+
+	// Requests derive from this context, which is cancelled when the model quits or disconnects
+	requestContext       context.Context
+	cancelRequestContext context.CancelFunc
 
 	// User interface preferences and configuration
 	showTimestamps     bool
 	showLineNumbers    bool
-	autoScroll         bool
+	showAllBlocks      bool // /show-all: render the blocks the profile hides
+	autoScroll         bool // follow tail: jump to new output unless the user has scrolled back
 	confirmDestructive bool
 	maxHistorySize     int
+	highContrast       bool
 	theme              *interfaces.Theme
 
 	// Terminal dimensions for responsive layout
@@ -88,13 +139,56 @@ type AppModel struct {
 	headerHeight   int
 	inputHeight    int
 
+	// Pending yes/no confirmation that intercepts input until answered
+	pendingConfirmation *confirmationPrompt
+	palette             *commandPalette // Open command palette, or nil
+	themePicker         *themePicker    // Open theme picker, or nil
+	appPicker           *appPicker      // Open registered application picker, or nil
+	metaCommands        *MetaCommandRegistry
+
 	// Status and error management
 	statusMessage   string
 	currentError    *errors.ProcessedError // Replaces simple errorMessage string
+	errorDetails    []interfaces.RenderedContent // currentError's details, rendered once when it is set
 	lastUpdateTime  time.Time
 	connectionStats ConnectionStatistics
+
+	// Commands waiting for a response, shown with a spinner in the status section
+	running           []runningCommand
+	queuedCommands    []queuedCommand // Commands held back by the profile's maxInFlight
+	sequence          uint64          // Latest sequence number given to a request or history entry
+	spinnerFrame      int
+	spinnerGeneration int
+
+	// Pending re-render of history after the terminal was resized; only the latest resize is acted on
+	resizeGeneration int
+
+	// Commands shown as diffs against their earlier output
+	pendingDiffs    map[string]*interfaces.CommandResponse // Earlier output of commands sent by /diff, by command
+	watch           *commandWatch                          // Command run repeatedly by /watch, or nil
+	watchGeneration int
+
+	// Reference blocks whose content is being fetched, by URL
+	pendingReferences map[string]bool
+
+	// Running operations whose progress is polled, by operation ID
+	progressOperations     map[string]*progressOperation
+	progressPollGeneration int       // Distinguishes the latest scheduled poll from superseded ones
+	progressPollInFlight   bool      // A progress request has been sent and not yet answered
+	lastProgressPoll       time.Time // When the latest progress request was sent
+
+	// Messages the application pushed, handed from the client's read loop to the update loop
+	pushes chan pushedMsg
 }
 
+// Capability names advertised in the handshake Features map
+const (
+	FeatureProgress      = "progress"       // Progress polling for long-running operations
+	FeatureProgressBatch = "progress-batch" // Progress of several operations in one request, by OperationIDs
+	FeatureSuggest       = "suggest"        // Command suggestions while typing
+	FeatureCancel        = "cancel"         // Cancellation of workflows and operations
+)
+
 // FocusState represents the current focus location within the application interface
 type FocusState int
 
@@ -103,12 +197,21 @@ const (
 	FocusActions
 	FocusContent
 	FocusExpandable
+	FocusTree
+	FocusForm
 )
 
+// confirmationPrompt is a question the user must explicitly answer before a destructive operation runs
+type confirmationPrompt struct {
+	Title     string
+	Message   string
+	onConfirm func() tea.Cmd
+}
+
 // FocusableElement represents an interactive element that can receive keyboard focus
 type FocusableElement struct {
 	ID       string                 `json:"id"`
-	Type     string                 `json:"type"` // "input", "action", "collapsible", "content"
+	Type     string                 `json:"type"` // "input", "action", "form", "content", "collapsible", "tree"
 	Position int                    `json:"position"`
 	Data     map[string]interface{} `json:"data"`
 }
@@ -122,6 +225,34 @@ type CollapsibleElement struct {
 	Position int    `json:"position"`
 }
 
+// viewLayout records where interactive elements were drawn so mouse events can be mapped to them
+type viewLayout struct {
+	historyTop    int            // screen row of the first history content line
+	historyBottom int            // screen row just past the last history content line
+	actionsTop    int            // screen row of the top of the actions pane, or -1 if hidden
+	sectionRows   map[int]string // collapsible section IDs keyed by row offset from historyTop
+}
+
+// historyLine is a single rendered element of the history pane, tagged with the
+// collapsible section it toggles when it is a section header
+type historyLine struct {
+	text      string
+	sectionID string
+}
+
+// historyBufferKey captures the state that the flattened history buffer depends on.
+// Any change to history content must bump historyVersion via invalidateHistoryBuffer.
+type historyBufferKey struct {
+	version          int
+	width            int
+	focusState       FocusState
+	focusedSectionID string
+	focusedTreeID    string
+	focusedTreeNode  int
+	focusedFormID    string
+	showTimestamps   bool
+}
+
 // HistoryEntry represents a single interaction in the command history
 type HistoryEntry struct {
 	Timestamp time.Time                    `json:"timestamp"`
@@ -132,6 +263,12 @@ type HistoryEntry struct {
 	Workflow  *interfaces.Workflow         `json:"workflow,omitempty"`
 	Error     *errors.ProcessedError       `json:"error,omitempty"`
 	Duration  time.Duration                `json:"duration"`
+	Cached    bool                         `json:"cached,omitempty"` // Shown from the response cache without a request
+
+	renderedWidth int                         // Content width Rendered was wrapped to
+	seq           uint64                      // Sequence number of the request that produced the entry, or of the entry itself
+	fullContent   bool                        // Rendered without the complexity budget after "Expand full (heavy)"
+	diffBase      *interfaces.CommandResponse // Earlier output the entry is shown as a diff against, from /diff or /watch
 }
 
 // NavigationStep tracks focus navigation for user experience analysis
@@ -163,6 +300,9 @@ type PendingOperation struct {
 	ExpectedEnd time.Time              `json:"expectedEnd"`
 	Context     map[string]interface{} `json:"context"`
 	Cancelable  bool                   `json:"cancelable"`
+
+	cancel context.CancelFunc // Aborts the request
+	retry  string             // Latest automatic retry, e.g. "Transient error, retrying (1/2)…"
 }
 
 // ConnectionStatistics tracks communication metrics with the connected application
@@ -175,6 +315,8 @@ type ConnectionStatistics struct {
 	LastCommandTime     time.Time     `json:"lastCommandTime"`
 	SessionDuration     time.Duration `json:"sessionDuration"`
 	SessionStartTime    time.Time     `json:"sessionStartTime"`
+	Latency             LatencyHistogram `json:"latency"`
+	SlowCommands        int           `json:"slowCommands"` // Commands flagged as much slower than average
 }
 
 // NewAppModel creates a new Application Mode model with comprehensive dependency injection
@@ -184,6 +326,7 @@ func NewAppModel(
 	contentRenderer interfaces.ContentRenderer,
 	configManager interfaces.ConfigManager,
 	authManager interfaces.AuthManager,
+	registryManager interfaces.RegistryManager,
 ) *AppModel {
 	// Initialize command input component
 	commandInput := textinput.New()
@@ -199,6 +342,18 @@ func NewAppModel(
 		}
 	}
 
+	// Entries beyond the in-memory cap are spilled to a session log
+	maxHistorySize := defaultMaxHistorySize
+	if profile.MaxHistory > 0 {
+		maxHistorySize = profile.MaxHistory
+	}
+	spillPath := profile.HistorySpill
+	if spillPath == "" {
+		spillPath = defaultHistorySpillPath(configManager.GetConfigPath(), profile.Name)
+	}
+
+	requestContext, cancelRequestContext := context.WithCancel(context.Background())
+
 	model := &AppModel{
 		// Dependency injection
 		profile:         profile,
@@ -206,15 +361,17 @@ func NewAppModel(
 		contentRenderer: contentRenderer,
 		configManager:   configManager,
 		authManager:     authManager,
+		registryManager: registryManager,
 
 		// Initialize integrated UI components
 		actionsPane:     actions.NewPane(),
-		workflowManager: workflow.NewManager(),
+		workflowManager: workflow.NewManager(contentRenderer),
 		errorHandler:    errors.NewHandler(),
 		recoveryManager: errors.NewRecoveryManager(),
 
 		// Initialize command handling
 		commandHistory:    make([]HistoryEntry, 0),
+		historySpill:      newHistorySpill(spillPath),
 		historyIndex:      -1,
 		commandInput:      commandInput,
 		inputHistory:      make([]string, 0),
@@ -227,17 +384,31 @@ func NewAppModel(
 		navigationHistory:   make([]NavigationStep, 0),
 		expandedSections:    make(map[string]bool),
 		collapsibleElements: make([]CollapsibleElement, 0),
+		forms:               make(map[string]*form.Model),
+		variables:           newContextVariables(profile),
+		labels:              newPromptLabels(profile),
+		responseCache:       make(map[string]cacheEntry),
+		pendingDiffs:        make(map[string]*interfaces.CommandResponse),
+		pendingReferences:   make(map[string]bool),
+		progressOperations:  make(map[string]*progressOperation),
+		pushes:              make(chan pushedMsg, pushBufferSize),
 
 		// Initialize operation tracking
 		operationHistory:  make([]OperationRecord, 0),
+		auditLog:          newAuditLog(AuditLogPath),
 		pendingOperations: make(map[string]*PendingOperation),
+		retryBudget:       protocol.NewRetryBudget(sessionRetryBudget),
+
+		requestContext:       requestContext,
+		cancelRequestContext: cancelRequestContext,
 
 		// Configure default preferences
-		showTimestamps:     false,
-		showLineNumbers:    false,
+		showTimestamps:     profile.ShowTimestamps,
+		showLineNumbers:    !profile.HideLineNumbers,
 		autoScroll:         true,
-		confirmDestructive: true,
-		maxHistorySize:     1000,
+		confirmDestructive: profile.Confirmations,
+		maxHistorySize:     maxHistorySize,
+		highContrast:       profile.HighContrast,
 		theme:              theme,
 
 		// Initialize connection state
@@ -249,6 +420,31 @@ func NewAppModel(
 		// Set default UI dimensions
 		headerHeight: 3,
 		inputHeight:  3,
+		layout:       viewLayout{actionsTop: -1},
+	}
+
+	// Apply the profile's display preferences to the shared renderer
+	contentRenderer.SetHighContrast(profile.HighContrast)
+	model.actionsPane.SetHighContrast(profile.HighContrast)
+	contentRenderer.SetDefaultCodeLanguage(profile.CodeLanguage)
+	contentRenderer.SetShowLineNumbers(!profile.HideLineNumbers)
+	contentRenderer.SetBlockFilter(profile.HideBlocks, profile.HideStatuses)
+	contentRenderer.SetComplexityBudget(profile.ComplexityBudget)
+
+	model.metaCommands = model.newMetaCommandRegistry()
+
+	// Receive what the application pushes over this and any later connection of the client
+	protocolClient.SetPushHandler(model.receivePush)
+
+	// Recall commands sent to this host in earlier sessions
+	model.inputHistoryPath = defaultInputHistoryPath(configManager.GetConfigPath(), profile.Host)
+	if model.inputHistoryPath != "" {
+		commands, err := loadInputHistory(model.inputHistoryPath)
+		if err != nil {
+			model.statusMessage = fmt.Sprintf("Command history unavailable: %v", err)
+		}
+		model.inputHistory = append(model.inputHistory, commands...)
+		model.inputHistoryIndex = len(model.inputHistory)
 	}
 
 	// Initialize focusable elements
@@ -262,6 +458,7 @@ func (m *AppModel) Init() tea.Cmd {
 	commands := []tea.Cmd{
 		textinput.Blink,
 		m.loadApplicationInfo(),
+		m.waitForPush(),
 	}
 
 	return tea.Batch(commands...)
@@ -288,6 +485,9 @@ func (m *AppModel) SetTerminalSize(width, height int) {
 		m.maxDisplayLines = 5
 	}
 
+	// Wrap pre-formatted content to the history pane, less its border, padding, and the response indent
+	m.contentRenderer.SetContentWidth(m.historyContentWidth())
+
 	// Adjust command input width based on terminal size
 	availableWidth := width - 10 // Account for borders and padding
 	if availableWidth > 20 {
@@ -297,6 +497,12 @@ func (m *AppModel) SetTerminalSize(width, height int) {
 
 // ExecuteCommand processes a user command and sends it to the connected application
 func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
+	return m.executeCommand(command, true)
+}
+
+// executeCommand processes a user command, showing a cached response instead of sending it when useCache
+// is set and one has not expired
+func (m *AppModel) executeCommand(command string, useCache bool) tea.Cmd {
 	if !m.connected {
 		return m.showError("Not connected to any application")
 	}
@@ -309,6 +515,11 @@ func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
 	// Clear previous error/status when a new command is issued
 	m.clearStatus()
 
+	// Any new command ends a watch
+	if m.watch != nil && !isWatchCommand(command) {
+		m.stopWatch()
+	}
+
 	// Check for meta commands
 	if strings.HasPrefix(command, "/") {
 		return m.handleMetaCommand(command)
@@ -317,17 +528,47 @@ func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
 	// Add to input history
 	m.addToInputHistory(command)
 
+	// Hold the command back while the profile's in-flight limit is reached
+	if m.inFlightLimitReached() {
+		return m.queueCommand(command, useCache)
+	}
+
+	return m.sendCommand(command, useCache)
+}
+
+// sendCommand sends a command that has passed the in-flight limit, tagged with a new sequence number
+func (m *AppModel) sendCommand(command string, useCache bool) tea.Cmd {
+	// Strip a leading "!timeout=<duration>" override
+	command, timeout, err := m.parseTimeoutOverride(command)
+	if err != nil {
+		return m.showError(err.Error())
+	}
+
+	// Split off a trailing "> file" or "| program" when the profile allows it
+	display, redirect := command, (*outputRedirect)(nil)
+	if m.profile.ShellRedirection {
+		command, redirect = parseRedirect(command)
+	}
+
+	if useCache {
+		if response, ok := m.cachedResponse(command); ok {
+			return m.showCachedResponse(display, response, redirect)
+		}
+	}
+
 	// Create command request
 	request := interfaces.CommandRequest{
 		Command: command,
+		Context: m.withVariables(nil),
 	}
 
-	return tea.Cmd(func() tea.Msg {
+	seq := m.nextSequence()
+	return tea.Batch(m.startRunning(seq, display), func() tea.Msg {
 		startTime := time.Now()
 
 		// Execute command
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx, done := m.beginRequest("command", command, timeout)
+		defer done()
 
 		response, err := m.protocolClient.ExecuteCommand(ctx, request)
 		duration := time.Since(startTime)
@@ -336,10 +577,11 @@ func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
 			// Check if the returned error is a structured protocol error
 			if protoErr, ok := err.(*protocol.ProtocolError); ok && protoErr.HTTPDetails != nil && protoErr.HTTPDetails.Body != "" {
 				var structuredErr interfaces.ErrorResponse
-				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil {
+				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil && structuredErr.Error.Message != "" {
 					// Successfully parsed structured error
 					return commandExecutedMsg{
-						command:         command,
+						seq:             seq,
+						command:         display,
 						success:         false,
 						structuredError: &structuredErr,
 						duration:        duration,
@@ -348,7 +590,8 @@ func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
 			}
 			// Fallback to a simple error string if parsing fails or it's not a structured protocol error
 			return commandExecutedMsg{
-				command:  command,
+				seq:      seq,
+				command:  display,
 				success:  false,
 				error:    err.Error(),
 				duration: duration,
@@ -356,10 +599,13 @@ func (m *AppModel) ExecuteCommand(command string) tea.Cmd {
 		}
 
 		return commandExecutedMsg{
-			command:  command,
-			response: response,
-			success:  true,
-			duration: duration,
+			seq:          seq,
+			command:      display,
+			response:     response,
+			success:      true,
+			duration:     duration,
+			redirect:     redirect,
+			cacheCommand: command,
 		}
 	})
 }
@@ -385,28 +631,85 @@ func (m *AppModel) ExecuteAction(actionIndex int) tea.Cmd {
 		return nil
 	}
 
+	// Synthetic "Show more" actions fetch the next page of a paginated list
+	if strings.HasPrefix(selectedAction.Command, showMoreCommandPrefix) {
+		return m.fetchNextListPage(strings.TrimPrefix(selectedAction.Command, showMoreCommandPrefix))
+	}
+
+	// So do "Expand full (heavy)" actions, rendering a truncated response in full
+	if strings.HasPrefix(selectedAction.Command, expandFullCommandPrefix) {
+		return m.expandFullContent(strings.TrimPrefix(selectedAction.Command, expandFullCommandPrefix))
+	}
+
+	return m.confirmAction(*selectedAction)
+}
+
+// confirmAction sends an action, first asking for an explicit Yes when its type requires it. The
+// profile's confirm map decides per action type, even when confirmations are otherwise disabled;
+// unlisted types fall back to confirming only "confirmation" actions.
+func (m *AppModel) confirmAction(action interfaces.Action) tea.Cmd {
+	title := "Confirm Action"
+	message := fmt.Sprintf("Run '%s'? This cannot be undone.", action.Name)
+	onConfirm := func() tea.Cmd { return m.sendAction(action) }
+
+	if confirm, listed := m.profile.Confirm[action.Type]; listed {
+		if !confirm {
+			return m.sendAction(action)
+		}
+		if action.Type != "confirmation" {
+			message = fmt.Sprintf("Run '%s'?", action.Name)
+		}
+		m.showConfirmation(title, message, onConfirm)
+		return nil
+	}
+
+	if action.Type == "confirmation" {
+		return m.requestConfirmation(title, message, onConfirm)
+	}
+
+	return m.sendAction(action)
+}
+
+// sendAction sends an action request to the application
+func (m *AppModel) sendAction(selectedAction interfaces.Action) tea.Cmd {
+	return m.sendActionWithContext(selectedAction, nil)
+}
+
+// sendActionWithContext sends an action request carrying the given values, such as submitted form
+// fields, in its context
+func (m *AppModel) sendActionWithContext(selectedAction interfaces.Action, values map[string]interface{}) tea.Cmd {
+	// Recovery actions may name console meta commands, such as /retry or /connect
+	if m.recoveryManager.IsActive() && strings.HasPrefix(selectedAction.Command, "/") {
+		m.clearStatus()
+		return m.handleMetaCommand(selectedAction.Command)
+	}
+
 	m.statusMessage = fmt.Sprintf("Executing action: %s...", selectedAction.Name)
 
 	// Create action request
 	request := interfaces.ActionRequest{
 		Command: selectedAction.Command,
+		Context: m.withVariables(values),
 	}
 
 	// Include workflow context if present
 	if m.workflowManager.IsActive() {
 		if wf := m.workflowManager.GetCurrentWorkflow(); wf != nil {
 			request.WorkflowID = wf.ID
-			request.Context = make(map[string]interface{})
+			if request.Context == nil {
+				request.Context = make(map[string]interface{})
+			}
 			request.Context["workflowStep"] = wf.Step
 		}
 	}
 
+	seq := m.nextSequence()
 	return tea.Cmd(func() tea.Msg {
 		startTime := time.Now()
 
 		// Execute action
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx, done := m.beginRequest("action", selectedAction.Name, m.commandTimeout())
+		defer done()
 
 		response, err := m.protocolClient.ExecuteAction(ctx, request)
 		duration := time.Since(startTime)
@@ -415,10 +718,11 @@ func (m *AppModel) ExecuteAction(actionIndex int) tea.Cmd {
 			// Check if the returned error is a structured protocol error
 			if protoErr, ok := err.(*protocol.ProtocolError); ok && protoErr.HTTPDetails != nil && protoErr.HTTPDetails.Body != "" {
 				var structuredErr interfaces.ErrorResponse
-				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil {
+				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil && structuredErr.Error.Message != "" {
 					// Successfully parsed structured error
 					return actionExecutedMsg{
-						action:          *selectedAction,
+						seq:             seq,
+						action:          selectedAction,
 						success:         false,
 						structuredError: &structuredErr,
 						duration:        duration,
@@ -427,7 +731,8 @@ func (m *AppModel) ExecuteAction(actionIndex int) tea.Cmd {
 			}
 			// Fallback to a simple error string
 			return actionExecutedMsg{
-				action:   *selectedAction,
+				seq:      seq,
+				action:   selectedAction,
 				success:  false,
 				error:    err.Error(),
 				duration: duration,
@@ -435,7 +740,8 @@ func (m *AppModel) ExecuteAction(actionIndex int) tea.Cmd {
 		}
 
 		return actionExecutedMsg{
-			action:   *selectedAction,
+			seq:      seq,
+			action:   selectedAction,
 			response: response,
 			success:  true,
 			duration: duration,
@@ -446,20 +752,17 @@ func (m *AppModel) ExecuteAction(actionIndex int) tea.Cmd {
 // SetFocus changes the current focus state and updates navigation tracking
 func (m *AppModel) SetFocus(newFocus FocusState) {
 	if newFocus != m.focusState {
-		// Record navigation step
-		step := NavigationStep{
-			Timestamp: time.Now(),
-			FromFocus: m.focusState,
-			ToFocus:   newFocus,
-			Method:    "programmatic",
+		// Record navigation step, attributed to the user navigation in progress if there is one
+		method := m.navigationMethod
+		if method == "" {
+			method = "programmatic"
 		}
-		m.navigationHistory = append(m.navigationHistory, step)
+		m.navigationMethod = ""
+		m.recordNavigation(m.focusState, newFocus, method)
 
-		// Limit navigation history size
-		if len(m.navigationHistory) > 100 {
-			m.navigationHistory = m.navigationHistory[1:]
+		if m.focusState == FocusForm {
+			m.blurForm()
 		}
-
 		m.focusState = newFocus
 		m.updateFocusableElements()
 	}
@@ -471,6 +774,11 @@ func (m *AppModel) ToggleSection(sectionID string) tea.Cmd {
 		return nil
 	}
 
+	// Reference sections are expanded by fetching their content
+	if _, content := m.findContentEntry(sectionID); content != nil && content.Reference != nil {
+		return m.loadReference(*content.Reference)
+	}
+
 	// Toggle expansion state
 	m.expandedSections[sectionID] = !m.expandedSections[sectionID]
 
@@ -483,19 +791,20 @@ func (m *AppModel) ToggleSection(sectionID string) tea.Cmd {
 	}
 
 	// Use content renderer to toggle the section
+	expanded := m.expandedSections[sectionID]
 	return tea.Cmd(func() tea.Msg {
 		err := m.contentRenderer.ToggleCollapsible(sectionID)
 		if err != nil {
 			return sectionToggledMsg{
 				sectionID: sectionID,
-				expanded:  m.expandedSections[sectionID],
+				expanded:  expanded,
 				error:     err.Error(),
 			}
 		}
 
 		return sectionToggledMsg{
 			sectionID: sectionID,
-			expanded:  m.expandedSections[sectionID],
+			expanded:  expanded,
 		}
 	})
 }
@@ -504,16 +813,21 @@ func (m *AppModel) ToggleSection(sectionID string) tea.Cmd {
 
 // commandExecutedMsg carries the result of command execution
 type commandExecutedMsg struct {
+	seq             uint64 // Sequence number of the request; 0 for results produced without one
 	command         string
 	response        *interfaces.CommandResponse
 	success         bool
 	error           string
 	structuredError *interfaces.ErrorResponse
 	duration        time.Duration
+	redirect        *outputRedirect // Where to send the output once displayed, if anywhere
+	cached          bool            // Whether the response came from the cache rather than the application
+	cacheCommand    string          // Command to cache the response under, when it is cacheable
 }
 
 // actionExecutedMsg carries the result of action execution
 type actionExecutedMsg struct {
+	seq             uint64 // Sequence number of the request
 	action          interfaces.Action
 	response        *interfaces.CommandResponse
 	success         bool
@@ -541,6 +855,7 @@ type applicationInfoMsg struct {
 	appVersion      string
 	protocolVersion string
 	features        map[string]bool
+	commands        []interfaces.CommandInfo
 	error           string
 }
 
@@ -553,67 +868,43 @@ func (m *AppModel) loadApplicationInfo() tea.Cmd {
 	}
 
 	return tea.Cmd(func() tea.Msg {
-		// Get application info from the protocol client's connection state
-		// Check if we can cast to the concrete client type to access connection state
-		if client, ok := m.protocolClient.(*protocol.Client); ok {
-			// Access connection state via a method we need to add
-			if connectionState := client.GetConnectionState(); connectionState != nil {
-				return applicationInfoMsg{
-					appName:         connectionState.AppName,
-					appVersion:      connectionState.AppVersion,
-					protocolVersion: "2.0", // Could also get from connection state
-					features:        connectionState.Features,
-				}
-			}
+		info := m.protocolClient.GetAppInfo()
+		if info == nil {
+			return applicationInfoMsg{error: "Application details are unavailable: not connected"}
 		}
-		
-		// Fallback if we can't access connection state
+
 		return applicationInfoMsg{
-			appName:         "Connected Application",
-			appVersion:      "Unknown",
-			protocolVersion: "2.0",
-			features:        make(map[string]bool),
+			appName:         info.AppName,
+			appVersion:      info.AppVersion,
+			protocolVersion: info.ProtocolVersion,
+			features:        info.Features,
+			commands:        info.Commands,
 		}
 	})
 }
 
-// handleMetaCommand processes console meta commands
+// handleMetaCommand dispatches a console meta command through the registry, printing its usage on bad arguments
 func (m *AppModel) handleMetaCommand(command string) tea.Cmd {
-	parts := strings.Fields(command)
-	cmd := strings.ToLower(parts[0])
-
-	switch cmd {
-	case "/quit", "/exit":
-		return m.disconnectAndReturn()
-	case "/clear":
-		return m.clearHistory()
-	case "/help":
-		return m.showHelp()
-	case "/expand-all":
-		return m.expandAllSections()
-	case "/collapse-all":
-		return m.collapseAllSections()
-	case "/retry":
-		return m.retryLastCommand()
-	case "/history":
-		return m.showCommandHistory()
-	case "/theme":
-		themeName := ""
-		if len(parts) > 1 {
-			themeName = parts[1]
-		}
-		return m.changeTheme(themeName)
-	case "/connect":
-		m.statusMessage = "Disconnecting to switch connection. Please select from the menu."
-		return m.disconnectAndReturn()
-	default:
+	fields := strings.Fields(command)
+	metaCommand, ok := m.metaCommands.Lookup(fields[0])
+	if !ok {
 		return m.showError(fmt.Sprintf("Unknown meta command: %s", command))
 	}
+
+	args := fields[1:]
+	if err := metaCommand.checkArgs(args); err != nil {
+		return m.showError(err.Error())
+	}
+	return metaCommand.Handler(args)
 }
 
 // Command generation methods for meta commands
 
 func (m *AppModel) disconnectAndReturn() tea.Cmd {
+	m.CancelRequests()
+	m.historySpill.Close()
+	m.auditLog.Close()
+
 	return tea.Cmd(func() tea.Msg {
 		// Disconnect from the protocol client
 		if m.protocolClient.IsConnected() {
@@ -627,33 +918,93 @@ func (m *AppModel) disconnectAndReturn() tea.Cmd {
 	})
 }
 
+// confirmClearHistory clears the history after the user confirms
+func (m *AppModel) confirmClearHistory() tea.Cmd {
+	return m.requestConfirmation("Clear History",
+		"Clear all command history from the screen?",
+		m.clearHistory)
+}
+
+// requestConfirmation shows a confirmation dialog that runs onConfirm when the user answers Yes.
+// When destructive confirmations are disabled for the profile, onConfirm runs immediately.
+func (m *AppModel) requestConfirmation(title, message string, onConfirm func() tea.Cmd) tea.Cmd {
+	if !m.confirmDestructive {
+		return onConfirm()
+	}
+
+	m.showConfirmation(title, message, onConfirm)
+	return nil
+}
+
+// showConfirmation shows a confirmation dialog that runs onConfirm when the user answers Yes
+func (m *AppModel) showConfirmation(title, message string, onConfirm func() tea.Cmd) {
+	m.pendingConfirmation = &confirmationPrompt{
+		Title:     title,
+		Message:   message,
+		onConfirm: onConfirm,
+	}
+}
+
 func (m *AppModel) clearHistory() tea.Cmd {
 	m.commandHistory = make([]HistoryEntry, 0)
 	m.renderedContent = make([]interfaces.RenderedContent, 0)
 	m.scrollOffset = 0
+	m.scrolledBack = false
+	m.historySpill.Reset()
+	m.progressOperations = make(map[string]*progressOperation)
+	m.focusedTreeID = ""
+	if m.focusState == FocusTree || m.focusState == FocusForm {
+		m.SetFocus(FocusInput)
+	}
+	m.forms = make(map[string]*form.Model)
+	m.focusedFormID = ""
+	m.invalidateHistoryBuffer()
 	return nil
 }
 
 func (m *AppModel) showHelp() tea.Cmd {
-	helpText := `Available Meta Commands:
-/quit, /exit    - Disconnect and return to Console Menu
-/clear          - Clear command history
-/help           - Show this help message
-/expand-all     - Expand all collapsible sections
-/collapse-all   - Collapse all collapsible sections
-/retry          - Retry the last command
-/history        - Show command history
-/theme <name>   - Change visual theme
-/connect        - Disconnect and return to menu
+	helpText := "Available Meta Commands:\n" + m.metaCommands.Help() + `
 
 Keyboard Navigation:
-Tab             - Cycle through focusable elements
+Tab             - Accept the suggestion shown dimmed after the cursor, or cycle through focusable elements
 Shift+Tab       - Cycle backward through elements
-Space           - Toggle expansion of focused collapsible sections
+Space           - Toggle expansion of focused collapsible sections or tree nodes
+↑/↓ ←/→         - Move between tree nodes, collapse or expand branches
+S               - Select the focused tree node (Enter also selects leaves)
 Enter           - Execute focused action or submit command
 Escape          - Return focus to command input
 Ctrl+↑/↓        - Navigate command history
-Numbers 1-9     - Quick execute numbered actions`
+→ or Ctrl+E     - Accept the suggestion shown dimmed after the cursor
+Ctrl+P          - Open the command palette
+Ctrl+V          - Paste the clipboard into the command input, joining multiple lines
+Ctrl+T          - Show or hide timestamps (/timestamps)
+Ctrl+N          - Show or hide line numbers in code blocks (/linenumbers)
+Ctrl+L          - Clear the history pane, after confirming
+Ctrl+O          - Edit the configuration file in $EDITOR and reload it (/config)
+E               - Edit the failed command and resend it (error actions focused)
+Numbers 1-9     - Quick execute numbered actions, or jump to a numbered section from the content
+0, a-z          - Execute the 10th action with 0, and later actions by their letter with the actions focused
+
+Forms:
+Tab, ↑/↓        - Move between fields and the submit button
+←/→             - Choose an option of a select field
+Space           - Check or uncheck a checkbox
+Enter           - Move to the next field, or submit from the submit button
+Ctrl+S          - Submit the form from any field
+
+Output Redirection (profiles with shellRedirection: true):
+<cmd> > file    - Run a command and write its raw output to a file
+<cmd> >> file   - Append the output to a file instead
+<cmd> | program - Pipe the output to a shell program, e.g. logs | less
+Quote a literal > or | to send it to the application; the quotes are sent too
+
+Timeouts:
+!timeout=120 <cmd> - Wait up to 120 seconds (or a duration like 5m) for this command
+Otherwise the profile's commandTimeout applies, then --timeout, then 30 seconds
+
+Response Cache:
+Responses the application marks cacheable, or of commands in the profile's cacheCommands,
+are reused until they expire and marked (cached). /refresh <cmd> fetches a fresh one.`
 
 	// Create a mock help response
 	return tea.Cmd(func() tea.Msg {
@@ -681,11 +1032,6 @@ func (m *AppModel) expandAllSections() tea.Cmd {
 			return sectionToggledMsg{error: err.Error()}
 		}
 
-		// Update local state
-		for id := range m.expandedSections {
-			m.expandedSections[id] = true
-		}
-
 		return sectionToggledMsg{sectionID: "all", expanded: true}
 	})
 }
@@ -697,11 +1043,6 @@ func (m *AppModel) collapseAllSections() tea.Cmd {
 			return sectionToggledMsg{error: err.Error()}
 		}
 
-		// Update local state
-		for id := range m.expandedSections {
-			m.expandedSections[id] = false
-		}
-
 		return sectionToggledMsg{sectionID: "all", expanded: false}
 	})
 }
@@ -715,18 +1056,44 @@ func (m *AppModel) retryLastCommand() tea.Cmd {
 	return m.ExecuteCommand(lastEntry.Command)
 }
 
+// editLastFailedCommand puts the most recent failed command back in the command input so it can be
+// corrected and resubmitted instead of retyped
+func (m *AppModel) editLastFailedCommand() tea.Cmd {
+	for i := len(m.commandHistory) - 1; i >= 0; i-- {
+		entry := m.commandHistory[i]
+		if entry.Error == nil || strings.HasPrefix(entry.Command, "[Action]") || strings.HasPrefix(entry.Command, "/") {
+			continue
+		}
+
+		m.commandInput.SetValue(entry.Command)
+		m.commandInput.CursorEnd()
+		m.inputHistoryIndex = len(m.inputHistory)
+		m.SetFocus(FocusInput)
+		m.statusMessage = "Edit the command and press Enter to resend it"
+		return nil
+	}
+	return m.showError("No failed command to edit")
+}
+
 func (m *AppModel) showCommandHistory() tea.Cmd {
-	if len(m.commandHistory) == 0 {
+	entries, err := m.fullHistory()
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to read session log: %v", err))
+	}
+	if len(entries) == 0 {
 		return m.showError("No command history available")
 	}
 
 	var historyLines []string
 	historyLines = append(historyLines, "--- Command History ---")
-	for i, entry := range m.commandHistory {
+	for i, entry := range entries {
 		// Only show user-issued commands, not action markers
 		if !strings.HasPrefix(entry.Command, "[Action]") {
-			historyLines = append(historyLines, fmt.Sprintf("%3d: %s (%s)",
-				i+1, entry.Command, entry.Timestamp.Format("15:04:05")))
+			line := fmt.Sprintf("%3d: %s (%s)", i+1, entry.Command, entry.Timestamp.Format("15:04:05"))
+			if entry.Response != nil && len(entry.Response.Warnings) > 0 {
+				line += fmt.Sprintf(" ⚠ %d warning(s)", len(entry.Response.Warnings))
+			}
+			historyLines = append(historyLines, line)
 		}
 	}
 	historyLines = append(historyLines, "-----------------------")
@@ -752,10 +1119,6 @@ func (m *AppModel) showCommandHistory() tea.Cmd {
 
 // changeTheme attempts to load and apply a new visual theme.
 func (m *AppModel) changeTheme(themeName string) tea.Cmd {
-	if themeName == "" {
-		return m.showError("Usage: /theme <theme_name>")
-	}
-
 	theme, err := m.configManager.LoadTheme(themeName)
 	if err != nil {
 		return m.showError(fmt.Sprintf("Failed to load theme '%s': %v", themeName, err))
@@ -770,6 +1133,242 @@ func (m *AppModel) changeTheme(themeName string) tea.Cmd {
 	return nil
 }
 
+// toggleHighContrast switches high-contrast rendering on or off and re-renders history.
+func (m *AppModel) toggleHighContrast() tea.Cmd {
+	m.highContrast = !m.highContrast
+	m.contentRenderer.SetHighContrast(m.highContrast)
+	m.actionsPane.SetHighContrast(m.highContrast)
+
+	if m.highContrast {
+		m.statusMessage = "High-contrast mode enabled"
+	} else {
+		m.statusMessage = "High-contrast mode disabled"
+	}
+
+	// Re-render history with the new styling
+	m.reRenderHistory()
+
+	return nil
+}
+
+// toggleTimestamps shows or hides when each history entry was sent, saving the choice to the profile when asked
+func (m *AppModel) toggleTimestamps(args []string) tea.Cmd {
+	if len(args) > 0 && args[0] != "save" {
+		return m.showError("Usage: /timestamps [save]")
+	}
+
+	m.showTimestamps = !m.showTimestamps
+	m.profile.ShowTimestamps = m.showTimestamps
+
+	m.statusMessage = "Timestamps hidden"
+	if m.showTimestamps {
+		m.statusMessage = "Timestamps shown"
+	}
+	m.invalidateHistoryBuffer()
+
+	if len(args) > 0 {
+		return m.saveDisplayPreference(func(profile *interfaces.Profile) { profile.ShowTimestamps = m.showTimestamps })
+	}
+	return nil
+}
+
+// toggleLineNumbers shows or hides line numbers in code blocks that ask for them, re-rendering history so
+// existing code blocks follow, and saves the choice to the profile when asked
+func (m *AppModel) toggleLineNumbers(args []string) tea.Cmd {
+	if len(args) > 0 && args[0] != "save" {
+		return m.showError("Usage: /linenumbers [save]")
+	}
+
+	m.showLineNumbers = !m.showLineNumbers
+	m.profile.HideLineNumbers = !m.showLineNumbers
+	m.contentRenderer.SetShowLineNumbers(m.showLineNumbers)
+
+	m.statusMessage = "Line numbers hidden in code blocks"
+	if m.showLineNumbers {
+		m.statusMessage = "Line numbers shown in code blocks that ask for them"
+	}
+	m.reRenderHistory()
+
+	if len(args) > 0 {
+		return m.saveDisplayPreference(func(profile *interfaces.Profile) { profile.HideLineNumbers = !m.showLineNumbers })
+	}
+	return nil
+}
+
+// toggleShowAll handles /show-all, rendering the content blocks the profile hides or hiding them again
+func (m *AppModel) toggleShowAll() tea.Cmd {
+	if len(m.profile.HideBlocks) == 0 && len(m.profile.HideStatuses) == 0 {
+		m.statusMessage = "This profile hides no content blocks"
+		return nil
+	}
+
+	m.showAllBlocks = !m.showAllBlocks
+	if m.showAllBlocks {
+		m.contentRenderer.SetBlockFilter(nil, nil)
+		m.statusMessage = "Showing all content blocks"
+	} else {
+		m.contentRenderer.SetBlockFilter(m.profile.HideBlocks, m.profile.HideStatuses)
+		m.statusMessage = "Hiding the content blocks filtered by the profile"
+	}
+	m.reRenderHistory()
+	return nil
+}
+
+// saveDisplayPreference applies a change to the saved copy of the session's profile and writes it back.
+// The saved copy is changed rather than the session's profile, which may hold command-line overrides.
+func (m *AppModel) saveDisplayPreference(apply func(profile *interfaces.Profile)) tea.Cmd {
+	saved, err := m.configManager.LoadProfile(m.profile.Name)
+	if err != nil {
+		return m.showError(fmt.Sprintf("Cannot save to profile '%s': %v", m.profile.Name, err))
+	}
+
+	apply(saved)
+	if err := m.configManager.SaveProfile(saved); err != nil {
+		return m.showError(fmt.Sprintf("Failed to save profile: %v", err))
+	}
+
+	m.statusMessage += fmt.Sprintf(" (saved to profile '%s')", m.profile.Name)
+	return nil
+}
+
+// toggleFollow switches follow-tail mode. Turning it on jumps to the latest output;
+// turning it off pins the view where it is until follow is re-enabled.
+func (m *AppModel) toggleFollow() tea.Cmd {
+	if m.autoScroll && !m.scrolledBack {
+		m.autoScroll = false
+		m.scrollOffset = m.maxScrollOffset()
+		m.scrolledBack = true
+		m.statusMessage = "Follow paused"
+		return nil
+	}
+
+	m.autoScroll = true
+	m.statusMessage = "Following new output"
+	return m.scrollToBottom()
+}
+
+// following reports whether new output should scroll into view
+func (m *AppModel) following() bool {
+	return m.autoScroll && !m.scrolledBack
+}
+
+// listThemes shows all configured themes, marking the one currently in use.
+func (m *AppModel) listThemes() tea.Cmd {
+	themeNames, err := m.configManager.ListThemes()
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to list themes: %v", err))
+	}
+	if len(themeNames) == 0 {
+		return m.showError("No themes are configured")
+	}
+
+	var themeLines []string
+	themeLines = append(themeLines, "--- Available Themes ---")
+	for _, name := range themeNames {
+		marker := " "
+		if m.theme != nil && m.theme.Name == name {
+			marker = "*"
+		}
+		themeLines = append(themeLines, fmt.Sprintf("%s %s", marker, name))
+	}
+	themeLines = append(themeLines, "------------------------")
+	themeText := strings.Join(themeLines, "\n")
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/theme list",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: themeText,
+				},
+			},
+			success:  true,
+			duration: 0,
+		}
+	})
+}
+
+// hasFeature reports whether the connected application advertised a capability in its handshake.
+// Capabilities that were not advertised are treated as unsupported.
+func (m *AppModel) hasFeature(name string) bool {
+	return m.features[name]
+}
+
+// cancelWorkflow asks the application to cancel the active workflow
+func (m *AppModel) cancelWorkflow() tea.Cmd {
+	if !m.hasFeature(FeatureCancel) {
+		return m.showError("The connected application does not support cancellation")
+	}
+
+	workflow := m.workflowManager.GetCurrentWorkflow()
+	if !m.workflowManager.IsActive() || workflow == nil {
+		return m.showError("No active workflow to cancel")
+	}
+
+	request := interfaces.CancelRequest{WorkflowID: workflow.ID}
+	m.statusMessage = fmt.Sprintf("Cancelling workflow '%s'...", workflow.Title)
+
+	return tea.Cmd(func() tea.Msg {
+		startTime := time.Now()
+
+		ctx, done := m.beginRequest("cancel", workflow.ID, 10*time.Second)
+		defer done()
+
+		response, err := m.protocolClient.CancelOperation(ctx, request)
+		duration := time.Since(startTime)
+
+		if err != nil {
+			return commandExecutedMsg{
+				command:  "/cancel",
+				success:  false,
+				error:    fmt.Sprintf("Failed to cancel workflow: %v", err),
+				duration: duration,
+			}
+		}
+
+		if !response.Cancelled {
+			message := "The application declined to cancel the workflow"
+			if response.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, response.Message)
+			}
+			return commandExecutedMsg{
+				command:  "/cancel",
+				success:  false,
+				error:    message,
+				duration: duration,
+			}
+		}
+
+		cancelText := fmt.Sprintf("Workflow '%s' cancelled.", workflow.Title)
+		if response.Message != "" {
+			cancelText += " " + response.Message
+		}
+		if response.RollbackRequired {
+			cancelText += " Some changes may need to be rolled back."
+		}
+
+		// A response without a workflow ends the current one
+		return commandExecutedMsg{
+			command: "/cancel",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: cancelText,
+				},
+			},
+			success:  true,
+			duration: duration,
+		}
+	})
+}
+
 // showError creates a command to display error messages
 func (m *AppModel) showError(message string) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
@@ -784,16 +1383,27 @@ func (m *AppModel) showError(message string) tea.Cmd {
 
 // Utility methods
 
-// addToInputHistory adds a command to the input history
+// addToInputHistory adds a command to the input history and the host's history file. Repeating the
+// previous command does not add a new entry.
 func (m *AppModel) addToInputHistory(command string) {
+	m.inputHistoryIndex = len(m.inputHistory)
+	if len(m.inputHistory) > 0 && m.inputHistory[len(m.inputHistory)-1] == command {
+		return
+	}
+
 	m.inputHistory = append(m.inputHistory, command)
 
 	// Limit history size
-	if len(m.inputHistory) > 100 {
+	if len(m.inputHistory) > maxInputHistory {
 		m.inputHistory = m.inputHistory[1:]
 	}
-
 	m.inputHistoryIndex = len(m.inputHistory)
+
+	if m.inputHistoryPath != "" {
+		if err := appendInputHistory(m.inputHistoryPath, command); err != nil {
+			m.statusMessage = fmt.Sprintf("Command not saved to history: %v", err)
+		}
+	}
 }
 
 // updateFocusableElements rebuilds the list of focusable elements
@@ -807,19 +1417,41 @@ func (m *AppModel) updateFocusableElements() {
 		Position: 0,
 	})
 
-	// Add action elements if actions are visible
+	// Add action elements if actions are visible, one per numbered action
 	if m.actionsPane.IsVisible() {
-		if currentActions, err := m.actionsPane.Selected(); err == nil {
-			// This logic is simplified; it should iterate over all actions from the pane
+		for i, action := range m.actionsPane.Actions() {
 			elements = append(elements, FocusableElement{
-				ID:       "action_0", // Example ID
+				ID:       fmt.Sprintf("action_%d", i),
 				Type:     "action",
-				Position: 1,
-				Data:     map[string]interface{}{"actionName": currentActions.Name},
+				Position: len(elements),
+				Data: map[string]interface{}{
+					"actionName": action.Name,
+					"command":    action.Command,
+					"index":      i,
+				},
 			})
 		}
 	}
 
+	// Add forms, which come before the history so a new form is a single Tab away from the actions
+	for i, formID := range m.formIDs() {
+		elements = append(elements, FocusableElement{
+			ID:       formID,
+			Type:     "form",
+			Position: len(elements),
+			Data:     map[string]interface{}{"index": i},
+		})
+	}
+
+	// Add the scrollable history once there is something in it
+	if len(m.commandHistory) > 0 {
+		elements = append(elements, FocusableElement{
+			ID:       "history_content",
+			Type:     "content",
+			Position: len(elements),
+		})
+	}
+
 	// Add collapsible elements
 	for i, element := range m.collapsibleElements {
 		elements = append(elements, FocusableElement{
@@ -835,6 +1467,16 @@ func (m *AppModel) updateFocusableElements() {
 		})
 	}
 
+	// Add trees, which follow the sections as Tab moves on from them
+	for i, treeID := range m.treeIDs() {
+		elements = append(elements, FocusableElement{
+			ID:       treeID,
+			Type:     "tree",
+			Position: len(elements),
+			Data:     map[string]interface{}{"index": i},
+		})
+	}
+
 	m.focusableElements = elements
 }
 
@@ -844,27 +1486,85 @@ func (m *AppModel) clearStatus() {
 	if m.recoveryManager.IsActive() {
 		m.recoveryManager.EndSession()
 		m.currentError = nil
+		m.errorDetails = nil
 		m.actionsPane.Reset()
 	}
 }
 
+// setCurrentError shows an error in the error pane and offers its recovery actions as numbered actions
+func (m *AppModel) setCurrentError(processedErr *errors.ProcessedError) {
+	// Every error can be reported, whatever the application suggests
+	hasReport := false
+	for _, action := range processedErr.RecoveryActions {
+		hasReport = hasReport || action.Command == errorReportCommand
+	}
+	if !hasReport {
+		processedErr.RecoveryActions = append(processedErr.RecoveryActions, errorReportAction())
+	}
+
+	m.currentError = processedErr
+	m.errorDetails = components.RenderErrorDetails(processedErr.Details, m.contentRenderer, m.theme)
+	m.recoveryManager.StartSession(processedErr)
+	m.actionsPane.SetActions(m.recoveryManager.GetRecoveryActions())
+}
+
+// copyErrorCode copies the current error's code to the clipboard using the OSC 52 escape sequence
+func (m *AppModel) copyErrorCode() tea.Cmd {
+	if m.currentError == nil || m.currentError.Code == "" {
+		return m.showError("No error code to copy")
+	}
+
+	termenv.Copy(m.currentError.Code)
+	m.statusMessage = fmt.Sprintf("Copied error code %s to the clipboard", m.currentError.Code)
+	return nil
+}
+
 // reRenderHistory re-renders all history entries, which is useful after a state change like a new theme.
 func (m *AppModel) reRenderHistory() {
+	m.reRenderHistoryEntries(false)
+}
+
+// reRenderHistoryEntries re-renders history entries, skipping those already rendered at the current
+// content width when staleOnly is set
+func (m *AppModel) reRenderHistoryEntries(staleOnly bool) {
+	width := m.historyContentWidth()
+
 	// Create a new slice for updated history to avoid modifying while iterating
 	newHistory := make([]HistoryEntry, len(m.commandHistory))
 	copy(newHistory, m.commandHistory)
 
 	for i, entry := range newHistory {
-		if entry.Response != nil {
+		if entry.Response != nil && !(staleOnly && entry.renderedWidth == width) {
 			// Re-render the content part of the response
-			rendered, err := m.contentRenderer.RenderContent(entry.Response.Response.Content, m.theme)
+			render := m.contentRenderer.RenderContent
+			if entry.fullContent {
+				render = m.contentRenderer.RenderFullContent
+			}
+			rendered, err := render(entry.Response.Response.Content, m.theme)
 			if err == nil {
+				m.carryFormsOver(entry.Rendered, rendered)
 				newHistory[i].Rendered = rendered
+				newHistory[i].renderedWidth = width
+				m.applyDiff(&newHistory[i])
 			}
 		}
 	}
 	m.commandHistory = newHistory
 	m.updateCollapsibleElementsFromHistory()
+
+	// Re-rendered trees get new IDs, so tree focus cannot carry over
+	if m.focusState == FocusTree && m.findTree(m.focusedTreeID) == nil {
+		m.SetFocus(FocusInput)
+	}
+	if m.focusState == FocusForm && m.findForm(m.focusedFormID) == nil {
+		m.SetFocus(FocusInput)
+	}
+	m.invalidateHistoryBuffer()
+}
+
+// invalidateHistoryBuffer marks the flattened history buffer as stale after history content changes
+func (m *AppModel) invalidateHistoryBuffer() {
+	m.historyVersion++
 }
 
 // updateCollapsibleElementsFromHistory rebuilds the collapsible element list from the entire history.
@@ -0,0 +1,223 @@
+// Package app implements navigation analytics for Application Mode in the Universal Application Console.
+// Every focus change is recorded as a NavigationStep with the method that caused it: Tab, Shift+Tab, a number
+// key, Esc, a mouse click, or "programmatic" when the console moved focus itself. /nav-stats summarizes the
+// recorded steps, showing how often each method is used and the most common focus transitions, to help tune
+// default keybindings and focus order. /nav-stats export <file> writes the summary and the step sequence as
+// JSON; the export is anonymized, holding only focus areas, methods, and offsets from the first step, with no
+// element IDs, wall-clock times, profile, or host. Nothing is written unless an export is requested.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+const (
+	// maxNavigationHistory is the number of navigation steps kept for analysis
+	maxNavigationHistory = 200
+
+	// topTransitionCount is the number of focus transitions /nav-stats lists
+	topTransitionCount = 5
+)
+
+// focusName returns the name of a focus area as used in navigation analytics
+func focusName(focus FocusState) string {
+	switch focus {
+	case FocusInput:
+		return "input"
+	case FocusActions:
+		return "actions"
+	case FocusContent:
+		return "content"
+	case FocusExpandable:
+		return "sections"
+	case FocusTree:
+		return "tree"
+	case FocusForm:
+		return "form"
+	default:
+		return "unknown"
+	}
+}
+
+// navigate performs a user navigation and records it as a single step attributed to the given method.
+// A navigation that stays in the same focus area, such as a number key between sections, is still recorded.
+func (m *AppModel) navigate(method string, move func()) {
+	from := m.focusState
+	m.navigationMethod = method
+	move()
+	if m.navigationMethod != "" {
+		m.recordNavigation(from, m.focusState, method)
+	}
+	m.navigationMethod = ""
+}
+
+// recordNavigation records a navigation step for user experience analysis
+func (m *AppModel) recordNavigation(fromFocus, toFocus FocusState, method string) {
+	step := NavigationStep{
+		Timestamp: time.Now(),
+		FromFocus: fromFocus,
+		ToFocus:   toFocus,
+		Method:    method,
+	}
+
+	m.navigationHistory = append(m.navigationHistory, step)
+
+	// Limit navigation history size
+	if len(m.navigationHistory) > maxNavigationHistory {
+		m.navigationHistory = m.navigationHistory[1:]
+	}
+}
+
+// navigationTransition counts the steps between two focus areas
+type navigationTransition struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int    `json:"count"`
+}
+
+// anonymizedStep is a navigation step without identifying details, as exported
+type anonymizedStep struct {
+	OffsetMs int64  `json:"offsetMs"` // Time since the first recorded step
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Method   string `json:"method"`
+}
+
+// navigationStats summarizes recorded navigation steps
+type navigationStats struct {
+	Steps       int                    `json:"steps"`
+	Methods     map[string]int         `json:"methods"`
+	Transitions []navigationTransition `json:"transitions"`
+	Sequence    []anonymizedStep       `json:"sequence,omitempty"`
+}
+
+// summarizeNavigation counts steps by method and by transition, most common transitions first
+func summarizeNavigation(steps []NavigationStep) navigationStats {
+	stats := navigationStats{
+		Steps:   len(steps),
+		Methods: make(map[string]int),
+	}
+
+	counts := make(map[[2]FocusState]int)
+	for _, step := range steps {
+		stats.Methods[step.Method]++
+		counts[[2]FocusState{step.FromFocus, step.ToFocus}]++
+	}
+
+	for key, count := range counts {
+		stats.Transitions = append(stats.Transitions, navigationTransition{
+			From:  focusName(key[0]),
+			To:    focusName(key[1]),
+			Count: count,
+		})
+	}
+	sort.Slice(stats.Transitions, func(i, j int) bool {
+		a, b := stats.Transitions[i], stats.Transitions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	return stats
+}
+
+// navStats handles /nav-stats, showing the navigation summary or exporting it with "export <file>"
+func (m *AppModel) navStats(args []string) tea.Cmd {
+	if len(args) > 0 {
+		if args[0] != "export" || len(args) < 2 {
+			return m.showError("Usage: /nav-stats [export <file>]")
+		}
+		return m.exportNavStats(strings.Join(args[1:], " "))
+	}
+
+	stats := summarizeNavigation(m.navigationHistory)
+
+	lines := []string{fmt.Sprintf("--- Navigation (%d steps) ---", stats.Steps)}
+	if stats.Steps == 0 {
+		lines = append(lines, "No navigation recorded yet")
+	} else {
+		methods := make([]string, 0, len(stats.Methods))
+		for method := range stats.Methods {
+			methods = append(methods, method)
+		}
+		sort.Slice(methods, func(i, j int) bool {
+			if stats.Methods[methods[i]] != stats.Methods[methods[j]] {
+				return stats.Methods[methods[i]] > stats.Methods[methods[j]]
+			}
+			return methods[i] < methods[j]
+		})
+
+		lines = append(lines, "", "Methods:")
+		for _, method := range methods {
+			count := stats.Methods[method]
+			lines = append(lines, fmt.Sprintf("  %-12s %4d  %3.0f%%", method, count, float64(count)*100/float64(stats.Steps)))
+		}
+
+		lines = append(lines, "", "Most common transitions:")
+		for i, transition := range stats.Transitions {
+			if i == topTransitionCount {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %-21s %4d", transition.From+" → "+transition.To, transition.Count))
+		}
+	}
+	lines = append(lines, "", "Export an anonymized copy with /nav-stats export <file>")
+	statsText := strings.Join(lines, "\n")
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/nav-stats",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: statsText,
+				},
+			},
+			success: true,
+		}
+	})
+}
+
+// exportNavStats writes the anonymized navigation summary and step sequence to a JSON file
+func (m *AppModel) exportNavStats(path string) tea.Cmd {
+	if len(m.navigationHistory) == 0 {
+		return m.showError("No navigation recorded yet")
+	}
+
+	stats := summarizeNavigation(m.navigationHistory)
+	start := m.navigationHistory[0].Timestamp
+	for _, step := range m.navigationHistory {
+		stats.Sequence = append(stats.Sequence, anonymizedStep{
+			OffsetMs: step.Timestamp.Sub(start).Milliseconds(),
+			From:     focusName(step.FromFocus),
+			To:       focusName(step.ToFocus),
+			Method:   step.Method,
+		})
+	}
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to encode navigation statistics: %v", err))
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return m.showError(fmt.Sprintf("Failed to export navigation statistics: %v", err))
+	}
+
+	m.statusMessage = fmt.Sprintf("Exported %d navigation steps to %s", stats.Steps, path)
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package app implements lazy fetching of paginated lists for Application Mode in the Universal Application Console.
+// When the latest response contains a list with a continuation token, a synthetic "Show more" action is added
+// to the Actions Pane for it. Selecting the action sends the list's next-page command to the application with
+// the token in the action context, and the returned page is appended to the list already in history.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// showMoreCommandPrefix marks synthetic "Show more" actions; the list's content ID follows it
+const showMoreCommandPrefix = "internal_show_more:"
+
+// listPageFetchedMsg carries the next page of a paginated list
+type listPageFetchedMsg struct {
+	listID   string
+	response *interfaces.CommandResponse
+	error    string
+}
+
+// refreshShowMoreActions shows the current response's actions followed by a "Show more" action for
+// each paginated list in the latest history entry, and an "Expand full (heavy)" action when the entry
+// was truncated by the complexity budget
+func (m *AppModel) refreshShowMoreActions() {
+	var actions []interfaces.Action
+	if m.currentResponse != nil {
+		actions = append(actions, m.currentResponse.Actions...)
+	}
+
+	if len(m.commandHistory) > 0 {
+		latest := m.commandHistory[len(m.commandHistory)-1]
+		for _, content := range latest.Rendered {
+			if content.Truncated {
+				actions = append(actions, expandFullAction(latest.seq))
+			}
+			if content.NextPage == nil {
+				continue
+			}
+			actions = append(actions, interfaces.Action{
+				Name:    "Show more",
+				Command: showMoreCommandPrefix + content.ID,
+				Type:    "info",
+				Icon:    "⏬",
+			})
+		}
+	}
+
+	m.actionsPane.SetActions(actions)
+}
+
+// findContentEntry returns the history entry and rendered content with a content ID
+func (m *AppModel) findContentEntry(contentID string) (*HistoryEntry, *interfaces.RenderedContent) {
+	for i := range m.commandHistory {
+		for j := range m.commandHistory[i].Rendered {
+			if m.commandHistory[i].Rendered[j].ID == contentID {
+				return &m.commandHistory[i], &m.commandHistory[i].Rendered[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// fetchNextListPage requests the next page of a list. Lists that do not name a next-page command
+// repeat the command that produced them.
+func (m *AppModel) fetchNextListPage(listID string) tea.Cmd {
+	entry, list := m.findContentEntry(listID)
+	if list == nil || list.NextPage == nil {
+		return m.showError("This list has no more items to load")
+	}
+
+	command := list.NextPage.Command
+	if command == "" {
+		if strings.HasPrefix(entry.Command, "[Action] ") {
+			return m.showError("The application did not say how to load more items for this list")
+		}
+		command = entry.Command
+	}
+
+	request := interfaces.ActionRequest{
+		Command: command,
+		Context: m.withVariables(map[string]interface{}{
+			"nextToken": list.NextPage.Token,
+			"listId":    listID,
+		}),
+	}
+
+	m.statusMessage = "Loading more items..."
+
+	return tea.Cmd(func() tea.Msg {
+		ctx, done := m.beginRequest("action", "Show more: "+listID, m.commandTimeout())
+		defer done()
+
+		response, err := m.protocolClient.ExecuteAction(ctx, request)
+
+		if err != nil {
+			message := err.Error()
+			if protoErr, ok := err.(*protocol.ProtocolError); ok && protoErr.HTTPDetails != nil && protoErr.HTTPDetails.Body != "" {
+				var structuredErr interfaces.ErrorResponse
+				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil && structuredErr.Error.Message != "" {
+					message = structuredErr.Error.Message
+				}
+			}
+			return listPageFetchedMsg{listID: listID, error: message}
+		}
+
+		return listPageFetchedMsg{listID: listID, response: response}
+	})
+}
+
+// handleListPageFetched appends a fetched page to its list and updates the "Show more" actions
+func (m *AppModel) handleListPageFetched(msg listPageFetchedMsg) tea.Cmd {
+	if msg.error != "" {
+		return m.showError(fmt.Sprintf("Failed to load more items: %s", msg.error))
+	}
+
+	_, list := m.findContentEntry(msg.listID)
+	if list == nil {
+		return m.showError("The list is no longer in history")
+	}
+
+	rendered, err := m.contentRenderer.AppendListPage(msg.listID, msg.response.Response.Content)
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to load more items: %v", err))
+	}
+
+	*list = *rendered
+	m.invalidateHistoryBuffer()
+	m.refreshShowMoreActions()
+
+	m.statusMessage = "Loaded more items"
+	if rendered.NextPage == nil {
+		m.statusMessage = "Loaded the last page"
+	}
+
+	if m.following() {
+		return m.scrollToBottom()
+	}
+	return nil
+}
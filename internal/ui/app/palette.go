@@ -0,0 +1,165 @@
+// Package app implements the command palette for Application Mode in the Universal Application Console.
+// Ctrl+P opens a list of every meta command with its description, filtered as the user types. Enter runs
+// the highlighted command; commands that require arguments are placed in the input field to be completed.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPaletteRows limits how many matching commands the palette shows at once
+const maxPaletteRows = 8
+
+// commandPalette holds the state of an open command palette
+type commandPalette struct {
+	query    string
+	matches  []int // Indexes into the registry's commands
+	selected int   // Index into matches
+}
+
+var (
+	paletteStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#89B4FA")).
+			Padding(0, 1)
+
+	paletteTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#89B4FA"))
+
+	paletteSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#89B4FA"))
+
+	paletteDescriptionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#6C7086"))
+)
+
+// openCommandPalette shows the palette with every command listed
+func (m *AppModel) openCommandPalette() tea.Cmd {
+	m.palette = &commandPalette{}
+	m.filterCommandPalette()
+	return nil
+}
+
+// filterCommandPalette matches commands whose names, aliases, or descriptions contain the query.
+// Commands whose names start with the query are listed first.
+func (m *AppModel) filterCommandPalette() {
+	query := strings.ToLower(strings.TrimPrefix(m.palette.query, "/"))
+
+	var prefixMatches, otherMatches []int
+	commands := m.metaCommands.Commands()
+	for i := range commands {
+		command := &commands[i]
+		name := strings.ToLower(strings.TrimPrefix(command.Name, "/"))
+		switch {
+		case strings.HasPrefix(name, query):
+			prefixMatches = append(prefixMatches, i)
+		case strings.Contains(strings.ToLower(command.Synopsis()+" "+command.Description), query):
+			otherMatches = append(otherMatches, i)
+		}
+	}
+
+	m.palette.matches = append(prefixMatches, otherMatches...)
+	m.palette.selected = 0
+}
+
+// handlePaletteKeys processes keyboard input while the palette is open
+func (m *AppModel) handlePaletteKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlP:
+		m.palette = nil
+		return nil
+
+	case tea.KeyEnter:
+		return m.runPaletteSelection()
+
+	case tea.KeyUp, tea.KeyShiftTab:
+		if m.palette.selected > 0 {
+			m.palette.selected--
+		}
+		return nil
+
+	case tea.KeyDown, tea.KeyTab:
+		if m.palette.selected < len(m.palette.matches)-1 {
+			m.palette.selected++
+		}
+		return nil
+
+	case tea.KeyBackspace:
+		if query := []rune(m.palette.query); len(query) > 0 {
+			m.palette.query = string(query[:len(query)-1])
+			m.filterCommandPalette()
+		}
+		return nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.palette.query += string(msg.Runes)
+		m.filterCommandPalette()
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// runPaletteSelection closes the palette and runs the highlighted command, or places it in the
+// input field when it needs arguments
+func (m *AppModel) runPaletteSelection() tea.Cmd {
+	palette := m.palette
+	m.palette = nil
+	if len(palette.matches) == 0 {
+		return nil
+	}
+
+	command := m.metaCommands.Commands()[palette.matches[palette.selected]]
+	if command.MinArgs > 0 {
+		m.SetFocus(FocusInput)
+		m.commandInput.SetValue(command.Name + " ")
+		m.commandInput.CursorEnd()
+		m.statusMessage = fmt.Sprintf("Usage: %s %s", command.Name, command.Usage)
+		return nil
+	}
+
+	return m.ExecuteCommand(command.Name)
+}
+
+// renderCommandPalette creates the palette listing, scrolled to keep the highlighted command visible
+func (m *AppModel) renderCommandPalette() string {
+	palette := m.palette
+	lines := []string{paletteTitleStyle.Render("Command Palette") + "  > " + palette.query + "█"}
+
+	if len(palette.matches) == 0 {
+		lines = append(lines, paletteDescriptionStyle.Render("No matching commands"))
+	}
+
+	start := 0
+	if palette.selected >= maxPaletteRows {
+		start = palette.selected - maxPaletteRows + 1
+	}
+	end := start + maxPaletteRows
+	if end > len(palette.matches) {
+		end = len(palette.matches)
+	}
+
+	for i := start; i < end; i++ {
+		command := &m.metaCommands.Commands()[palette.matches[i]]
+		line := fmt.Sprintf("%-20s %s", command.Synopsis(), paletteDescriptionStyle.Render(command.Description))
+		if i == palette.selected {
+			line = paletteSelectedStyle.Render(fmt.Sprintf("%-20s %s", command.Synopsis(), command.Description))
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "↑/↓ select  Enter run  Esc close")
+
+	width := m.terminalWidth - 2
+	if width < 20 {
+		width = 20
+	}
+	return paletteStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
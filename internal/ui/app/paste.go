@@ -0,0 +1,83 @@
+// Package app implements pasting into the command input for Application Mode in the Universal Application
+// Console. Ctrl+V reads the system clipboard and inserts its text at the cursor, from any focus area, and
+// bracketed pastes from the terminal are inserted the same way. Pasted text is cleaned before it reaches the
+// input: escape sequences and control characters are removed, and multi-line text is flattened into a single
+// command by joining its non-blank lines with spaces.
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// clipboardPasteMsg carries the clipboard text read for a paste.
+// This is an internal message and remains UNEXPORTED.
+type clipboardPasteMsg struct {
+	text string
+	err  error
+}
+
+// pasteFromClipboard reads the system clipboard without blocking the UI
+func (m *AppModel) pasteFromClipboard() tea.Cmd {
+	return func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		return clipboardPasteMsg{text: text, err: err}
+	}
+}
+
+// handleClipboardPaste inserts the clipboard text, or reports why the clipboard could not be read
+func (m *AppModel) handleClipboardPaste(msg clipboardPasteMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.showError(fmt.Sprintf("Failed to read the clipboard: %v", msg.err))
+	}
+	return m.insertPastedText(msg.text)
+}
+
+// insertPastedText cleans pasted text and inserts it at the cursor, moving focus to the input
+func (m *AppModel) insertPastedText(text string) tea.Cmd {
+	pasted, lines := flattenPastedText(text)
+	if pasted == "" {
+		m.statusMessage = "Nothing to paste"
+		return nil
+	}
+
+	if m.focusState != FocusInput {
+		m.SetFocus(FocusInput)
+	}
+
+	value := []rune(m.commandInput.Value())
+	position := m.commandInput.Position()
+	m.commandInput.SetValue(string(value[:position]) + pasted + string(value[position:]))
+	m.commandInput.SetCursor(position + len([]rune(pasted)))
+
+	if lines > 1 {
+		m.statusMessage = fmt.Sprintf("Pasted %d lines as a single command", lines)
+	}
+	return m.inputChanged()
+}
+
+// flattenPastedText removes escape sequences and control characters from pasted text and joins its
+// non-blank lines with spaces, returning the result and the number of lines joined
+func flattenPastedText(text string) (string, int) {
+	text = ansi.Strip(text)
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", " ").Replace(text)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, line)
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " "), len(lines)
+}
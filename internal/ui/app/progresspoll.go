@@ -0,0 +1,268 @@
+// Package app implements polling of long-running operations for Application Mode in the Universal Application
+// Console. When the application advertises "progress", each progress block that names a running operation is
+// kept up to date by polling the application with GetProgress. The interval of each operation adapts to it:
+// operations near completion or advancing quickly are polled sooner, and operations that have not moved are
+// polled less and less often. Operations that are due at the same time are coalesced into one batched request
+// when the application also advertises "progress-batch", and polled one request at a time otherwise. However
+// many operations are running, requests are spaced to stay under the profile's progressPollRate. An operation
+// stops being polled once it completes or fails, or once its history entry is gone.
+package app
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+const (
+	// progressPollInterval is how often a running operation is polled before its pace is known
+	progressPollInterval = time.Second
+
+	// minProgressPollInterval and maxProgressPollInterval bound how far an operation's interval adapts
+	minProgressPollInterval = 250 * time.Millisecond
+	maxProgressPollInterval = 10 * time.Second
+
+	// defaultProgressPollRate is the most progress requests sent per second when the profile sets no rate
+	defaultProgressPollRate = 2.0
+
+	// progressPollTimeout bounds a progress request; the next poll asks again
+	progressPollTimeout = 5 * time.Second
+
+	// progressNearCompletion is the progress from which an operation counts as nearly complete
+	progressNearCompletion = 90
+
+	// progressFastRate is the progress per second from which an operation counts as advancing quickly
+	progressFastRate = 5.0
+)
+
+// progressOperation is a running operation whose progress is polled
+type progressOperation struct {
+	id       string
+	seq      uint64        // Sequence number of the history entry showing the operation
+	progress int           // Progress at the latest poll
+	polled   time.Time     // When the operation was last polled; zero before the first poll
+	interval time.Duration // Wait until the next poll, adapted to the operation's pace
+	due      time.Time     // When the operation is next polled
+}
+
+// progressPollTickMsg sends the next progress request unless a later schedule has superseded it
+type progressPollTickMsg struct {
+	generation int
+}
+
+// progressPolledMsg carries the result of a progress request for one or more operations
+type progressPolledMsg struct {
+	ids      []string
+	response *interfaces.ProgressResponse
+	err      error
+}
+
+// progressPollRate returns the most progress requests the profile allows per second
+func (m *AppModel) progressPollRate() float64 {
+	if m.profile.ProgressPollRate > 0 {
+		return m.profile.ProgressPollRate
+	}
+	return defaultProgressPollRate
+}
+
+// trackProgressOperations starts polling the running operations named by a response's progress blocks
+func (m *AppModel) trackProgressOperations(seq uint64, response *interfaces.CommandResponse) tea.Cmd {
+	if !m.hasFeature(FeatureProgress) || response == nil {
+		return nil
+	}
+
+	now := time.Now()
+	added := false
+	for _, id := range m.contentRenderer.ProgressOperations(response.Response.Content) {
+		if _, tracked := m.progressOperations[id]; tracked {
+			m.progressOperations[id].seq = seq
+			continue
+		}
+		m.progressOperations[id] = &progressOperation{
+			id:       id,
+			seq:      seq,
+			interval: progressPollInterval,
+			due:      now.Add(progressPollInterval),
+		}
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	return m.scheduleProgressPoll()
+}
+
+// scheduleProgressPoll schedules the next progress request for when an operation is due, no sooner than the
+// profile's poll rate allows. A request in flight schedules the next one when it finishes.
+func (m *AppModel) scheduleProgressPoll() tea.Cmd {
+	if m.progressPollInFlight || len(m.progressOperations) == 0 {
+		return nil
+	}
+
+	m.progressPollGeneration++
+	generation := m.progressPollGeneration
+	return tea.Tick(time.Until(m.nextProgressPoll()), func(time.Time) tea.Msg {
+		return progressPollTickMsg{generation: generation}
+	})
+}
+
+// nextProgressPoll returns when the next progress request is sent: when the first operation is due, or once
+// the poll rate allows another request after the latest one if that is later
+func (m *AppModel) nextProgressPoll() time.Time {
+	var next time.Time
+	for _, operation := range m.progressOperations {
+		if next.IsZero() || operation.due.Before(next) {
+			next = operation.due
+		}
+	}
+	spacing := time.Duration(float64(time.Second) / m.progressPollRate())
+	if earliest := m.lastProgressPoll.Add(spacing); next.Before(earliest) {
+		next = earliest
+	}
+	return next
+}
+
+// handleProgressPollTick polls the operations that are due: all of them in one request when the application
+// accepts batches, otherwise the one that has waited longest
+func (m *AppModel) handleProgressPollTick(msg progressPollTickMsg) tea.Cmd {
+	if msg.generation != m.progressPollGeneration || m.progressPollInFlight || !m.connected {
+		return nil
+	}
+
+	now := time.Now()
+	var due []*progressOperation
+	for _, operation := range m.progressOperations {
+		if !operation.due.After(now) {
+			due = append(due, operation)
+		}
+	}
+	if len(due) == 0 {
+		return m.scheduleProgressPoll()
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].due.Before(due[j].due) })
+	if !m.hasFeature(FeatureProgressBatch) {
+		due = due[:1]
+	}
+
+	ids := make([]string, len(due))
+	for i, operation := range due {
+		ids[i] = operation.id
+	}
+	m.progressPollInFlight = true
+	m.lastProgressPoll = now
+	return m.pollProgress(ids)
+}
+
+// pollProgress requests the progress of the operations, the first by OperationID and the rest by OperationIDs
+func (m *AppModel) pollProgress(ids []string) tea.Cmd {
+	request := interfaces.ProgressRequest{OperationID: ids[0], RequestUpdate: true}
+	if len(ids) > 1 {
+		request.OperationIDs = ids[1:]
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.requestContext, progressPollTimeout)
+		defer cancel()
+
+		response, err := m.protocolClient.GetProgress(ctx, request)
+		return progressPolledMsg{ids: ids, response: response, err: err}
+	}
+}
+
+// handleProgressPolled records the polled progress of each operation, re-renders the history entries showing
+// them, and schedules the next request
+func (m *AppModel) handleProgressPolled(msg progressPolledMsg) tea.Cmd {
+	m.progressPollInFlight = false
+
+	now := time.Now()
+	changed := make(map[uint64]bool)
+	for i, id := range msg.ids {
+		operation, tracked := m.progressOperations[id]
+		if !tracked {
+			continue
+		}
+
+		var progress *interfaces.ProgressResponse
+		if msg.err == nil && msg.response != nil {
+			if i == 0 {
+				progress = msg.response
+			} else if reported, ok := msg.response.Operations[id]; ok {
+				progress = &reported
+			}
+		}
+		if progress == nil {
+			// Failed or unreported polls back off, so that an unavailable application is not hammered
+			operation.interval = clampProgressInterval(operation.interval * 2)
+			operation.due = now.Add(operation.interval)
+			continue
+		}
+
+		m.contentRenderer.TrackProgress(id, progress)
+		changed[operation.seq] = true
+		if progress.Status == "complete" || progress.Status == "error" {
+			delete(m.progressOperations, id)
+			continue
+		}
+		operation.adapt(progress.Progress, now)
+	}
+
+	var commands []tea.Cmd
+	for seq := range changed {
+		entry := m.historyEntryBySequence(seq)
+		if entry == nil || entry.Response == nil {
+			m.forgetProgressOperations(seq)
+			continue
+		}
+		commands = append(commands, m.renderResponseContent(seq, entry.Response))
+	}
+	return tea.Batch(append(commands, m.scheduleProgressPoll())...)
+}
+
+// adapt sets the operation's next poll from its pace since the last one: sooner when it is nearly complete or
+// advancing quickly, later when it has not moved, and at the default interval otherwise. Its pace is unknown
+// until it has been polled twice.
+func (op *progressOperation) adapt(progress int, now time.Time) {
+	first := op.polled.IsZero()
+	advanced := progress - op.progress
+	elapsed := now.Sub(op.polled).Seconds()
+
+	switch {
+	case progress >= progressNearCompletion:
+		op.interval = clampProgressInterval(op.interval / 2)
+	case first:
+		op.interval = progressPollInterval
+	case elapsed > 0 && float64(advanced)/elapsed >= progressFastRate:
+		op.interval = clampProgressInterval(op.interval / 2)
+	case advanced <= 0:
+		op.interval = clampProgressInterval(op.interval * 2)
+	default:
+		op.interval = progressPollInterval
+	}
+
+	op.progress = progress
+	op.polled = now
+	op.due = now.Add(op.interval)
+}
+
+// clampProgressInterval keeps a poll interval within the bounds operations adapt between
+func clampProgressInterval(interval time.Duration) time.Duration {
+	if interval < minProgressPollInterval {
+		return minProgressPollInterval
+	}
+	if interval > maxProgressPollInterval {
+		return maxProgressPollInterval
+	}
+	return interval
+}
+
+// forgetProgressOperations stops polling the operations shown by a history entry that no longer exists
+func (m *AppModel) forgetProgressOperations(seq uint64) {
+	for id, operation := range m.progressOperations {
+		if operation.seq == seq {
+			delete(m.progressOperations, id)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// progressClient records progress requests and answers each with the configured progress
+type progressClient struct {
+	interfaces.ProtocolClient
+	requests []interfaces.ProgressRequest
+	progress map[string]interfaces.ProgressResponse
+}
+
+func (c *progressClient) GetProgress(ctx context.Context, request interfaces.ProgressRequest) (*interfaces.ProgressResponse, error) {
+	c.requests = append(c.requests, request)
+	response := c.progress[request.OperationID]
+	if len(request.OperationIDs) > 0 {
+		response.Operations = make(map[string]interfaces.ProgressResponse)
+		for _, id := range request.OperationIDs {
+			response.Operations[id] = c.progress[id]
+		}
+	}
+	return &response, nil
+}
+
+// progressResponse returns a structured response with a progress block for each operation and status
+func progressResponse(statuses map[string]string) *interfaces.CommandResponse {
+	response := &interfaces.CommandResponse{}
+	response.Response.Type = "structured"
+	var blocks []interfaces.ContentBlock
+	for id, status := range statuses {
+		blocks = append(blocks, interfaces.ContentBlock{
+			Type:    "progress",
+			Content: map[string]interface{}{"label": id, "progress": 10, "status": status, "operationId": id, "showPercent": true},
+		})
+	}
+	response.Response.Content = blocks
+	return response
+}
+
+// newProgressModel returns a model advertising the given features, polling through a progressClient
+func newProgressModel(t *testing.T, features ...string) (*AppModel, *progressClient) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	client := &progressClient{ProtocolClient: m.protocolClient, progress: make(map[string]interfaces.ProgressResponse)}
+	m.protocolClient = client
+	m.features = map[string]bool{}
+	for _, feature := range features {
+		m.features[feature] = true
+	}
+	return m, client
+}
+
+// pollDue polls every tracked operation at once, as if they had all fallen due
+func pollDue(t *testing.T, m *AppModel) progressPolledMsg {
+	t.Helper()
+	for _, operation := range m.progressOperations {
+		operation.due = time.Now().Add(-time.Second)
+	}
+	cmd := m.handleProgressPollTick(progressPollTickMsg{generation: m.progressPollGeneration})
+	if cmd == nil {
+		t.Fatal("no progress request was sent for due operations")
+	}
+	msg, ok := cmd().(progressPolledMsg)
+	if !ok {
+		t.Fatal("the progress request did not return a progressPolledMsg")
+	}
+	return msg
+}
+
+func TestRunningOperationsAreTracked(t *testing.T) {
+	m, _ := newProgressModel(t, FeatureProgress)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{
+		"build": "running", "upload": "running", "lint": "complete",
+	})})
+
+	if cmd := m.trackProgressOperations(entry.seq, entry.Response); cmd == nil {
+		t.Fatal("tracking running operations scheduled no poll")
+	}
+	if len(m.progressOperations) != 2 || m.progressOperations["build"] == nil || m.progressOperations["upload"] == nil {
+		t.Errorf("tracked operations = %v, want build and upload", m.progressOperations)
+	}
+}
+
+func TestOperationsAreNotTrackedWithoutProgressFeature(t *testing.T) {
+	m, _ := newProgressModel(t)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{"build": "running"})})
+
+	if cmd := m.trackProgressOperations(entry.seq, entry.Response); cmd != nil || len(m.progressOperations) != 0 {
+		t.Errorf("operations were tracked although the application does not advertise progress")
+	}
+}
+
+func TestDueOperationsAreBatched(t *testing.T) {
+	m, client := newProgressModel(t, FeatureProgress, FeatureProgressBatch)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{
+		"build": "running", "upload": "running", "test": "running",
+	})})
+	m.trackProgressOperations(entry.seq, entry.Response)
+
+	msg := pollDue(t, m)
+	if len(client.requests) != 1 {
+		t.Fatalf("sent %d progress requests, want 1", len(client.requests))
+	}
+	ids := append([]string{client.requests[0].OperationID}, client.requests[0].OperationIDs...)
+	sort.Strings(ids)
+	if want := []string{"build", "test", "upload"}; len(ids) != 3 || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("batched operations = %v, want %v", ids, want)
+	}
+	if len(msg.ids) != 3 {
+		t.Errorf("polled ids = %v, want all three", msg.ids)
+	}
+}
+
+func TestDueOperationsArePolledSinglyWithoutBatching(t *testing.T) {
+	m, client := newProgressModel(t, FeatureProgress)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{
+		"build": "running", "upload": "running",
+	})})
+	m.trackProgressOperations(entry.seq, entry.Response)
+
+	pollDue(t, m)
+	if len(client.requests) != 1 || len(client.requests[0].OperationIDs) != 0 {
+		t.Errorf("requests = %+v, want one request for a single operation", client.requests)
+	}
+}
+
+func TestFinishedOperationsStopBeingPolled(t *testing.T) {
+	m, client := newProgressModel(t, FeatureProgress, FeatureProgressBatch)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{
+		"build": "running", "upload": "running",
+	})})
+	m.trackProgressOperations(entry.seq, entry.Response)
+	client.progress["build"] = interfaces.ProgressResponse{Progress: 100, Status: "complete"}
+	client.progress["upload"] = interfaces.ProgressResponse{Progress: 40, Status: "running"}
+
+	m.handleProgressPolled(pollDue(t, m))
+	if _, tracked := m.progressOperations["build"]; tracked {
+		t.Error("a completed operation is still polled")
+	}
+	if operation := m.progressOperations["upload"]; operation == nil || operation.progress != 40 {
+		t.Errorf("upload = %+v, want it polled at 40%%", operation)
+	}
+
+	// The history entry shows the polled progress, so only the running operation is left in it
+	if ids := m.contentRenderer.ProgressOperations(entry.Response.Response.Content); len(ids) != 1 || ids[0] != "upload" {
+		t.Errorf("running operations of the entry = %v, want [upload]", ids)
+	}
+}
+
+func TestPollIntervalAdaptsToPace(t *testing.T) {
+	start := time.Now()
+	polled := func(progress int) *progressOperation {
+		return &progressOperation{progress: progress, polled: start, interval: progressPollInterval}
+	}
+
+	stalled := polled(30)
+	stalled.adapt(30, start.Add(time.Second))
+	if stalled.interval != 2*progressPollInterval {
+		t.Errorf("a stalled operation is polled every %s, want %s", stalled.interval, 2*progressPollInterval)
+	}
+
+	fast := polled(30)
+	fast.adapt(50, start.Add(time.Second))
+	if fast.interval != progressPollInterval/2 {
+		t.Errorf("a fast operation is polled every %s, want %s", fast.interval, progressPollInterval/2)
+	}
+
+	steady := polled(30)
+	steady.adapt(32, start.Add(time.Second))
+	if steady.interval != progressPollInterval {
+		t.Errorf("a steady operation is polled every %s, want %s", steady.interval, progressPollInterval)
+	}
+
+	nearlyDone := polled(90)
+	nearlyDone.adapt(91, start.Add(time.Second))
+	if nearlyDone.interval != progressPollInterval/2 {
+		t.Errorf("a nearly complete operation is polled every %s, want %s", nearlyDone.interval, progressPollInterval/2)
+	}
+
+	idle := &progressOperation{progress: 30, polled: start, interval: maxProgressPollInterval}
+	idle.adapt(30, start.Add(time.Second))
+	if idle.interval != maxProgressPollInterval {
+		t.Errorf("an idle operation is polled every %s, want at most %s", idle.interval, maxProgressPollInterval)
+	}
+}
+
+func TestPollRateCapsRequests(t *testing.T) {
+	m, _ := newProgressModel(t, FeatureProgress)
+	m.profile.ProgressPollRate = 0.5
+
+	now := time.Now()
+	m.lastProgressPoll = now
+	m.progressOperations["build"] = &progressOperation{id: "build", due: now}
+
+	if next := m.nextProgressPoll(); next.Before(now.Add(2 * time.Second)) {
+		t.Errorf("next poll is %s after the last, want at least 2s at 0.5 polls per second", next.Sub(now))
+	}
+
+	m.profile.ProgressPollRate = 0
+	if next := m.nextProgressPoll(); next.Before(now.Add(time.Duration(float64(time.Second) / defaultProgressPollRate))) {
+		t.Errorf("next poll is %s after the last, want the default rate", next.Sub(now))
+	}
+}
@@ -0,0 +1,107 @@
+// Package app implements messages pushed by the application for Application Mode in the Universal Application
+// Console. Over the WebSocket transport the application may send messages without a request: "progress"
+// messages carry the progress of a running operation, named by "operationId", and "content" messages carry a
+// response streamed on the application's own initiative. The client's read loop hands each message to the
+// update loop through a buffered channel; pushed progress updates the progress blocks naming the operation
+// just as a poll would, and pushed content is added to the history like a command's response.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// pushBufferSize is how many pushed messages wait for the update loop before further ones are dropped
+const pushBufferSize = 64
+
+// pushedMsg is a message the application sent without a request
+type pushedMsg struct {
+	messageType string
+	payload     json.RawMessage
+}
+
+// pushedProgress is the payload of a "progress" push
+type pushedProgress struct {
+	OperationID string `json:"operationId"`
+	interfaces.ProgressResponse
+}
+
+// receivePush queues a pushed message for the update loop. It runs on the client's read loop, so it never
+// blocks; when the update loop has fallen behind, the message is dropped, and progress is polled again.
+func (m *AppModel) receivePush(messageType string, payload json.RawMessage) {
+	select {
+	case m.pushes <- pushedMsg{messageType: messageType, payload: payload}:
+	default:
+	}
+}
+
+// waitForPush delivers the next pushed message to the update loop
+func (m *AppModel) waitForPush() tea.Cmd {
+	pushes, done := m.pushes, m.requestContext.Done()
+	return func() tea.Msg {
+		select {
+		case msg := <-pushes:
+			return msg
+		case <-done:
+			return nil
+		}
+	}
+}
+
+// handlePush applies a pushed message and waits for the next one
+func (m *AppModel) handlePush(msg pushedMsg) tea.Cmd {
+	var cmd tea.Cmd
+	switch msg.messageType {
+	case "progress":
+		cmd = m.handlePushedProgress(msg.payload)
+	case "content":
+		cmd = m.handlePushedContent(msg.payload)
+	}
+	return tea.Batch(cmd, m.waitForPush())
+}
+
+// handlePushedProgress records an operation's pushed progress and re-renders the history entry showing it
+func (m *AppModel) handlePushedProgress(payload json.RawMessage) tea.Cmd {
+	var pushed pushedProgress
+	if err := json.Unmarshal(payload, &pushed); err != nil || pushed.OperationID == "" {
+		return nil
+	}
+	m.contentRenderer.TrackProgress(pushed.OperationID, &pushed.ProgressResponse)
+
+	operation, tracked := m.progressOperations[pushed.OperationID]
+	if !tracked {
+		return nil
+	}
+	if pushed.Status == "complete" || pushed.Status == "error" {
+		delete(m.progressOperations, pushed.OperationID)
+	} else {
+		operation.adapt(pushed.Progress, time.Now())
+	}
+
+	entry := m.historyEntryBySequence(operation.seq)
+	if entry == nil || entry.Response == nil {
+		m.forgetProgressOperations(operation.seq)
+		return nil
+	}
+	return m.renderResponseContent(operation.seq, entry.Response)
+}
+
+// handlePushedContent adds a pushed response to the history
+func (m *AppModel) handlePushedContent(payload json.RawMessage) tea.Cmd {
+	var response interfaces.CommandResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		m.statusMessage = fmt.Sprintf("Ignored content pushed by the application: %v", err)
+		return nil
+	}
+
+	entry := m.addToHistory(HistoryEntry{
+		Timestamp: time.Now(),
+		Command:   "[Pushed] " + m.appName,
+		Response:  &response,
+	})
+	return tea.Batch(m.renderResponseContent(entry.seq, entry.Response), m.trackProgressOperations(entry.seq, entry.Response))
+}
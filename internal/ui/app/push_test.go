@@ -0,0 +1,80 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// pushClient keeps the push handler the model registers, so tests can push messages through it
+type pushClient struct {
+	interfaces.ProtocolClient
+	handler interfaces.PushHandler
+}
+
+func (c *pushClient) SetPushHandler(handler interfaces.PushHandler) {
+	c.handler = handler
+}
+
+// newPushModel returns a model advertising progress whose client lets tests push messages
+func newPushModel(t *testing.T) (*AppModel, *pushClient) {
+	t.Helper()
+	base := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	client := &pushClient{ProtocolClient: base.protocolClient}
+
+	m := NewAppModel(base.profile, client, base.contentRenderer, base.configManager, nil, nil)
+	m.connected = true
+	m.features = map[string]bool{FeatureProgress: true}
+	m.SetTerminalSize(100, 40)
+	if client.handler == nil {
+		t.Fatal("the model registered no push handler")
+	}
+	return m, client
+}
+
+// push sends a message through the client's push handler and delivers it to the model
+func push(t *testing.T, m *AppModel, client *pushClient, messageType, payload string) {
+	t.Helper()
+	client.handler(messageType, json.RawMessage(payload))
+
+	msg, ok := m.waitForPush()().(pushedMsg)
+	if !ok {
+		t.Fatalf("the model did not receive the pushed %s message", messageType)
+	}
+	m.Update(msg)
+}
+
+func TestPushedProgressUpdatesTheEntry(t *testing.T) {
+	m, client := newPushModel(t)
+	entry := m.addToHistory(HistoryEntry{Command: "deploy", Response: progressResponse(map[string]string{"build": "running"})})
+	m.trackProgressOperations(entry.seq, entry.Response)
+
+	push(t, m, client, "progress", `{"operationId": "build", "progress": 60, "status": "running"}`)
+	if operation := m.progressOperations["build"]; operation == nil || operation.progress != 60 {
+		t.Errorf("build = %+v, want it at the pushed 60%%", operation)
+	}
+	rendered, err := m.contentRenderer.RenderContent(entry.Response.Response.Content, nil)
+	if err != nil || len(rendered) != 1 || !strings.Contains(rendered[0].Text, "60%") {
+		t.Errorf("rendered progress = %+v, %v, want the pushed 60%%", rendered, err)
+	}
+
+	push(t, m, client, "progress", `{"operationId": "build", "progress": 100, "status": "complete"}`)
+	if _, tracked := m.progressOperations["build"]; tracked {
+		t.Error("an operation pushed as complete is still polled")
+	}
+}
+
+func TestPushedContentIsAddedToHistory(t *testing.T) {
+	m, client := newPushModel(t)
+
+	push(t, m, client, "content", `{"response": {"type": "text", "content": "Backup finished"}}`)
+	if len(m.commandHistory) != 1 {
+		t.Fatalf("history has %d entries, want the pushed one", len(m.commandHistory))
+	}
+	entry := m.commandHistory[0]
+	if !strings.HasPrefix(entry.Command, "[Pushed]") || entry.Response == nil || entry.Response.Response.Content != "Backup finished" {
+		t.Errorf("pushed entry = %+v, want the pushed response", entry)
+	}
+}
@@ -0,0 +1,142 @@
+// Package app implements command output redirection for Application Mode in the Universal Application Console.
+// This file recognizes a trailing "> file", ">> file", or "| program" on a command when the profile enables
+// shellRedirection. The command runs normally and its raw output is then written to the file or piped to the
+// program through the system shell, which takes over the terminal so interactive pagers like less work.
+// Redirection is opt-in because it gives console input shell semantics: a literal '>' or '|' in a command
+// must be quoted once it is enabled. The quotes are left in the command, since the console cannot know how
+// the application treats them.
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Redirection kinds
+const (
+	redirectWrite  = ">"
+	redirectAppend = ">>"
+	redirectPipe   = "|"
+)
+
+// outputRedirect describes where a command's output goes after it is displayed
+type outputRedirect struct {
+	kind   string
+	target string
+}
+
+// redirectFinishedMsg reports the outcome of writing or piping command output
+type redirectFinishedMsg struct {
+	redirect *outputRedirect
+	err      error
+}
+
+// parseRedirect splits a trailing redirection from a command. Operators inside single or double quotes
+// are ignored, and the quotes stay in the command. The first unquoted operator splits the input, so
+// "logs | grep error | less" hands the whole pipeline to the shell. Both the command and the target must
+// be non-empty.
+func parseRedirect(command string) (string, *outputRedirect) {
+	var quote rune
+	position, kind := -1, ""
+
+	runes := []rune(command)
+	for i := 0; i < len(runes) && position < 0; i++ {
+		switch r := runes[i]; {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '|':
+			position, kind = i, redirectPipe
+		case r == '>':
+			position, kind = i, redirectWrite
+			if i+1 < len(runes) && runes[i+1] == '>' {
+				kind = redirectAppend
+				i++
+			}
+		}
+	}
+
+	if position < 0 {
+		return command, nil
+	}
+
+	base := strings.TrimSpace(string(runes[:position]))
+	target := strings.TrimSpace(string(runes[position+len([]rune(kind)):]))
+	if base == "" || target == "" {
+		return command, nil
+	}
+
+	return base, &outputRedirect{kind: kind, target: target}
+}
+
+// applyRedirect writes or pipes a response's raw output according to the redirection
+func (m *AppModel) applyRedirect(redirect *outputRedirect, response *interfaces.CommandResponse) tea.Cmd {
+	output := transcriptContent(response)
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+
+	if redirect.kind == redirectPipe {
+		process := shellCommand(redirect.target)
+		process.Stdin = strings.NewReader(output)
+		return tea.ExecProcess(process, func(err error) tea.Msg {
+			return redirectFinishedMsg{redirect: redirect, err: err}
+		})
+	}
+
+	return func() tea.Msg {
+		return redirectFinishedMsg{redirect: redirect, err: writeRedirectFile(redirect, output)}
+	}
+}
+
+// writeRedirectFile creates, truncates, or appends to the redirection target
+func writeRedirectFile(redirect *outputRedirect, output string) error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if redirect.kind == redirectAppend {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(redirect.target, flags, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(output); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// shellCommand runs a pipeline target through the platform shell so it can contain arguments and further pipes
+func shellCommand(target string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", target)
+	}
+	return exec.Command("sh", "-c", target)
+}
+
+// handleRedirectFinished reports where redirected output went
+func (m *AppModel) handleRedirectFinished(msg redirectFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.showError(fmt.Sprintf("Redirect to %s failed: %v", msg.redirect.target, msg.err))
+	}
+
+	switch msg.redirect.kind {
+	case redirectPipe:
+		m.statusMessage = fmt.Sprintf("Output piped to %s", msg.redirect.target)
+	case redirectAppend:
+		m.statusMessage = fmt.Sprintf("Output appended to %s", msg.redirect.target)
+	default:
+		m.statusMessage = fmt.Sprintf("Output written to %s", msg.redirect.target)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package app
+
+import "testing"
+
+func TestParseRedirect(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantCommand string
+		wantKind    string
+		wantTarget  string
+	}{
+		{"logs > out.txt", "logs", redirectWrite, "out.txt"},
+		{"logs >> out.txt", "logs", redirectAppend, "out.txt"},
+		{"logs | grep error | less", "logs", redirectPipe, "grep error | less"},
+		{"echo '>' > out.txt", "echo '>'", redirectWrite, "out.txt"},
+		{`filter "a|b"`, `filter "a|b"`, "", ""},
+		{"> out.txt", "> out.txt", "", ""},
+		{"logs |", "logs |", "", ""},
+	}
+
+	for _, tt := range tests {
+		command, redirect := parseRedirect(tt.input)
+		if command != tt.wantCommand {
+			t.Errorf("parseRedirect(%q) command = %q, want %q", tt.input, command, tt.wantCommand)
+		}
+		switch {
+		case tt.wantKind == "" && redirect != nil:
+			t.Errorf("parseRedirect(%q) = %+v, want no redirection", tt.input, redirect)
+		case tt.wantKind != "" && (redirect == nil || redirect.kind != tt.wantKind || redirect.target != tt.wantTarget):
+			t.Errorf("parseRedirect(%q) = %+v, want %s %q", tt.input, redirect, tt.wantKind, tt.wantTarget)
+		}
+	}
+}
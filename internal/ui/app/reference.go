@@ -0,0 +1,70 @@
+// Package app implements fetching reference blocks on expand for Application Mode in the Universal Application Console.
+// A reference block is shown as a collapsed section with the application's summary of the content it stands
+// for. Expanding it, with Enter or its section number, fetches the content from the application through the
+// protocol client, with the session's credentials, and the response is re-rendered with the content in place
+// of the reference. /expand-all leaves references alone, so one command does not start a burst of fetches.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// referenceFetchedMsg carries the content of a reference block
+type referenceFetchedMsg struct {
+	reference interfaces.ContentReference
+	content   interface{}
+	error     string
+}
+
+// loadReference fetches the content of a reference section, unless it is already being fetched
+func (m *AppModel) loadReference(reference interfaces.ContentReference) tea.Cmd {
+	if m.pendingReferences[reference.URL] {
+		return nil
+	}
+	m.pendingReferences[reference.URL] = true
+	m.statusMessage = fmt.Sprintf("Loading %s...", reference.Summary)
+
+	return tea.Cmd(func() tea.Msg {
+		ctx, done := m.beginRequest("reference", "Load: "+reference.Summary, m.commandTimeout())
+		defer done()
+
+		content, err := m.protocolClient.FetchReference(ctx, reference.URL)
+		if err != nil {
+			message := err.Error()
+			if protoErr, ok := err.(*protocol.ProtocolError); ok && protoErr.HTTPDetails != nil && protoErr.HTTPDetails.Body != "" {
+				var structuredErr interfaces.ErrorResponse
+				if json.Unmarshal([]byte(protoErr.HTTPDetails.Body), &structuredErr) == nil && structuredErr.Error.Message != "" {
+					message = structuredErr.Error.Message
+				}
+			}
+			return referenceFetchedMsg{reference: reference, error: message}
+		}
+
+		return referenceFetchedMsg{reference: reference, content: content}
+	})
+}
+
+// handleReferenceFetched renders fetched reference content in place of every reference to it
+func (m *AppModel) handleReferenceFetched(msg referenceFetchedMsg) tea.Cmd {
+	delete(m.pendingReferences, msg.reference.URL)
+
+	if msg.error != "" {
+		return m.showError(fmt.Sprintf("Failed to load %s: %s", msg.reference.Summary, msg.error))
+	}
+	if err := m.contentRenderer.ResolveReference(msg.reference.URL, msg.content); err != nil {
+		return m.showError(fmt.Sprintf("Failed to load %s: %v", msg.reference.Summary, err))
+	}
+
+	m.reRenderHistory()
+	m.statusMessage = fmt.Sprintf("Loaded %s", msg.reference.Summary)
+
+	if m.following() {
+		return m.scrollToBottom()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Package app implements registration of the connected application for Application Mode in the Universal
+// Application Console. /register [name] adds the session's application to the registry so that it is listed
+// in the Console Menu next time. The name defaults to the application name from the handshake and the
+// profile to the session's profile; a quick connection has no saved profile, so one is saved under the
+// registered name. Replacing a registered application or an existing profile asks for confirmation first.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// registerApp handles /register, adding the connected application to the registry
+func (m *AppModel) registerApp(args []string) tea.Cmd {
+	if m.registryManager == nil {
+		return m.showError("The application registry is not available in this session")
+	}
+
+	name := strings.Join(args, " ")
+	if name == "" {
+		name = m.appName
+	}
+	if name == "" {
+		name = m.profile.Name
+	}
+
+	// A quick connection's profile only exists in this session, so it is saved under the registered name
+	var newProfile *interfaces.Profile
+	profileName := m.profile.Name
+	if _, err := m.configManager.LoadProfile(profileName); err != nil {
+		profile := *m.profile
+		profile.Name = name
+		newProfile = &profile
+		profileName = name
+	}
+
+	app := interfaces.RegisteredApp{
+		Name:    name,
+		Profile: profileName,
+	}
+
+	var replaced []string
+	if existing, err := m.registryManager.GetAppByName(name); err == nil {
+		app.AutoStart = existing.AutoStart
+		replaced = append(replaced, fmt.Sprintf("the registered application '%s' (profile '%s')", existing.Name, existing.Profile))
+	}
+	if newProfile != nil {
+		if existing, err := m.configManager.LoadProfile(newProfile.Name); err == nil {
+			replaced = append(replaced, fmt.Sprintf("the profile '%s' (host %s)", existing.Name, existing.Host))
+		}
+	}
+
+	register := func() tea.Cmd { return m.saveRegistration(app, newProfile) }
+	if len(replaced) == 0 {
+		return register()
+	}
+	return m.requestConfirmation("Replace Registration",
+		fmt.Sprintf("Replace %s? The registration will use profile '%s' for %s.", strings.Join(replaced, " and "), profileName, m.profile.Host),
+		register)
+}
+
+// saveRegistration saves a new profile, if any, and registers the application
+func (m *AppModel) saveRegistration(app interfaces.RegisteredApp, newProfile *interfaces.Profile) tea.Cmd {
+	if newProfile != nil {
+		if err := m.configManager.SaveProfile(newProfile); err != nil {
+			return m.showError(fmt.Sprintf("Failed to save profile '%s': %v", newProfile.Name, err))
+		}
+		// The session now has a saved profile, for /timestamps save and later registrations
+		m.profile.Name = newProfile.Name
+	}
+
+	if err := m.registryManager.RegisterApp(app); err != nil {
+		return m.showError(fmt.Sprintf("Failed to register '%s': %v", app.Name, err))
+	}
+
+	m.statusMessage = fmt.Sprintf("Registered '%s' with profile '%s'; it will be listed in the Console Menu", app.Name, app.Profile)
+	return nil
+}
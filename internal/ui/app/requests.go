@@ -0,0 +1,114 @@
+// Package app implements request lifetimes for Application Mode in the Universal Application Console.
+// Every request sent to the application runs under a context derived from one owned by the model, and is
+// tracked as a pending operation with its cancel function while it is in flight. Quitting, disconnecting,
+// or pressing Ctrl+C cancels the model's context, which aborts all in-flight requests at once instead of
+// leaving them running until they time out; a single request can be aborted by its operation ID.
+// Automatic retries of transient failures are shown next to the running command as they happen, and are
+// drawn from a budget shared by the session; once it is spent, failures are reported without retrying.
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/universal-console/console/internal/protocol"
+)
+
+// sessionRetryBudget is the number of automatic retries the requests of a session may make in total
+const sessionRetryBudget = 10
+
+// beginRequest derives a context with the given timeout from the model's context and records the request
+// as a pending operation. The returned function must be called when the request finishes.
+func (m *AppModel) beginRequest(operationType, description string, timeout time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithTimeout(m.requestContext, timeout)
+
+	m.pendingMutex.Lock()
+	m.nextOperationID++
+	id := fmt.Sprintf("req_%d", m.nextOperationID)
+	now := time.Now()
+	m.pendingOperations[id] = &PendingOperation{
+		ID:          id,
+		Type:        operationType,
+		StartTime:   now,
+		ExpectedEnd: now.Add(timeout),
+		Context:     map[string]interface{}{"description": description},
+		Cancelable:  true,
+		cancel:      cancel,
+	}
+	m.pendingMutex.Unlock()
+
+	ctx = protocol.WithRetryObserver(ctx, func(retry, retries int, err error, wait time.Duration) {
+		m.pendingMutex.Lock()
+		defer m.pendingMutex.Unlock()
+		if operation, exists := m.pendingOperations[id]; exists {
+			operation.retry = fmt.Sprintf("Transient error, retrying (%d/%d)…", retry, retries)
+		}
+	}, m.retryBudget)
+
+	return ctx, func() {
+		cancel()
+		m.pendingMutex.Lock()
+		delete(m.pendingOperations, id)
+		m.pendingMutex.Unlock()
+	}
+}
+
+// cancelPendingOperation aborts a single in-flight request, reporting whether it was still pending
+func (m *AppModel) cancelPendingOperation(id string) bool {
+	m.pendingMutex.Lock()
+	defer m.pendingMutex.Unlock()
+
+	operation, exists := m.pendingOperations[id]
+	if !exists || operation.cancel == nil {
+		return false
+	}
+	operation.cancel()
+	delete(m.pendingOperations, id)
+	return true
+}
+
+// CancelRequests aborts every in-flight request and any started later. It is called when the model quits or
+// disconnects, including by the console controller on Ctrl+C.
+func (m *AppModel) CancelRequests() {
+	m.cancelRequestContext()
+
+	m.pendingMutex.Lock()
+	defer m.pendingMutex.Unlock()
+
+	for id := range m.pendingOperations {
+		delete(m.pendingOperations, id)
+	}
+}
+
+// retryNotice returns the latest automatic retry of an in-flight request, or "" when none is retrying
+func (m *AppModel) retryNotice() string {
+	m.pendingMutex.Lock()
+	defer m.pendingMutex.Unlock()
+
+	var latest *PendingOperation
+	for _, operation := range m.pendingOperations {
+		if operation.retry != "" && (latest == nil || operation.StartTime.After(latest.StartTime)) {
+			latest = operation
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.retry
+}
+
+// reportRetryBudget tells the user once that the session's automatic retries are spent
+func (m *AppModel) reportRetryBudget() {
+	if m.retryBudgetShown || !m.retryBudget.Exhausted() {
+		return
+	}
+	m.retryBudgetShown = true
+	m.statusMessage = fmt.Sprintf("Automatic retries are used up for this session (%d); failures are no longer retried, press Ctrl+R to retry", sessionRetryBudget)
+}
+
+// resetRetryBudget gives a new session its full retry budget
+func (m *AppModel) resetRetryBudget() {
+	m.retryBudget.Reset(sessionRetryBudget)
+	m.retryBudgetShown = false
+}
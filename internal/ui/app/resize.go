@@ -0,0 +1,59 @@
+// Package app implements re-rendering of history after a terminal resize in the Universal Application Console.
+// Rendered history is wrapped to the content width at the time it was rendered, so tables and wrapped text
+// would keep their old layout after the window changes size. A resize schedules a reflow that re-renders
+// the history at the new width; while a window is being dragged every resize supersedes the previous one,
+// so only the last size is rendered, and entries already rendered at that width are left alone.
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// resizeDebounce is how long the terminal size must stay unchanged before history is re-rendered
+const resizeDebounce = 150 * time.Millisecond
+
+// historyReflowMsg re-renders history at the current width unless a later resize has superseded it
+type historyReflowMsg struct {
+	generation int
+}
+
+// historyPaneWidth is the width of the history pane: the terminal less a margin, capped at the profile's
+// maxContentWidth so that text stays readable on very wide terminals
+func (m *AppModel) historyPaneWidth() int {
+	width := m.terminalWidth - 4
+	if maxWidth := m.profile.MaxContentWidth; maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// historyContentWidth is the width pre-formatted content is wrapped to: the history pane, less its
+// border, padding, and the response indent
+func (m *AppModel) historyContentWidth() int {
+	return m.historyPaneWidth() - 8
+}
+
+// scheduleHistoryReflow schedules a re-render of history once resizing has settled
+func (m *AppModel) scheduleHistoryReflow() tea.Cmd {
+	m.resizeGeneration++
+	generation := m.resizeGeneration
+	return tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+		return historyReflowMsg{generation: generation}
+	})
+}
+
+// handleHistoryReflow re-renders the history entries whose content was wrapped to a different width
+func (m *AppModel) handleHistoryReflow(msg historyReflowMsg) {
+	if msg.generation != m.resizeGeneration || m.historyContentWidth() <= 0 {
+		return
+	}
+
+	for _, entry := range m.commandHistory {
+		if entry.Response != nil && entry.renderedWidth != m.historyContentWidth() {
+			m.reRenderHistoryEntries(true)
+			return
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package app implements history spillover for Application Mode in the Universal Application Console.
+// This file moves history entries beyond the in-memory cap into an append-only JSON lines session log,
+// so long sessions keep a complete transcript while memory use stays bounded. The /history and /export
+// meta commands read from the session log and memory together.
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/universal-console/console/internal/config"
+)
+
+// defaultMaxHistorySize is the number of history entries kept in memory when the profile does not set one
+const defaultMaxHistorySize = 1000
+
+// historySpill appends history entries that no longer fit in memory to a session log file
+type historySpill struct {
+	path        string
+	file        *os.File
+	count       int
+	startOffset int64 // size of the log before this session, when a configured path is reused
+	opened      bool
+}
+
+// newHistorySpill creates a spill log at the given path. The file is created on first use.
+func newHistorySpill(path string) *historySpill {
+	return &historySpill{path: path}
+}
+
+// defaultHistorySpillPath returns a per-session log path in the sessions directory next to the configuration file
+func defaultHistorySpillPath(configPath, profileName string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, profileName)
+	if name == "" {
+		name = "session"
+	}
+
+	fileName := fmt.Sprintf("%s-%s.jsonl", name, time.Now().Format("20060102-150405"))
+	return filepath.Join(filepath.Dir(configPath), "sessions", fileName)
+}
+
+// Append writes an entry to the session log as a single JSON line
+func (hs *historySpill) Append(entry HistoryEntry) error {
+	if hs.file == nil {
+		if err := config.MakePrivateDir(filepath.Dir(hs.path)); err != nil {
+			return fmt.Errorf("failed to create session log directory: %w", err)
+		}
+
+		file, err := config.OpenPrivateFile(hs.path)
+		if err != nil {
+			return fmt.Errorf("failed to open session log: %w", err)
+		}
+		hs.file = file
+
+		// Only entries written by this session are read back
+		if !hs.opened {
+			if info, err := file.Stat(); err == nil {
+				hs.startOffset = info.Size()
+			}
+			hs.opened = true
+		}
+	}
+
+	// Rendered content is terminal-specific and can be regenerated from the response
+	entry.Rendered = nil
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	if _, err := hs.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+
+	hs.count++
+	return nil
+}
+
+// Count returns the number of entries spilled during this session
+func (hs *historySpill) Count() int {
+	return hs.count
+}
+
+// ReadAll loads every entry spilled during this session, oldest first
+func (hs *historySpill) ReadAll() ([]HistoryEntry, error) {
+	if hs.count == 0 {
+		return nil, nil
+	}
+
+	file, err := os.Open(hs.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(hs.startOffset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode session log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Reset stops reading back previously spilled entries, as after /clear. The log file itself is kept.
+func (hs *historySpill) Reset() error {
+	err := hs.Close()
+	hs.count = 0
+	hs.opened = false
+	return err
+}
+
+// Close releases the session log file handle. The log is reopened if more entries are spilled.
+func (hs *historySpill) Close() error {
+	if hs.file == nil {
+		return nil
+	}
+	err := hs.file.Close()
+	hs.file = nil
+	return err
+}
+
+// fullHistory returns the complete session transcript: spilled entries followed by those in memory
+func (m *AppModel) fullHistory() ([]HistoryEntry, error) {
+	spilled, err := m.historySpill.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]HistoryEntry, 0, len(spilled)+len(m.commandHistory))
+	entries = append(entries, spilled...)
+	entries = append(entries, m.commandHistory...)
+	return entries, nil
+}
@@ -0,0 +1,85 @@
+// Package app implements the in-flight command indicator for Application Mode in the Universal Application Console.
+// While a command is waiting for its response, the status section shows an animated spinner with the command and
+// the time elapsed so far, so a slow application is not mistaken for a frozen console. The spinner is driven by
+// tea.Tick and stops as soon as no commands are running.
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/ui/components"
+)
+
+// spinnerInterval is how often the spinner advances and the elapsed time is refreshed
+const spinnerInterval = 100 * time.Millisecond
+
+// runningCommand is a command waiting for its response
+type runningCommand struct {
+	seq     uint64
+	command string
+	started time.Time
+}
+
+// spinnerTickMsg advances the spinner; ticks from an earlier run of the spinner are ignored
+type spinnerTickMsg struct {
+	generation int
+}
+
+// startRunning records a command as in flight and starts the spinner if it is not already running
+func (m *AppModel) startRunning(seq uint64, command string) tea.Cmd {
+	m.running = append(m.running, runningCommand{seq: seq, command: command, started: time.Now()})
+	if len(m.running) > 1 {
+		return nil
+	}
+
+	m.spinnerGeneration++
+	m.spinnerFrame = 0
+	return m.spinnerTick()
+}
+
+// finishRunning removes the command with the given sequence number from the in-flight list once its result arrives
+func (m *AppModel) finishRunning(seq uint64) {
+	for i, running := range m.running {
+		if running.seq == seq {
+			m.running = append(m.running[:i], m.running[i+1:]...)
+			return
+		}
+	}
+}
+
+// spinnerTick schedules the next spinner frame
+func (m *AppModel) spinnerTick() tea.Cmd {
+	generation := m.spinnerGeneration
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{generation: generation}
+	})
+}
+
+// handleSpinnerTick advances the spinner while commands are in flight
+func (m *AppModel) handleSpinnerTick(msg spinnerTickMsg) tea.Cmd {
+	if msg.generation != m.spinnerGeneration || len(m.running) == 0 {
+		return nil
+	}
+	m.spinnerFrame++
+	return m.spinnerTick()
+}
+
+// runningStatus describes the most recent in-flight command, e.g. "⠹ Running 'status'… 3.2s"
+func (m *AppModel) runningStatus() string {
+	if len(m.running) == 0 {
+		return ""
+	}
+
+	latest := m.running[len(m.running)-1]
+	status := fmt.Sprintf("%s Running '%s'… %.1fs",
+		components.RenderSpinner(m.spinnerFrame), latest.command, time.Since(latest.started).Seconds())
+	if others := len(m.running) - 1; others > 0 {
+		status += fmt.Sprintf(" (+%d more)", others)
+	}
+	if retry := m.retryNotice(); retry != "" {
+		status += " · " + retry
+	}
+	return status
+}
@@ -0,0 +1,129 @@
+// Package app implements the bottom status bar for Application Mode in the Universal Application Console.
+// The bar is the last line of the screen. On the left it lists the keys that work where focus currently
+// is, so history navigation, section toggling, and tree movement can be discovered without opening /help.
+// On the right it shows the connection status and how many requests are still in flight, after the progress
+// of a watched command.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// Status bar styling, kept dim so it does not compete with content
+	statusBarTextStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#A6ADC8")).
+				Background(lipgloss.Color("#313244"))
+
+	statusBarStyle = statusBarTextStyle.Padding(0, 1)
+
+	statusBarKeyStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#CDD6F4")).
+				Background(lipgloss.Color("#313244")).
+				Bold(true)
+)
+
+// keyHint is a key and what it does where focus currently is
+type keyHint struct {
+	key    string
+	action string
+}
+
+// keyHints returns the keybindings that apply to the current focus, overlays first as they take all input
+func (m *AppModel) keyHints() []keyHint {
+	switch {
+	case m.pendingConfirmation != nil:
+		return []keyHint{{"y", "confirm"}, {"n", "cancel"}}
+	case m.palette != nil:
+		return []keyHint{{"↑↓", "select"}, {"enter", "run"}, {"esc", "close"}}
+	case m.themePicker != nil:
+		return []keyHint{{"↑↓", "preview"}, {"enter", "apply"}, {"esc", "revert"}}
+	case m.appPicker != nil:
+		return []keyHint{{"↑↓", "select"}, {"enter", "switch"}, {"esc", "close"}}
+	}
+
+	switch m.focusState {
+	case FocusActions:
+		hints := []keyHint{{"↑↓", "select"}, {"enter", "run"}}
+		if m.recoveryManager.IsActive() {
+			hints = append(hints, keyHint{"e", "edit & retry"})
+		}
+		return append(hints, keyHint{"tab", "next"}, keyHint{"esc", "input"})
+	case FocusContent:
+		return []keyHint{{"↑↓", "scroll"}, {"pgup/pgdn", "page"}, {"F", "follow"}, {"space", "toggle"}, {"1-9", "section"}, {"esc", "input"}}
+	case FocusExpandable:
+		return []keyHint{{"↑↓", "move"}, {"space", "toggle"}, {"1-9", "jump"}, {"←→", "collapse/expand"}, {"esc", "input"}}
+	case FocusForm:
+		return []keyHint{{"tab/↑↓", "field"}, {"←→", "choose"}, {"space", "check"}, {"ctrl+s", "submit"}, {"esc", "input"}}
+	case FocusTree:
+		return []keyHint{{"↑↓", "move"}, {"space", "toggle"}, {"←→", "collapse/expand"}, {"s", "select"}, {"enter", "run"}}
+	default:
+		hints := []keyHint{{"enter", "send"}, {"ctrl+↑↓", "history"}}
+		if count := len(m.actionsPane.Actions()); count >= 10 {
+			hints = append(hints, keyHint{"1-9,0", "actions"})
+		} else if count > 0 {
+			hints = append(hints, keyHint{"1-9", "actions"})
+		}
+		if m.watch != nil {
+			hints = append(hints, keyHint{"esc", "stop watch"})
+		}
+		tab := keyHint{"tab", "navigate"}
+		if m.ghostText() != "" {
+			tab = keyHint{"tab", "complete"}
+		}
+		return append(hints, tab, keyHint{"ctrl+p", "palette"})
+	}
+}
+
+// pendingOperationCount returns how many requests are in flight
+func (m *AppModel) pendingOperationCount() int {
+	m.pendingMutex.Lock()
+	defer m.pendingMutex.Unlock()
+	return len(m.pendingOperations)
+}
+
+// renderStatusBar renders the key hints on the left and the connection status on the right, as wide as the terminal
+func (m *AppModel) renderStatusBar() string {
+	width := m.terminalWidth
+	if width <= 0 {
+		width = 80
+	}
+
+	hints := m.keyHints()
+	parts := make([]string, 0, len(hints))
+	for _, hint := range hints {
+		parts = append(parts, statusBarKeyStyle.Render(hint.key)+statusBarTextStyle.Render(" "+hint.action))
+	}
+	separator := statusBarTextStyle.Render(" · ")
+	left := strings.Join(parts, separator)
+
+	status := "● Connected"
+	if !m.connected {
+		status = "○ Disconnected"
+	}
+	if pending := m.pendingOperationCount(); pending > 0 {
+		status += fmt.Sprintf(" · %d pending", pending)
+	}
+	if m.watch != nil {
+		status = m.watchStatus() + " · " + status
+	}
+	right := statusBarTextStyle.Render(status)
+
+	// Drop hints from the end until the connection status fits
+	available := width - 2
+	for len(parts) > 0 && lipgloss.Width(left)+lipgloss.Width(right)+1 > available {
+		parts = parts[:len(parts)-1]
+		left = strings.Join(parts, separator)
+	}
+
+	gap := available - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		gap = 1
+	}
+	filler := statusBarTextStyle.Render(strings.Repeat(" ", gap))
+
+	return statusBarStyle.Render(left + filler + right)
+}
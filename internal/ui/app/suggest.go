@@ -0,0 +1,195 @@
+// Package app implements inline suggestions for Application Mode in the Universal Application Console.
+// As the user types, the top-ranked completion is shown as dimmed ghost text after the cursor, like fish
+// and zsh autosuggestions, and Tab, → or Ctrl+E at the end of the input accepts it. Meta commands are completed
+// from the meta command registry; command names are completed at once from the catalog the application
+// publishes in its handshake, and other input from the application's suggest endpoint when the handshake
+// advertises the suggest feature. The endpoint is only asked once typing pauses, and a request the input has
+// moved on from is cancelled. The ghost text is never part of the editable value, and a response that
+// arrives after the input has changed again is discarded.
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// suggestionTimeout bounds a suggestion request; a slow suggestion is no longer useful
+const suggestionTimeout = 2 * time.Second
+
+// suggestionDebounce is how long the input must stay unchanged before suggestions are requested for it
+const suggestionDebounce = 150 * time.Millisecond
+
+// ghostTextStyle renders the unaccepted part of a suggestion
+var ghostTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6C7086"))
+
+// suggestionMsg carries the top-ranked suggestion for an input.
+// This is an internal message and remains UNEXPORTED.
+type suggestionMsg struct {
+	input      string
+	suggestion string
+}
+
+// suggestionDueMsg is sent once the input has been left unchanged for suggestionDebounce.
+// This is an internal message and remains UNEXPORTED.
+type suggestionDueMsg struct {
+	input string
+}
+
+// inputChanged updates the suggestion after an edit: a suggestion that no longer extends the input is
+// dropped at once, and a new one is requested for the current input once typing pauses
+func (m *AppModel) inputChanged() tea.Cmd {
+	m.cancelSuggestionRequest()
+
+	input := m.commandInput.Value()
+	if !strings.HasPrefix(m.suggestion, input) {
+		m.suggestion = ""
+	}
+	if input == "" || strings.TrimSpace(input) == "" {
+		m.suggestion = ""
+		return nil
+	}
+
+	if strings.HasPrefix(input, "/") {
+		m.suggestion = m.suggestMetaCommand(input)
+		return nil
+	}
+
+	// A command name from the published catalog is shown at once, until the suggest endpoint answers
+	if suggestion := m.suggestCatalogCommand(input); suggestion != "" {
+		m.suggestion = suggestion
+	}
+
+	if !m.hasFeature(FeatureSuggest) {
+		return nil
+	}
+
+	return tea.Tick(suggestionDebounce, func(time.Time) tea.Msg {
+		return suggestionDueMsg{input: input}
+	})
+}
+
+// requestSuggestion asks the suggest endpoint to complete the input, unless it has changed since the
+// request was scheduled
+func (m *AppModel) requestSuggestion(input string) tea.Cmd {
+	if input != m.commandInput.Value() {
+		return nil
+	}
+
+	m.cancelSuggestionRequest()
+	ctx, cancel := context.WithTimeout(m.requestContext, suggestionTimeout)
+	m.cancelSuggestion = cancel
+
+	request := interfaces.SuggestRequest{
+		CurrentInput: input,
+		Context:      m.withVariables(nil),
+	}
+	return func() tea.Msg {
+		defer cancel()
+
+		response, err := m.protocolClient.GetSuggestions(ctx, request)
+		if err != nil {
+			return suggestionMsg{input: input}
+		}
+		return suggestionMsg{input: input, suggestion: topSuggestion(input, response.Suggestions)}
+	}
+}
+
+// cancelSuggestionRequest cancels the suggestion request in flight, whose input has been superseded
+func (m *AppModel) cancelSuggestionRequest() {
+	if m.cancelSuggestion != nil {
+		m.cancelSuggestion()
+		m.cancelSuggestion = nil
+	}
+}
+
+// topSuggestion returns the highest-ranked suggestion that completes the input, if any
+func topSuggestion(input string, suggestions []interfaces.SuggestionItem) string {
+	for _, item := range suggestions {
+		if len(item.Text) > len(input) && strings.HasPrefix(item.Text, input) {
+			return item.Text
+		}
+	}
+	return ""
+}
+
+// suggestMetaCommand completes a meta command name from the registry, in registration order
+func (m *AppModel) suggestMetaCommand(input string) string {
+	if strings.ContainsAny(input, " \t") {
+		return ""
+	}
+	for _, command := range m.metaCommands.Commands() {
+		for _, name := range append([]string{command.Name}, command.Aliases...) {
+			if len(name) > len(input) && strings.HasPrefix(name, input) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// handleSuggestion keeps a suggestion unless the input has changed since it was requested; an empty answer
+// leaves a completion from the command catalog in place
+func (m *AppModel) handleSuggestion(msg suggestionMsg) {
+	if msg.input != m.commandInput.Value() || (msg.suggestion == "" && m.suggestCatalogCommand(msg.input) != "") {
+		return
+	}
+	m.suggestion = msg.suggestion
+}
+
+// ghostText returns the part of the suggestion shown after the cursor, or "" when none is shown.
+// Ghost text only appears while the input has focus and the cursor is at the end of the input.
+func (m *AppModel) ghostText() string {
+	if m.focusState != FocusInput || m.suggestion == "" {
+		return ""
+	}
+	input := m.commandInput.Value()
+	if m.commandInput.Position() != len([]rune(input)) || !strings.HasPrefix(m.suggestion, input) {
+		return ""
+	}
+	return m.suggestion[len(input):]
+}
+
+// acceptSuggestion completes the input with the ghost text, reporting whether there was any
+func (m *AppModel) acceptSuggestion() bool {
+	if m.ghostText() == "" {
+		return false
+	}
+	m.commandInput.SetValue(m.suggestion)
+	m.commandInput.CursorEnd()
+	m.suggestion = ""
+	return true
+}
+
+// inputView renders the command input with the ghost text after the cursor, clipped to the input width.
+// The ghost text is rendered on a copy of the input so that it never becomes part of the value.
+func (m *AppModel) inputView() string {
+	ghost := m.ghostText()
+	if ghost == "" {
+		return m.commandInput.View()
+	}
+
+	if m.commandInput.Width > 0 {
+		room := m.commandInput.Width - lipgloss.Width(m.commandInput.Value())
+		if room <= 0 {
+			return m.commandInput.View()
+		}
+		runes := []rune(ghost)
+		if len(runes) > room {
+			ghost = string(runes[:room])
+		}
+	}
+
+	// The completion view places the cursor on the first ghost character and dims the rest;
+	// without a width the input adds no padding after the value for the ghost text to follow
+	view := m.commandInput
+	view.Width = 0
+	view.ShowSuggestions = true
+	view.CompletionStyle = ghostTextStyle
+	view.SetSuggestions([]string{m.commandInput.Value() + ghost})
+	return view.View()
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+func TestSuggestionRequestsWaitForTypingToPause(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	m.features = map[string]bool{FeatureSuggest: true}
+
+	m.commandInput.SetValue("de")
+	cmd := m.inputChanged()
+	if cmd == nil {
+		t.Fatal("no suggestion was scheduled")
+	}
+	due, ok := cmd().(suggestionDueMsg)
+	if !ok || due.input != "de" {
+		t.Fatalf("scheduled message = %#v, want suggestionDueMsg for \"de\"", due)
+	}
+
+	// The input moved on before the pause ended, so the request is not sent
+	m.commandInput.SetValue("dep")
+	m.inputChanged()
+	if m.requestSuggestion(due.input) != nil {
+		t.Error("suggestions were requested for superseded input")
+	}
+
+	request := m.requestSuggestion("dep")
+	if request == nil {
+		t.Fatal("suggestions were not requested for the current input")
+	}
+	msg, ok := request().(suggestionMsg)
+	if !ok || msg.suggestion != "deploy" {
+		t.Errorf("suggestion = %#v, want deploy", msg)
+	}
+}
+
+func TestSupersededSuggestionRequestIsCancelled(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	m.features = map[string]bool{FeatureSuggest: true}
+
+	m.commandInput.SetValue("de")
+	m.requestSuggestion("de")
+	cancelled := false
+	m.cancelSuggestion = func() { cancelled = true }
+
+	m.commandInput.SetValue("dep")
+	m.inputChanged()
+	if !cancelled {
+		t.Error("the request for the previous input was not cancelled")
+	}
+}
@@ -0,0 +1,178 @@
+// Package app implements switching profiles in place for Application Mode in the Universal Application
+// Console. /switch <profile> disconnects from the current application and connects with another saved
+// profile without returning to the Console Menu, which makes hopping between environments such as staging
+// and production quick. The session's settings follow the new profile: theme, context variables, labels,
+// display preferences, client limits, and command recall. History is cleared unless --keep-history is given.
+// If the new connection fails, the console reconnects with the previous profile.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// switchConnectTimeout bounds each connection attempt of a profile switch
+const switchConnectTimeout = 15 * time.Second
+
+// profileSwitchedMsg carries the outcome of a profile switch.
+// This is an internal message and remains UNEXPORTED.
+type profileSwitchedMsg struct {
+	profile     *interfaces.Profile // The profile now connected, or the previous one after a failed switch
+	spec        *interfaces.SpecResponse
+	keepHistory bool
+	err         error // Why the new profile could not be connected
+	restoreErr  error // Why the previous profile could not be reconnected after a failure
+}
+
+// switchProfile handles /switch [--keep-history] <profile>
+func (m *AppModel) switchProfile(args []string) tea.Cmd {
+	keepHistory := false
+	var nameParts []string
+	for _, arg := range args {
+		if arg == "--keep-history" {
+			keepHistory = true
+			continue
+		}
+		nameParts = append(nameParts, arg)
+	}
+	name := strings.Join(nameParts, " ")
+	if name == "" {
+		return m.showError("Usage: /switch [--keep-history] <profile>")
+	}
+
+	profile, err := m.configManager.LoadProfile(name)
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to load profile '%s': %v", name, err))
+	}
+	if profile.Name == m.profile.Name && profile.Host == m.profile.Host {
+		return m.showError(fmt.Sprintf("Already connected with profile '%s'", profile.Name))
+	}
+	if count := m.pendingOperationCount(); count > 0 {
+		return m.showError(fmt.Sprintf("%d request(s) still running; switch profiles once they finish", count))
+	}
+
+	previous := m.profile
+	m.statusMessage = fmt.Sprintf("Switching to '%s' at %s...", profile.Name, profile.Host)
+
+	return func() tea.Msg {
+		m.protocolClient.Disconnect()
+
+		spec, err := m.connectProfile(profile)
+		if err == nil {
+			return profileSwitchedMsg{profile: profile, spec: spec, keepHistory: keepHistory}
+		}
+
+		// Fall back to the application the session was using
+		spec, restoreErr := m.connectProfile(previous)
+		return profileSwitchedMsg{profile: previous, spec: spec, keepHistory: true, err: err, restoreErr: restoreErr}
+	}
+}
+
+// connectProfile applies a profile's client limits and connects to its host
+func (m *AppModel) connectProfile(profile *interfaces.Profile) (*interfaces.SpecResponse, error) {
+	m.protocolClient.SetRateLimit(profile.RateLimit, profile.RateBurst)
+	m.protocolClient.SetCircuitBreaker(profile.CircuitThreshold, time.Duration(profile.CircuitCooldown)*time.Second)
+	m.protocolClient.SetIdlePolicy(time.Duration(profile.KeepAlive)*time.Second, time.Duration(profile.IdleTimeout)*time.Minute)
+	m.protocolClient.SetConnectionPool(profile.PoolSize, profile.MaxConnections, profile.DisableHTTP2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), switchConnectTimeout)
+	defer cancel()
+
+	return m.protocolClient.Connect(ctx, profile.Host, &profile.Auth)
+}
+
+// handleProfileSwitched adopts the connected profile, or reports why the switch failed
+func (m *AppModel) handleProfileSwitched(msg profileSwitchedMsg) tea.Cmd {
+	m.statusMessage = ""
+
+	if msg.restoreErr != nil {
+		m.connected = false
+		m.connectionError = fmt.Sprintf("reconnecting to %s failed: %v", msg.profile.Host, msg.restoreErr)
+		return m.showError(fmt.Sprintf("Switch failed: %v; reconnecting to '%s' also failed: %v", msg.err, msg.profile.Name, msg.restoreErr))
+	}
+
+	if msg.profile != m.profile {
+		if !msg.keepHistory {
+			m.clearHistory()
+		}
+		m.applyProfile(msg.profile)
+	}
+
+	m.connected = true
+	m.connectionError = ""
+	if msg.spec != nil {
+		m.appName = msg.spec.AppName
+		m.appVersion = msg.spec.AppVersion
+		m.protocolVersion = msg.spec.ProtocolVersion
+		m.features = msg.spec.Features
+		m.commandCatalog = msg.spec.Commands
+	}
+
+	// The previous application's actions, workflow, and error do not apply to the new one
+	m.currentResponse = nil
+	m.actionsPane.SetActions(nil)
+	m.workflowManager.EndWorkflow()
+	m.clearStatus()
+	m.updateFocusableElements()
+	m.invalidateHistoryBuffer()
+
+	if msg.err != nil {
+		return m.showError(fmt.Sprintf("Failed to switch profiles: %v; still connected with '%s'", msg.err, m.profile.Name))
+	}
+	m.statusMessage = fmt.Sprintf("Switched to '%s' at %s", m.profile.Name, m.profile.Host)
+	return nil
+}
+
+// applyProfile replaces the session's profile and the settings that come from it
+func (m *AppModel) applyProfile(profile *interfaces.Profile) {
+	m.profile = profile
+
+	m.theme = nil
+	if profile.Theme != "" {
+		if theme, err := m.configManager.LoadTheme(profile.Theme); err == nil {
+			m.theme = theme
+		}
+	}
+
+	m.variables = newContextVariables(profile)
+	m.labels = newPromptLabels(profile)
+	m.clearResponseCache()
+	m.resetRetryBudget()
+	m.queuedCommands = nil
+	m.watch = nil
+	m.progressOperations = make(map[string]*progressOperation)
+
+	m.showTimestamps = profile.ShowTimestamps
+	m.showLineNumbers = !profile.HideLineNumbers
+	m.confirmDestructive = profile.Confirmations
+	m.highContrast = profile.HighContrast
+	m.maxHistorySize = defaultMaxHistorySize
+	if profile.MaxHistory > 0 {
+		m.maxHistorySize = profile.MaxHistory
+	}
+
+	m.contentRenderer.SetHighContrast(profile.HighContrast)
+	m.actionsPane.SetHighContrast(profile.HighContrast)
+	m.contentRenderer.SetDefaultCodeLanguage(profile.CodeLanguage)
+	m.contentRenderer.SetShowLineNumbers(!profile.HideLineNumbers)
+	m.contentRenderer.SetBlockFilter(profile.HideBlocks, profile.HideStatuses)
+	m.contentRenderer.SetComplexityBudget(profile.ComplexityBudget)
+	m.showAllBlocks = false
+
+	// Recall the commands sent to the new host
+	m.inputHistory = make([]string, 0)
+	m.inputHistoryPath = defaultInputHistoryPath(m.configManager.GetConfigPath(), profile.Host)
+	if m.inputHistoryPath != "" {
+		if commands, err := loadInputHistory(m.inputHistoryPath); err == nil {
+			m.inputHistory = append(m.inputHistory, commands...)
+		}
+	}
+	m.inputHistoryIndex = len(m.inputHistory)
+
+	m.reRenderHistory()
+}
@@ -0,0 +1,144 @@
+// Package app implements the theme picker for Application Mode in the Universal Application Console.
+// /theme without arguments lists the configured themes with the current one highlighted. Moving through the
+// list previews each theme by loading it and re-rendering history with it; Enter keeps the highlighted theme
+// and Esc restores the theme that was in use when the picker opened.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/ui/components"
+)
+
+// maxThemePickerRows limits how many themes the picker shows at once
+const maxThemePickerRows = 8
+
+// themePicker holds the state of an open theme picker
+type themePicker struct {
+	names    []string
+	selected int
+	original *interfaces.Theme // Theme restored when the picker is cancelled
+	err      string            // Why the highlighted theme could not be previewed
+}
+
+// openThemePicker lists the configured themes, starting at the current one
+func (m *AppModel) openThemePicker() tea.Cmd {
+	names, err := m.configManager.ListThemes()
+	if err != nil {
+		return m.showError(fmt.Sprintf("Failed to list themes: %v", err))
+	}
+	if len(names) == 0 {
+		return m.showError("No themes are configured")
+	}
+
+	m.themePicker = &themePicker{names: names, original: m.theme}
+	for i, name := range names {
+		if m.theme != nil && m.theme.Name == name {
+			m.themePicker.selected = i
+		}
+	}
+	return nil
+}
+
+// previewTheme loads the highlighted theme and re-renders history with it
+func (m *AppModel) previewTheme() {
+	picker := m.themePicker
+	theme, err := m.configManager.LoadTheme(picker.names[picker.selected])
+	if err != nil {
+		picker.err = err.Error()
+		return
+	}
+	picker.err = ""
+	m.theme = theme
+	m.reRenderHistory()
+}
+
+// closeThemePicker closes the picker, keeping the previewed theme or restoring the original one
+func (m *AppModel) closeThemePicker(keep bool) {
+	picker := m.themePicker
+
+	// The highlighted theme is not previewed yet when the picker opened without a theme in use
+	if keep && (m.theme == nil || m.theme.Name != picker.names[picker.selected]) {
+		m.previewTheme()
+	}
+	m.themePicker = nil
+
+	if keep && picker.err == "" {
+		m.statusMessage = fmt.Sprintf("Theme changed to '%s'", picker.names[picker.selected])
+		return
+	}
+	if m.theme != picker.original {
+		m.theme = picker.original
+		m.reRenderHistory()
+	}
+	if keep {
+		m.statusMessage = fmt.Sprintf("Theme '%s' could not be loaded; theme unchanged", picker.names[picker.selected])
+	}
+}
+
+// handleThemePickerKeys processes keyboard input while the theme picker is open
+func (m *AppModel) handleThemePickerKeys(msg tea.KeyMsg) tea.Cmd {
+	picker := m.themePicker
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closeThemePicker(false)
+
+	case tea.KeyEnter:
+		m.closeThemePicker(true)
+
+	case tea.KeyUp, tea.KeyShiftTab:
+		if picker.selected > 0 {
+			picker.selected--
+			m.previewTheme()
+		}
+
+	case tea.KeyDown, tea.KeyTab:
+		if picker.selected < len(picker.names)-1 {
+			picker.selected++
+			m.previewTheme()
+		}
+	}
+	return nil
+}
+
+// renderThemePicker creates the theme listing, scrolled to keep the highlighted theme visible
+func (m *AppModel) renderThemePicker() string {
+	picker := m.themePicker
+	lines := []string{paletteTitleStyle.Render("Theme")}
+
+	start := 0
+	if picker.selected >= maxThemePickerRows {
+		start = picker.selected - maxThemePickerRows + 1
+	}
+	end := start + maxThemePickerRows
+	if end > len(picker.names) {
+		end = len(picker.names)
+	}
+
+	for i := start; i < end; i++ {
+		name := picker.names[i]
+		marker := " "
+		if picker.original != nil && picker.original.Name == name {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s %s", marker, name)
+		if i == picker.selected {
+			line = paletteSelectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	if picker.err != "" {
+		lines = append(lines, "", components.RenderStatus("error", "Cannot preview: "+picker.err))
+	}
+	lines = append(lines, "", "↑/↓ preview  Enter apply  Esc revert")
+
+	width := m.terminalWidth - 2
+	if width < 20 {
+		width = 20
+	}
+	return paletteStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,59 @@
+// Package app implements command timeouts for Application Mode in the Universal Application Console.
+// A command or action waits for its response for a timeout resolved in order of precedence: an inline
+// "!timeout=<duration>" prefix on the command, then the profile's commandTimeout, then the --timeout flag,
+// and finally DefaultCommandTimeout. Inline durations are seconds ("120") or Go durations ("2m30s").
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeoutOverridePrefix starts an inline timeout override, e.g. "!timeout=120 long-running-task"
+const timeoutOverridePrefix = "!timeout="
+
+// DefaultCommandTimeout is the command timeout when neither the command nor the profile sets one.
+// It is set from the --timeout flag.
+var DefaultCommandTimeout = 30 * time.Second
+
+// commandTimeout returns the timeout for commands and actions without an inline override
+func (m *AppModel) commandTimeout() time.Duration {
+	if m.profile != nil && m.profile.CommandTimeout > 0 {
+		return time.Duration(m.profile.CommandTimeout) * time.Second
+	}
+	return DefaultCommandTimeout
+}
+
+// parseTimeoutOverride strips an inline timeout override from a command, returning the command to send
+// and the timeout it should run with
+func (m *AppModel) parseTimeoutOverride(command string) (string, time.Duration, error) {
+	if !strings.HasPrefix(command, timeoutOverridePrefix) {
+		return command, m.commandTimeout(), nil
+	}
+
+	value, rest, _ := strings.Cut(strings.TrimPrefix(command, timeoutOverridePrefix), " ")
+	timeout, err := parseTimeoutValue(value)
+	if err != nil {
+		return "", 0, err
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return "", 0, fmt.Errorf("no command given after %s%s", timeoutOverridePrefix, value)
+	}
+	return rest, timeout, nil
+}
+
+// parseTimeoutValue parses a positive timeout given in seconds or as a Go duration
+func parseTimeoutValue(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if seconds, convErr := strconv.Atoi(value); convErr == nil {
+		timeout, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout '%s': use seconds (120) or a duration (2m30s)", value)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,186 @@
+// Package app implements tree navigation for Application Mode in the Universal Application Console.
+// This file lets the user focus rendered trees, move a cursor between visible nodes with the arrow keys,
+// expand and collapse branches, and select nodes. Selecting a node whose metadata names a command runs it.
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// treeIDs returns the IDs of all rendered trees in history order
+func (m *AppModel) treeIDs() []string {
+	var ids []string
+	for _, entry := range m.commandHistory {
+		for _, content := range entry.Rendered {
+			if content.TreeNodes != nil {
+				ids = append(ids, content.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// findTree returns the rendered content of a tree in history
+func (m *AppModel) findTree(treeID string) *interfaces.RenderedContent {
+	for i := range m.commandHistory {
+		for j := range m.commandHistory[i].Rendered {
+			if m.commandHistory[i].Rendered[j].ID == treeID {
+				return &m.commandHistory[i].Rendered[j]
+			}
+		}
+	}
+	return nil
+}
+
+// focusTree moves focus to a tree, placing the cursor on its first or last visible node
+func (m *AppModel) focusTree(treeID string, atEnd bool) {
+	m.SetFocus(FocusTree)
+	m.focusedTreeID = treeID
+	m.focusedTreeNode = 0
+	if tree := m.findTree(treeID); tree != nil && atEnd {
+		m.focusedTreeNode = len(tree.TreeNodes) - 1
+	}
+}
+
+// focusedNode returns the tree node under the cursor
+func (m *AppModel) focusedNode() (*interfaces.RenderedTreeNode, bool) {
+	tree := m.findTree(m.focusedTreeID)
+	if tree == nil || m.focusedTreeNode < 0 || m.focusedTreeNode >= len(tree.TreeNodes) {
+		return nil, false
+	}
+	return &tree.TreeNodes[m.focusedTreeNode], true
+}
+
+// handleTreeKeys processes keyboard input when a tree has focus
+func (m *AppModel) handleTreeKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		return m.navigateTreeNodes(-1)
+
+	case "down", "j":
+		return m.navigateTreeNodes(1)
+
+	case "space", " ":
+		return m.toggleFocusedTreeNode()
+
+	case "enter":
+		// Enter opens branches and selects leaves
+		if node, ok := m.focusedNode(); ok && node.HasChildren {
+			return m.toggleFocusedTreeNode()
+		}
+		return m.selectFocusedTreeNode()
+
+	case "s":
+		return m.selectFocusedTreeNode()
+
+	case "right", "l":
+		if node, ok := m.focusedNode(); ok && node.HasChildren && !node.Expanded {
+			return m.toggleFocusedTreeNode()
+		}
+		return nil
+
+	case "left", "h":
+		if node, ok := m.focusedNode(); ok && node.HasChildren && node.Expanded {
+			return m.toggleFocusedTreeNode()
+		}
+		return nil
+
+	case "tab":
+		return m.cycleFocusForward()
+
+	case "shift+tab":
+		return m.cycleFocusBackward()
+
+	default:
+		return nil
+	}
+}
+
+// navigateTreeNodes moves the cursor between visible nodes, continuing into adjacent trees at the edges
+func (m *AppModel) navigateTreeNodes(direction int) tea.Cmd {
+	tree := m.findTree(m.focusedTreeID)
+	if tree == nil {
+		return nil
+	}
+
+	next := m.focusedTreeNode + direction
+	if next >= 0 && next < len(tree.TreeNodes) {
+		m.focusedTreeNode = next
+		return nil
+	}
+
+	ids := m.treeIDs()
+	for i, id := range ids {
+		if id != m.focusedTreeID {
+			continue
+		}
+		if direction < 0 && i > 0 {
+			m.focusTree(ids[i-1], true)
+		} else if direction > 0 && i < len(ids)-1 {
+			m.focusTree(ids[i+1], false)
+		}
+		break
+	}
+	return nil
+}
+
+// toggleFocusedTreeNode expands or collapses the branch under the cursor
+func (m *AppModel) toggleFocusedTreeNode() tea.Cmd {
+	node, ok := m.focusedNode()
+	if !ok || !node.HasChildren {
+		return nil
+	}
+
+	rendered, err := m.contentRenderer.ToggleTreeNode(m.focusedTreeID, node.ID)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return nil
+	}
+
+	m.replaceTree(rendered)
+	return nil
+}
+
+// selectFocusedTreeNode selects the node under the cursor, running its command if it has one
+func (m *AppModel) selectFocusedTreeNode() tea.Cmd {
+	node, ok := m.focusedNode()
+	if !ok {
+		return nil
+	}
+	nodeID, label, command := node.ID, node.Label, node.Command
+
+	rendered, err := m.contentRenderer.SelectTreeNode(m.focusedTreeID, nodeID)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return nil
+	}
+	m.replaceTree(rendered)
+
+	if updated, ok := m.focusedNode(); ok && !updated.Selected {
+		m.statusMessage = fmt.Sprintf("Deselected %s", label)
+		return nil
+	}
+
+	if command != "" {
+		m.SetFocus(FocusInput)
+		return m.ExecuteCommand(command)
+	}
+
+	m.statusMessage = fmt.Sprintf("Selected %s (%s)", label, nodeID)
+	return nil
+}
+
+// replaceTree swaps a re-rendered tree into history, keeping the cursor on a visible node
+func (m *AppModel) replaceTree(rendered *interfaces.RenderedContent) {
+	if tree := m.findTree(rendered.ID); tree != nil {
+		*tree = *rendered
+	}
+
+	if m.focusedTreeNode >= len(rendered.TreeNodes) {
+		m.focusedTreeNode = len(rendered.TreeNodes) - 1
+	}
+	m.invalidateHistoryBuffer()
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/content"
 	"github.com/universal-console/console/internal/errors"
 	"github.com/universal-console/console/internal/interfaces"
 )
@@ -27,14 +28,27 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			commands = append(commands, cmd)
 		}
 
+	case tea.MouseMsg:
+		cmd := m.handleMouseInput(msg)
+		if cmd != nil {
+			commands = append(commands, cmd)
+		}
+
 	case tea.WindowSizeMsg:
 		m.SetTerminalSize(msg.Width, msg.Height)
+		commands = append(commands, m.scheduleHistoryReflow())
+
+	case historyReflowMsg:
+		m.handleHistoryReflow(msg)
 
 	case commandExecutedMsg:
 		cmd := m.handleCommandExecuted(msg)
 		if cmd != nil {
 			commands = append(commands, cmd)
 		}
+		if cmd := m.runQueuedCommand(); cmd != nil {
+			commands = append(commands, cmd)
+		}
 
 	case actionExecutedMsg:
 		cmd := m.handleActionExecuted(msg)
@@ -42,6 +56,61 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			commands = append(commands, cmd)
 		}
 
+	case contentRenderedMsg:
+		if cmd := m.handleContentRendered(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case watchTickMsg:
+		if cmd := m.handleWatchTick(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case watchResultMsg:
+		if cmd := m.handleWatchResult(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case progressPollTickMsg:
+		if cmd := m.handleProgressPollTick(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case progressPolledMsg:
+		if cmd := m.handleProgressPolled(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case pushedMsg:
+		commands = append(commands, m.handlePush(msg))
+
+	case spinnerTickMsg:
+		if cmd := m.handleSpinnerTick(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case listPageFetchedMsg:
+		cmd := m.handleListPageFetched(msg)
+		if cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case referenceFetchedMsg:
+		if cmd := m.handleReferenceFetched(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case configEditedMsg:
+		if cmd := m.handleConfigEdited(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case redirectFinishedMsg:
+		cmd := m.handleRedirectFinished(msg)
+		if cmd != nil {
+			commands = append(commands, cmd)
+		}
+
 	case sectionToggledMsg:
 		m.handleSectionToggled(msg)
 
@@ -51,6 +120,24 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case applicationInfoMsg:
 		m.handleApplicationInfo(msg)
 
+	case suggestionMsg:
+		m.handleSuggestion(msg)
+
+	case suggestionDueMsg:
+		if cmd := m.requestSuggestion(msg.input); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case profileSwitchedMsg:
+		if cmd := m.handleProfileSwitched(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
+	case clipboardPasteMsg:
+		if cmd := m.handleClipboardPaste(msg); cmd != nil {
+			commands = append(commands, cmd)
+		}
+
 	default:
 		// Handle textinput updates for command input field
 		if m.focusState == FocusInput {
@@ -71,14 +158,53 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyInput processes keyboard input according to focus state and navigation patterns
 func (m *AppModel) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
+	// A pending confirmation intercepts all input except quitting
+	if m.pendingConfirmation != nil && msg.String() != "ctrl+c" {
+		return m.handleConfirmationKeys(msg)
+	}
+
+	// An open command palette takes all other input
+	if m.palette != nil && msg.String() != "ctrl+c" {
+		return m.handlePaletteKeys(msg)
+	}
+
+	// So does an open theme picker, restoring the original theme unless one is applied
+	if m.themePicker != nil && msg.String() != "ctrl+c" {
+		return m.handleThemePickerKeys(msg)
+	}
+
+	// And an open application picker
+	if m.appPicker != nil && msg.String() != "ctrl+c" {
+		return m.handleAppPickerKeys(msg)
+	}
+
+	// Pastes go to the command input, except into a form, whose fields take pastes themselves
+	if m.focusState != FocusForm {
+		if msg.Paste {
+			return m.insertPastedText(string(msg.Runes))
+		}
+		if msg.String() == "ctrl+v" {
+			return m.pasteFromClipboard()
+		}
+	}
+
 	// Handle global key commands that work regardless of focus
 	switch msg.String() {
 	case "ctrl+c":
+		m.CancelRequests()
 		return tea.Quit
 	case "esc":
 		return m.handleEscapeKey()
 	case "ctrl+r":
 		return m.retryLastCommand()
+	case "ctrl+p":
+		return m.openCommandPalette()
+	case "ctrl+t":
+		return m.toggleTimestamps(nil)
+	case "ctrl+n":
+		return m.toggleLineNumbers(nil)
+	case "ctrl+o":
+		return m.editConfig()
 	case "f5":
 		return m.refreshConnection()
 	}
@@ -93,6 +219,28 @@ func (m *AppModel) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 		return m.handleContentKeys(msg)
 	case FocusExpandable:
 		return m.handleExpandableKeys(msg)
+	case FocusTree:
+		return m.handleTreeKeys(msg)
+	case FocusForm:
+		return m.handleFormKeys(msg)
+	default:
+		return nil
+	}
+}
+
+// handleConfirmationKeys resolves a pending confirmation; only an explicit Yes runs the operation
+func (m *AppModel) handleConfirmationKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y":
+		prompt := m.pendingConfirmation
+		m.pendingConfirmation = nil
+		return prompt.onConfirm()
+
+	case "n", "N", "esc":
+		m.pendingConfirmation = nil
+		m.statusMessage = "Cancelled"
+		return nil
+
 	default:
 		return nil
 	}
@@ -104,12 +252,20 @@ func (m *AppModel) handleInputKeys(msg tea.KeyMsg) tea.Cmd {
 	case "enter":
 		command := strings.TrimSpace(m.commandInput.Value())
 		if command != "" {
+			if m.warnBeforeSending(command) {
+				return nil
+			}
 			m.commandInput.SetValue("")
+			m.suggestion = ""
 			return m.ExecuteCommand(command)
 		}
 		return nil
 
 	case "tab":
+		// Complete the input with the ghost text, or move focus on when there is none
+		if m.acceptSuggestion() {
+			return m.inputChanged()
+		}
 		return m.cycleFocusForward()
 
 	case "shift+tab":
@@ -130,19 +286,32 @@ func (m *AppModel) handleInputKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.navigateInputHistory(1)
 
 	case "ctrl+l":
-		return m.clearHistory()
+		return m.confirmClearHistory()
 
 	default:
-		// Handle numbered shortcuts for quick action execution (when input is empty)
+		// Handle numbered shortcuts for quick action execution (when input is empty); 0 runs the
+		// tenth action when there is one and is typed otherwise
 		if m.commandInput.Value() == "" {
 			if num, err := strconv.Atoi(msg.String()); err == nil && num >= 1 && num <= 9 {
 				return m.executeActionByNumber(num)
 			}
+			if msg.String() == "0" && len(m.actionsPane.Actions()) >= 10 {
+				return m.executeActionByNumber(10)
+			}
 		}
 
-		// Let textinput handle character input
+		// Accept the ghost text suggestion; otherwise the keys move the cursor as usual
+		if (msg.String() == "right" || msg.String() == "ctrl+e") && m.acceptSuggestion() {
+			return m.inputChanged()
+		}
+
+		// Let textinput handle character input, updating the suggestion when the value changes
+		before := m.commandInput.Value()
 		var cmd tea.Cmd
 		m.commandInput, cmd = m.commandInput.Update(msg)
+		if m.commandInput.Value() != before {
+			return tea.Batch(cmd, m.inputChanged())
+		}
 		return cmd
 	}
 }
@@ -161,6 +330,14 @@ func (m *AppModel) handleActionsKeys(msg tea.KeyMsg) tea.Cmd {
 	case "enter", "space":
 		return m.executeSelectedAction()
 
+	case "e":
+		// While an error is shown, edit the failed command instead of retyping it
+		if m.recoveryManager.IsActive() {
+			m.clearStatus()
+			return m.editLastFailedCommand()
+		}
+		return nil
+
 	case "tab":
 		return m.cycleFocusForward()
 
@@ -168,9 +345,9 @@ func (m *AppModel) handleActionsKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.cycleFocusBackward()
 
 	default:
-		// Handle numbered action selection
-		if num, err := strconv.Atoi(msg.String()); err == nil && num >= 1 && num <= 9 {
-			return m.executeActionByNumber(num)
+		// Handle shortcut keys: digits for the first ten actions, then letters
+		if index, ok := content.ShortcutIndex(msg.String()); ok {
+			return m.executeActionByNumber(index + 1)
 		}
 		return nil
 	}
@@ -197,6 +374,9 @@ func (m *AppModel) handleContentKeys(msg tea.KeyMsg) tea.Cmd {
 	case "end":
 		return m.scrollToBottom()
 
+	case "F":
+		return m.toggleFollow()
+
 	case "tab":
 		return m.cycleFocusForward()
 
@@ -207,6 +387,9 @@ func (m *AppModel) handleContentKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.toggleFocusedSection()
 
 	default:
+		if num, err := strconv.Atoi(msg.String()); err == nil && num >= 1 && num <= 9 {
+			return m.jumpToSection(num)
+		}
 		return nil
 	}
 }
@@ -238,100 +421,176 @@ func (m *AppModel) handleExpandableKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.expandFocusedSection()
 
 	default:
+		if num, err := strconv.Atoi(msg.String()); err == nil && num >= 1 && num <= 9 {
+			return m.jumpToSection(num)
+		}
 		return nil
 	}
 }
 
-// Focus navigation methods
+// handleMouseInput processes mouse clicks on actions and section headers and wheel scrolling
+func (m *AppModel) handleMouseInput(msg tea.MouseMsg) tea.Cmd {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.scrollContent(-3)
 
-// cycleFocusForward moves focus to the next focusable element
-func (m *AppModel) cycleFocusForward() tea.Cmd {
-	m.recordNavigation(m.focusState, "tab")
+	case tea.MouseButtonWheelDown:
+		return m.scrollContent(3)
 
-	// Determine next focus state based on current state and available elements
-	switch m.focusState {
-	case FocusInput:
-		if m.actionsPane.IsVisible() {
-			m.SetFocus(FocusActions)
-		} else if len(m.collapsibleElements) > 0 {
-			m.SetFocus(FocusExpandable)
-			m.currentFocusIndex = 0
-		} else {
-			m.SetFocus(FocusContent)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress || m.renderOverlay() != "" {
+			return nil
 		}
+		return m.handleMouseClick(msg.Y)
 
-	case FocusActions:
-		if len(m.collapsibleElements) > 0 {
-			m.SetFocus(FocusExpandable)
-			m.currentFocusIndex = 0
-		} else if len(m.renderedContent) > 0 {
-			m.SetFocus(FocusContent)
-		} else {
-			m.SetFocus(FocusInput)
+	default:
+		return nil
+	}
+}
+
+// handleMouseClick maps a clicked screen row to an action or collapsible header from the last render
+func (m *AppModel) handleMouseClick(row int) tea.Cmd {
+	// Clicking a numbered action selects and executes it
+	if m.layout.actionsTop >= 0 && m.actionsPane.IsVisible() {
+		if index, ok := m.actionsPane.ActionIndexAt(row - m.layout.actionsTop); ok {
+			m.navigate("click", func() { m.SetFocus(FocusActions) })
+			return m.executeActionByNumber(index + 1)
 		}
+	}
 
-	case FocusContent:
-		if len(m.collapsibleElements) > 0 {
-			m.SetFocus(FocusExpandable)
-			m.currentFocusIndex = 0
-		} else {
-			m.SetFocus(FocusInput)
+	// Clicking a collapsible header toggles it
+	if row >= m.layout.historyTop && row < m.layout.historyBottom {
+		if sectionID, ok := m.layout.sectionRows[row-m.layout.historyTop]; ok {
+			m.navigate("click", func() { m.SetFocus(FocusExpandable) })
+			m.focusedSectionID = sectionID
+			return m.ToggleSection(sectionID)
 		}
+	}
 
-	case FocusExpandable:
-		m.SetFocus(FocusInput)
+	return nil
+}
 
-	default:
-		m.SetFocus(FocusInput)
-	}
+// Focus navigation methods
 
+// cycleFocusForward moves focus to the next focus area, wrapping from the last back to the input
+func (m *AppModel) cycleFocusForward() tea.Cmd {
+	m.navigate("tab", func() { m.cycleFocus(1) })
 	return nil
 }
 
-// cycleFocusBackward moves focus to the previous focusable element
+// cycleFocusBackward moves focus to the previous focus area, wrapping from the input to the last
 func (m *AppModel) cycleFocusBackward() tea.Cmd {
-	m.recordNavigation(m.focusState, "shift+tab")
+	m.navigate("shift+tab", func() { m.cycleFocus(-1) })
+	return nil
+}
 
-	// Cycle backward through focus states
-	switch m.focusState {
-	case FocusInput:
-		if len(m.collapsibleElements) > 0 {
-			m.SetFocus(FocusExpandable)
-			m.currentFocusIndex = len(m.collapsibleElements) - 1
-		} else if len(m.renderedContent) > 0 {
-			m.SetFocus(FocusContent)
-		} else if m.actionsPane.IsVisible() {
-			m.SetFocus(FocusActions)
+// cycleFocus moves focus by one area in the order of the focusable elements. Tab and Shift+Tab walk
+// the same ring in opposite directions, so one always undoes the other; areas with nothing to focus are skipped.
+func (m *AppModel) cycleFocus(direction int) {
+	m.updateFocusableElements()
+	areas := m.focusAreas()
+
+	current := 0
+	for i, area := range areas {
+		if area == m.focusState {
+			current = i
+			break
 		}
+	}
+	next := areas[(current+direction+len(areas))%len(areas)]
 
+	// Entering an area going backward starts at its last element
+	atEnd := direction < 0
+	switch next {
 	case FocusActions:
-		m.SetFocus(FocusInput)
-
-	case FocusContent:
-		if m.actionsPane.IsVisible() {
-			m.SetFocus(FocusActions)
+		m.SetFocus(FocusActions)
+		if atEnd {
+			m.actionsPane.Select(len(m.actionsPane.Actions()) - 1)
 		} else {
-			m.SetFocus(FocusInput)
+			m.actionsPane.Select(0)
 		}
-
 	case FocusExpandable:
-		if len(m.renderedContent) > 0 {
-			m.SetFocus(FocusContent)
-		} else if m.actionsPane.IsVisible() {
-			m.SetFocus(FocusActions)
+		index := 0
+		if atEnd {
+			index = len(m.collapsibleElements) - 1
+		}
+		m.focusSection(index)
+	case FocusForm:
+		forms := m.formIDs()
+		if atEnd {
+			m.focusForm(forms[len(forms)-1], true)
 		} else {
-			m.SetFocus(FocusInput)
+			m.focusForm(forms[0], false)
+		}
+	case FocusTree:
+		trees := m.treeIDs()
+		if atEnd {
+			m.focusTree(trees[len(trees)-1], true)
+		} else {
+			m.focusTree(trees[0], false)
 		}
-
 	default:
-		m.SetFocus(FocusInput)
+		m.SetFocus(next)
+	}
+}
+
+// focusAreas returns the focus states that have something to focus, in the order of the focusable elements
+func (m *AppModel) focusAreas() []FocusState {
+	var areas []FocusState
+	for _, element := range m.focusableElements {
+		var area FocusState
+		switch element.Type {
+		case "action":
+			area = FocusActions
+		case "content":
+			area = FocusContent
+		case "collapsible":
+			area = FocusExpandable
+		case "tree":
+			area = FocusTree
+		case "form":
+			area = FocusForm
+		default:
+			area = FocusInput
+		}
+		if len(areas) == 0 || areas[len(areas)-1] != area {
+			areas = append(areas, area)
+		}
+	}
+	if len(areas) == 0 {
+		areas = append(areas, FocusInput)
 	}
+	return areas
+}
 
+// focusSection moves focus to the collapsible section at the given index
+func (m *AppModel) focusSection(index int) {
+	if index < 0 || index >= len(m.collapsibleElements) {
+		return
+	}
+	m.SetFocus(FocusExpandable)
+	m.currentFocusIndex = index
+	m.focusedSectionID = m.collapsibleElements[index].ID
+}
+
+// jumpToSection moves focus directly to the collapsible section shown with the given number
+func (m *AppModel) jumpToSection(number int) tea.Cmd {
+	if number < 1 || number > len(m.collapsibleElements) {
+		m.statusMessage = fmt.Sprintf("No section %d (%d available)", number, len(m.collapsibleElements))
+		return nil
+	}
+	m.navigate("number", func() { m.focusSection(number - 1) })
+	m.statusMessage = ""
 	return nil
 }
 
 // handleEscapeKey returns focus to the input component from any other focused element
 func (m *AppModel) handleEscapeKey() tea.Cmd {
+	// Esc ends a watch before anything else
+	if m.watch != nil {
+		return m.stopWatch()
+	}
+
 	// If an error is active, Esc dismisses it
 	if m.recoveryManager.IsActive() {
 		m.clearStatus()
@@ -339,8 +598,7 @@ func (m *AppModel) handleEscapeKey() tea.Cmd {
 	}
 
 	if m.focusState != FocusInput {
-		m.recordNavigation(m.focusState, "escape")
-		m.SetFocus(FocusInput)
+		m.navigate("escape", func() { m.SetFocus(FocusInput) })
 	}
 	return nil
 }
@@ -354,6 +612,7 @@ func (m *AppModel) navigateInputHistory(direction int) tea.Cmd {
 	}
 
 	newIndex := m.inputHistoryIndex + direction
+	m.suggestion = ""
 
 	// Handle boundary conditions
 	if direction < 0 {
@@ -407,14 +666,16 @@ func (m *AppModel) navigateExpandableElements(direction int) tea.Cmd {
 
 // scrollContent scrolls the content display by the specified number of lines
 func (m *AppModel) scrollContent(lines int) tea.Cmd {
-	newOffset := m.scrollOffset + lines
+	maxOffset := m.maxScrollOffset()
 
-	// Ensure scroll offset stays within bounds
-	maxOffset := len(m.renderedContent) - m.maxDisplayLines
-	if maxOffset < 0 {
-		maxOffset = 0
+	// Scrolling starts from the bottom when following the latest output
+	offset := m.scrollOffset
+	if !m.scrolledBack {
+		offset = maxOffset
 	}
 
+	// Ensure scroll offset stays within bounds
+	newOffset := offset + lines
 	if newOffset < 0 {
 		newOffset = 0
 	} else if newOffset > maxOffset {
@@ -422,23 +683,32 @@ func (m *AppModel) scrollContent(lines int) tea.Cmd {
 	}
 
 	m.scrollOffset = newOffset
+	// Returning to the bottom resumes following, unless follow was turned off explicitly
+	m.scrolledBack = newOffset < maxOffset || !m.autoScroll
 	return nil
 }
 
 // scrollToTop scrolls to the beginning of the content
 func (m *AppModel) scrollToTop() tea.Cmd {
 	m.scrollOffset = 0
+	m.scrolledBack = m.maxScrollOffset() > 0
 	return nil
 }
 
 // scrollToBottom scrolls to the end of the content
 func (m *AppModel) scrollToBottom() tea.Cmd {
-	maxOffset := len(m.renderedContent) - m.maxDisplayLines
+	m.scrollOffset = m.maxScrollOffset()
+	m.scrolledBack = !m.autoScroll // stay pinned here when follow is off
+	return nil
+}
+
+// maxScrollOffset returns the largest scroll offset that still fills the history pane
+func (m *AppModel) maxScrollOffset() int {
+	maxOffset := m.historyLines - m.maxDisplayLines
 	if maxOffset < 0 {
-		maxOffset = 0
+		return 0
 	}
-	m.scrollOffset = maxOffset
-	return nil
+	return maxOffset
 }
 
 // Action execution methods
@@ -446,6 +716,9 @@ func (m *AppModel) scrollToBottom() tea.Cmd {
 // executeActionByNumber executes an action by its numbered position
 func (m *AppModel) executeActionByNumber(number int) tea.Cmd {
 	index := number - 1 // Convert to zero-based index
+	if !m.actionsPane.Select(index) {
+		return m.showError(fmt.Sprintf("No action numbered %d", number))
+	}
 	return m.ExecuteAction(index)
 }
 
@@ -506,6 +779,8 @@ func (m *AppModel) collapseFocusedSection() tea.Cmd {
 
 // handleCommandExecuted processes the result of command execution
 func (m *AppModel) handleCommandExecuted(msg commandExecutedMsg) tea.Cmd {
+	m.finishRunning(msg.seq)
+
 	// Update connection statistics
 	m.connectionStats.TotalCommands++
 	if msg.success {
@@ -515,8 +790,12 @@ func (m *AppModel) handleCommandExecuted(msg commandExecutedMsg) tea.Cmd {
 	}
 	m.connectionStats.LastCommandTime = time.Now()
 
-	// Update average response time
+	m.recordOperation(operationType(msg.command), msg.command, msg.duration, msg.success, operationError(msg.error, msg.structuredError),
+		map[string]interface{}{"cached": msg.cached})
+
+	// Update the latency histogram, warning about slow commands, then the average response time
 	if msg.duration > 0 {
+		m.recordCommandLatency(msg.command, msg.duration)
 		if m.connectionStats.AverageResponseTime == 0 {
 			m.connectionStats.AverageResponseTime = msg.duration
 		} else {
@@ -532,9 +811,21 @@ func (m *AppModel) handleCommandExecuted(msg commandExecutedMsg) tea.Cmd {
 		Timestamp: time.Now(),
 		Command:   msg.command,
 		Duration:  msg.duration,
+		Cached:    msg.cached,
+		seq:       msg.seq,
+	}
+
+	// Show a command sent by /diff against its earlier output
+	if base, ok := m.pendingDiffs[msg.command]; ok {
+		delete(m.pendingDiffs, msg.command)
+		historyEntry.diffBase = base
 	}
 
 	if msg.success && msg.response != nil {
+		if msg.cacheCommand != "" {
+			m.cacheResponse(msg.cacheCommand, msg.response)
+		}
+
 		historyEntry.Response = msg.response
 		historyEntry.Actions = msg.response.Actions
 		historyEntry.Workflow = msg.response.Workflow
@@ -545,8 +836,12 @@ func (m *AppModel) handleCommandExecuted(msg commandExecutedMsg) tea.Cmd {
 		m.workflowManager.UpdateState(msg.response.Workflow)
 
 		// Process response content through content renderer
-		m.addToHistory(historyEntry) // Add to history before rendering content
-		return m.renderResponseContent(historyEntry.Response)
+		historyEntry = m.addToHistory(historyEntry) // Add to history before rendering content
+		poll := m.trackProgressOperations(historyEntry.seq, historyEntry.Response)
+		if msg.redirect != nil {
+			return tea.Batch(m.renderResponseContent(historyEntry.seq, historyEntry.Response), m.applyRedirect(msg.redirect, msg.response), poll)
+		}
+		return tea.Batch(m.renderResponseContent(historyEntry.seq, historyEntry.Response), poll)
 	} else {
 		// Implement correct error handling logic.
 		var processedErr *errors.ProcessedError
@@ -560,17 +855,26 @@ func (m *AppModel) handleCommandExecuted(msg commandExecutedMsg) tea.Cmd {
 			processedErr, _ = m.errorHandler.ProcessErrorResponse(&errResp)
 		}
 
+		// Failed commands can always be corrected and resent, whatever the application suggests
+		if !strings.HasPrefix(msg.command, "/") {
+			processedErr.RecoveryActions = append(processedErr.RecoveryActions, interfaces.Action{
+				Name:    "Edit and retry",
+				Command: "/edit-last",
+				Type:    "info",
+				Icon:    "✏️",
+			})
+		}
+
 		historyEntry.Error = processedErr
-		m.currentError = processedErr
-		m.recoveryManager.StartSession(processedErr)
-		m.actionsPane.SetActions(m.recoveryManager.GetRecoveryActions())
+		m.setCurrentError(processedErr)
+		m.reportRetryBudget()
 		m.workflowManager.EndWorkflow()
 
 		m.addToHistory(historyEntry)
 	}
 
-	// Auto-scroll to bottom if enabled
-	if m.autoScroll {
+	// Follow new output unless the user has scrolled back to read earlier history
+	if m.following() {
 		return m.scrollToBottom()
 	}
 
@@ -585,6 +889,9 @@ func (m *AppModel) handleActionExecuted(msg actionExecutedMsg) tea.Cmd {
 	// Update connection statistics
 	m.connectionStats.TotalActions++
 
+	m.recordOperation("action", msg.action.Name, msg.duration, msg.success, operationError(msg.error, msg.structuredError),
+		map[string]interface{}{"command": msg.action.Command})
+
 	if msg.success && msg.response != nil {
 		// Create history entry for the action
 		historyEntry := HistoryEntry{
@@ -594,6 +901,7 @@ func (m *AppModel) handleActionExecuted(msg actionExecutedMsg) tea.Cmd {
 			Actions:   msg.response.Actions,
 			Workflow:  msg.response.Workflow,
 			Duration:  msg.duration,
+			seq:       msg.seq,
 		}
 
 		// Update current response state
@@ -602,10 +910,10 @@ func (m *AppModel) handleActionExecuted(msg actionExecutedMsg) tea.Cmd {
 		m.workflowManager.UpdateState(msg.response.Workflow)
 
 		// Add to history
-		m.addToHistory(historyEntry)
+		historyEntry = m.addToHistory(historyEntry)
 
 		// Process response content
-		return m.renderResponseContent(msg.response)
+		return tea.Batch(m.renderResponseContent(historyEntry.seq, msg.response), m.trackProgressOperations(historyEntry.seq, msg.response))
 	} else {
 		// Implement correct error handling logic.
 		var processedErr *errors.ProcessedError
@@ -619,9 +927,8 @@ func (m *AppModel) handleActionExecuted(msg actionExecutedMsg) tea.Cmd {
 			processedErr, _ = m.errorHandler.ProcessErrorResponse(&errResp)
 		}
 
-		m.currentError = processedErr
-		m.recoveryManager.StartSession(processedErr)
-		m.actionsPane.SetActions(m.recoveryManager.GetRecoveryActions())
+		m.setCurrentError(processedErr)
+		m.reportRetryBudget()
 	}
 
 	return nil
@@ -681,59 +988,65 @@ func (m *AppModel) handleApplicationInfo(msg applicationInfoMsg) {
 	m.appVersion = msg.appVersion
 	m.protocolVersion = msg.protocolVersion
 	m.features = msg.features
+	m.commandCatalog = msg.commands
+
+	// The response label may show the application's name
+	m.invalidateHistoryBuffer()
 }
 
 // Content rendering and processing
 
-// renderResponseContent processes response content through the content renderer
-func (m *AppModel) renderResponseContent(response *interfaces.CommandResponse) tea.Cmd {
+// renderResponseContent processes response content through the content renderer. The result is delivered
+// to the history entry with the given sequence number by handleContentRendered.
+func (m *AppModel) renderResponseContent(seq uint64, response *interfaces.CommandResponse) tea.Cmd {
+	theme := m.theme
+	width := m.historyContentWidth()
+
 	return tea.Cmd(func() tea.Msg {
 		// Render content using the content renderer
-		renderedContent, err := m.contentRenderer.RenderContent(
-			response.Response.Content,
-			m.theme,
-		)
+		renderedContent, err := m.contentRenderer.RenderContent(response.Response.Content, theme)
 		if err != nil {
-			return commandExecutedMsg{
-				success: false,
-				error:   fmt.Sprintf("Content rendering failed: %s", err.Error()),
-			}
+			return contentRenderedMsg{seq: seq, error: err.Error()}
 		}
 
-		// Store rendered content in the last history entry
-		if len(m.commandHistory) > 0 {
-			m.commandHistory[len(m.commandHistory)-1].Rendered = renderedContent
-		}
-
-		// Update collapsible elements for focus management
-		m.updateCollapsibleElements(renderedContent)
-
-		return nil // Using nil here, as the update happens in the closure.
+		return contentRenderedMsg{seq: seq, rendered: renderedContent, width: width}
 	})
 }
 
 // Helper methods
 
-// addToHistory adds an entry to the command history
-func (m *AppModel) addToHistory(entry HistoryEntry) {
+// addToHistory adds an entry to the command history, numbering it if no request did, and returns the entry as added
+func (m *AppModel) addToHistory(entry HistoryEntry) HistoryEntry {
+	if entry.seq == 0 {
+		entry.seq = m.nextSequence()
+	}
 	m.commandHistory = append(m.commandHistory, entry)
 
-	// Limit history size
+	// Limit in-memory history size, spilling the oldest entry to the session log
 	if len(m.commandHistory) > m.maxHistorySize {
+		if err := m.historySpill.Append(m.commandHistory[0]); err != nil {
+			m.statusMessage = fmt.Sprintf("History entry dropped: %v", err)
+		}
+		m.dropForms(m.commandHistory[0])
 		m.commandHistory = m.commandHistory[1:]
 	}
 
+	m.invalidateHistoryBuffer()
 	m.lastUpdateTime = time.Now()
+	return entry
 }
 
-// updateCollapsibleElements updates the list of collapsible elements based on rendered content
+// updateCollapsibleElements appends the collapsible elements of rendered content to the list, numbered in order
 func (m *AppModel) updateCollapsibleElements(content []interfaces.RenderedContent) {
-	m.collapsibleElements = []CollapsibleElement{}
 	for i, item := range content {
 		if item.Expanded != nil {
+			title := item.Text
+			if title == "" {
+				title = fmt.Sprintf("Section %d", len(m.collapsibleElements)+1)
+			}
 			element := CollapsibleElement{
 				ID:       item.ID,
-				Title:    fmt.Sprintf("Section %d", i+1),
+				Title:    title,
 				Expanded: *item.Expanded,
 				Position: i,
 			}
@@ -746,22 +1059,6 @@ func (m *AppModel) updateCollapsibleElements(content []interfaces.RenderedConten
 	}
 }
 
-// recordNavigation records a navigation step for user experience analysis
-func (m *AppModel) recordNavigation(fromFocus FocusState, method string) {
-	step := NavigationStep{
-		Timestamp: time.Now(),
-		FromFocus: fromFocus,
-		Method:    method,
-	}
-
-	m.navigationHistory = append(m.navigationHistory, step)
-
-	// Limit navigation history size
-	if len(m.navigationHistory) > 200 {
-		m.navigationHistory = m.navigationHistory[1:]
-	}
-}
-
 // refreshConnection attempts to refresh the connection to the application
 func (m *AppModel) refreshConnection() tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
@@ -0,0 +1,143 @@
+// Package app implements client-side argument checking for Application Mode in the Universal Application Console.
+// When the application's command catalog gives a command an argument hint, such as "<service> [version] [--force]",
+// input for that command is checked against it as it is typed and a problem, such as a missing required
+// argument, is shown in the status line before anything is sent. The check is advisory, since the catalog may be
+// incomplete or looser than the application: the first Enter on input with a problem only warns, and a second
+// Enter on the same input sends it anyway. Required arguments are written <name>, optional ones [name], and a
+// trailing "..." accepts any number more; flags starting with "-" are not counted.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// argSpec is what a catalog argument hint says a command accepts
+type argSpec struct {
+	required []string // Names of the required positional arguments, in order
+	optional int      // Number of optional positional arguments
+	variadic bool     // Whether the last argument may repeat
+}
+
+// splitArgHint splits an argument hint on spaces outside brackets, so "<file name>" stays one argument
+func splitArgHint(hint string) []string {
+	var tokens []string
+	var current strings.Builder
+	depth := 0
+	for _, r := range hint {
+		switch {
+		case r == '<' || r == '[':
+			depth++
+		case (r == '>' || r == ']') && depth > 0:
+			depth--
+		case r == ' ' && depth == 0:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// parseArgSpec reads a catalog argument hint; words outside brackets count as required arguments
+func parseArgSpec(hint string) argSpec {
+	var spec argSpec
+	for _, token := range splitArgHint(hint) {
+		if strings.HasSuffix(token, "...") || strings.HasSuffix(strings.Trim(token, "<>[]"), "...") {
+			spec.variadic = true
+			token = strings.TrimSuffix(token, "...")
+			if token == "" {
+				continue
+			}
+		}
+
+		name := strings.TrimSpace(strings.Trim(token, "<>[]"))
+		if name == "" || strings.HasPrefix(name, "-") {
+			continue
+		}
+		if strings.HasPrefix(token, "[") {
+			spec.optional += strings.Count(token, "[") // "[name [value]]" allows two
+		} else {
+			spec.required = append(spec.required, strings.TrimSuffix(name, "..."))
+		}
+	}
+	return spec
+}
+
+// catalogCommandFor finds the catalog command the input invokes, preferring the longest matching name, and
+// returns it with the number of input words its name takes up
+func (m *AppModel) catalogCommandFor(fields []string) (*interfaces.CommandInfo, int) {
+	var match *interfaces.CommandInfo
+	matched := 0
+	for i := range m.commandCatalog {
+		nameFields := strings.Fields(m.commandCatalog[i].Name)
+		if len(nameFields) <= matched || len(nameFields) > len(fields) {
+			continue
+		}
+		if strings.Join(fields[:len(nameFields)], " ") == strings.Join(nameFields, " ") {
+			match, matched = &m.commandCatalog[i], len(nameFields)
+		}
+	}
+	return match, matched
+}
+
+// inputHint checks the command input against the catalog's argument hint, returning the problem or ""
+func (m *AppModel) inputHint() string {
+	if m.focusState != FocusInput {
+		return ""
+	}
+	fields := strings.Fields(m.commandInput.Value())
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "/") {
+		return ""
+	}
+
+	command, nameWords := m.catalogCommandFor(fields)
+	if command == nil || strings.TrimSpace(command.Args) == "" {
+		return ""
+	}
+
+	var args []string
+	for _, field := range fields[nameWords:] {
+		if !strings.HasPrefix(field, "-") {
+			args = append(args, field)
+		}
+	}
+
+	spec := parseArgSpec(command.Args)
+	usage := catalogSynopsis(*command)
+	if len(args) < len(spec.required) {
+		return fmt.Sprintf("Missing required arg: %s (usage: %s)", spec.required[len(args)], usage)
+	}
+	if most := len(spec.required) + spec.optional; !spec.variadic && len(args) > most {
+		return fmt.Sprintf("Too many args: %s takes at most %d (usage: %s)", command.Name, most, usage)
+	}
+	return ""
+}
+
+// warnBeforeSending reports whether a command with an argument problem should be held back with a warning.
+// Only the first Enter is held back, so that the catalog can be overridden by pressing Enter again.
+func (m *AppModel) warnBeforeSending(command string) bool {
+	if m.inputHint() == "" || m.warnedInput == command {
+		m.warnedInput = ""
+		return false
+	}
+	m.warnedInput = command
+	return true
+}
+
+// inputHintStatus returns the argument problem for the status line, noting how to send the input anyway
+func (m *AppModel) inputHintStatus() string {
+	hint := m.inputHint()
+	if hint != "" && m.warnedInput == strings.TrimSpace(m.commandInput.Value()) {
+		hint += "; press Enter again to send anyway"
+	}
+	return hint
+}
@@ -0,0 +1,130 @@
+// Package app implements client-side context variables for Application Mode in the Universal Application Console.
+// Backends that keep a "current directory" or "current namespace" per session would otherwise need session
+// state of their own. Instead the console holds a set of variables, starting from the profile's variables and
+// changed with /set, and merges them into the context of every command and action request. Values set by the
+// request itself, such as form fields or the workflow step, take precedence. The variables are shown in the header.
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/config"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// newContextVariables copies the profile's variables so that /set does not change the profile
+func newContextVariables(profile *interfaces.Profile) map[string]string {
+	variables := make(map[string]string, len(profile.Variables))
+	for name, value := range profile.Variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+// variableNames returns the names of the context variables in lexical order
+func (m *AppModel) variableNames() []string {
+	names := make([]string, 0, len(m.variables))
+	for name := range m.variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withVariables returns a request context holding the context variables overlaid with the given values,
+// or nil when both are empty
+func (m *AppModel) withVariables(values map[string]interface{}) map[string]interface{} {
+	if len(m.variables) == 0 && len(values) == 0 {
+		return nil
+	}
+
+	context := make(map[string]interface{}, len(m.variables)+len(values))
+	for name, value := range m.variables {
+		context[name] = value
+	}
+	for key, value := range values {
+		context[key] = value
+	}
+	return context
+}
+
+// setVariables handles /set. Without arguments it lists the variables; otherwise each name=value argument
+// sets a variable and name= removes it. Words without "=" continue the previous value, so values may
+// contain spaces.
+func (m *AppModel) setVariables(args []string) tea.Cmd {
+	if len(args) == 0 {
+		return m.showVariables()
+	}
+
+	type assignment struct{ name, value string }
+	var assignments []assignment
+	for _, arg := range args {
+		name, value, found := strings.Cut(arg, "=")
+		if !found {
+			if len(assignments) == 0 {
+				return m.showError("Usage: /set [name=value ...] (name= removes a variable)")
+			}
+			assignments[len(assignments)-1].value += " " + arg
+			continue
+		}
+		if err := config.ValidateVariableName(name); err != nil {
+			return m.showError(err.Error())
+		}
+		assignments = append(assignments, assignment{name, value})
+	}
+
+	var changes []string
+	for _, a := range assignments {
+		if a.value == "" {
+			delete(m.variables, a.name)
+			changes = append(changes, "removed "+a.name)
+		} else {
+			m.variables[a.name] = a.value
+			changes = append(changes, fmt.Sprintf("%s=%s", a.name, a.value))
+		}
+	}
+	// Cached responses were fetched with the old variables
+	m.clearResponseCache()
+	m.statusMessage = "Context: " + strings.Join(changes, ", ")
+	return nil
+}
+
+// showVariables lists the context variables in history
+func (m *AppModel) showVariables() tea.Cmd {
+	text := "No context variables are set. Use /set name=value to send one with every request."
+	if len(m.variables) > 0 {
+		lines := []string{"--- Context Variables ---"}
+		for _, name := range m.variableNames() {
+			lines = append(lines, fmt.Sprintf("%s=%s", name, m.variables[name]))
+		}
+		text = strings.Join(lines, "\n")
+	}
+
+	return tea.Cmd(func() tea.Msg {
+		return commandExecutedMsg{
+			command: "/set",
+			response: &interfaces.CommandResponse{
+				Response: struct {
+					Type    string      `json:"type"`
+					Content interface{} `json:"content"`
+				}{
+					Type:    "text",
+					Content: text,
+				},
+			},
+			success: true,
+		}
+	})
+}
+
+// variablesSummary renders the context variables for the header, e.g. "dir=/srv ns=prod"
+func (m *AppModel) variablesSummary() string {
+	parts := make([]string, 0, len(m.variables))
+	for _, name := range m.variableNames() {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, m.variables[name]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -31,18 +31,18 @@ var (
 				Padding(1).
 				Height(0) // Will be set dynamically
 
-	// User command styling with "YOU>" prefix
+	// User command styling, the default for the command prefix
 	userCommandStyle = lipgloss.NewStyle().
 				Bold(true).
 				Foreground(lipgloss.Color("#89B4FA"))
 
-	// Application response styling with "APP>" prefix
+	// Application response styling, the default for the response prefix
 	appResponseStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#A6E3A1"))
 
 	// Content styling for rich content rendering
 	contentStyle = lipgloss.NewStyle().
-			MarginLeft(6) // Indent content under APP> prefix
+			MarginLeft(6) // Indent content under the response prefix
 
 	// Collapsible section styling
 	collapsibleHeaderStyle = lipgloss.NewStyle().
@@ -55,6 +55,11 @@ var (
 					Background(lipgloss.Color("#F38BA8")).
 					Padding(0, 1)
 
+	// Focused tree node styling
+	treeNodeFocusedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#89B4FA"))
+
 	collapsibleContentStyle = lipgloss.NewStyle().
 				MarginLeft(2).
 				Foreground(lipgloss.Color("#CDD6F4"))
@@ -72,11 +77,37 @@ var (
 				Padding(0, 1).
 				Width(0) // Will be set dynamically
 
+	// Confirmation dialog styling for destructive operations
+	confirmationDialogStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#F9E2AF")).
+				Padding(0, 1)
+
+	confirmationTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#F9E2AF"))
+
 	// Status and error message styling
 	statusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#A6E3A1")).
 			Italic(true)
 
+	// Warnings returned alongside a successful response
+	warningBlockStyle = lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder(), false, false, false, true).
+				BorderForeground(lipgloss.Color("#F9E2AF")).
+				Foreground(lipgloss.Color("#F9E2AF")).
+				PaddingLeft(1).
+				MarginLeft(6)
+
+	warningDetailsStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#CDD6F4"))
+
+	// Follow-tail paused indicator styling
+	pausedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F9E2AF")).
+			Bold(true)
+
 	// Connection status indicator styling
 	connectedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#A6E3A1")).
@@ -85,6 +116,14 @@ var (
 	disconnectedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#F38BA8")).
 				Bold(true)
+
+	variablesStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F9E2AF"))
+
+	// Marker of responses shown from the cache
+	cachedMarkerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#6C7086")).
+				Italic(true)
 )
 
 // View implements the tea.Model interface to render the complete Application Mode interface
@@ -94,21 +133,42 @@ func (m *AppModel) View() string {
 	m.workflowManager.SetWidth(m.terminalWidth)
 
 	var viewContent []string
+	layout := viewLayout{actionsTop: -1}
+	row := 0
 
 	// Render header with connection status and application information
-	viewContent = append(viewContent, m.renderHeader())
+	header := m.renderHeader()
+	viewContent = append(viewContent, header)
+	row += lipgloss.Height(header)
 
 	// Render workflow breadcrumbs if present
 	if m.workflowManager.IsActive() {
-		viewContent = append(viewContent, m.workflowManager.View())
+		breadcrumbs := m.workflowManager.View()
+		viewContent = append(viewContent, breadcrumbs)
+		row += lipgloss.Height(breadcrumbs)
 	}
 
-	// Render main content history pane
-	viewContent = append(viewContent, m.renderHistoryPane())
-
-	// Render actions pane if actions are available
-	if m.actionsPane.IsVisible() {
-		viewContent = append(viewContent, m.actionsPane.View())
+	// Render main content history pane, recording where its content starts (after border and padding)
+	historyPane, sectionRows := m.renderHistoryPane()
+	if m.historyPaneWidth() < m.terminalWidth-4 {
+		// A pane capped by maxContentWidth is centered, the margins taking up the extra space
+		historyPane = lipgloss.PlaceHorizontal(m.terminalWidth, lipgloss.Center, historyPane)
+	}
+	viewContent = append(viewContent, historyPane)
+	layout.historyTop = row + 2
+	row += lipgloss.Height(historyPane)
+	layout.historyBottom = row - 2
+	layout.sectionRows = sectionRows
+
+	// A pending confirmation, the command palette, or the theme picker replaces the actions pane until dismissed
+	if overlay := m.renderOverlay(); overlay != "" {
+		viewContent = append(viewContent, overlay)
+	} else if m.actionsPane.IsVisible() {
+		// Render actions pane if actions are available
+		actionsView := m.actionsPane.View()
+		viewContent = append(viewContent, actionsView)
+		layout.actionsTop = row
+		row += lipgloss.Height(actionsView)
 	}
 
 	// Render input component
@@ -119,9 +179,48 @@ func (m *AppModel) View() string {
 		viewContent = append(viewContent, statusSection)
 	}
 
+	// Render the status bar with key hints for the current focus as the last line
+	viewContent = append(viewContent, m.renderStatusBar())
+
+	m.layout = layout
+
 	return lipgloss.JoinVertical(lipgloss.Left, viewContent...)
 }
 
+// renderOverlay returns the modal shown in place of the actions pane, or "" if none is open
+func (m *AppModel) renderOverlay() string {
+	if m.pendingConfirmation != nil {
+		return m.renderConfirmationDialog()
+	}
+	if m.palette != nil {
+		return m.renderCommandPalette()
+	}
+	if m.themePicker != nil {
+		return m.renderThemePicker()
+	}
+	if m.appPicker != nil {
+		return m.renderAppPicker()
+	}
+	return ""
+}
+
+// renderConfirmationDialog creates the modal yes/no prompt for a pending destructive operation
+func (m *AppModel) renderConfirmationDialog() string {
+	prompt := m.pendingConfirmation
+	lines := []string{
+		confirmationTitleStyle.Render(prompt.Title),
+		prompt.Message,
+		"",
+		"[y] Yes    [n] No",
+	}
+
+	width := m.terminalWidth - 2
+	if width < 20 {
+		width = 20
+	}
+	return confirmationDialogStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
+
 // renderHeader creates the application header with connection status and metadata
 func (m *AppModel) renderHeader() string {
 	var headerText string
@@ -152,16 +251,33 @@ func (m *AppModel) renderHeader() string {
 		headerText += fmt.Sprintf(" (Protocol %s)", m.protocolVersion)
 	}
 
+	// Show the context variables sent with every request
+	if len(m.variables) > 0 {
+		headerText += " " + variablesStyle.Render("{"+m.variablesSummary()+"}")
+	}
+
+	// Warn when the circuit breaker is failing requests fast
+	switch m.protocolClient.CircuitState() {
+	case "open":
+		headerText += " " + disconnectedStyle.Render("[circuit open]")
+	case "half-open":
+		headerText += " " + disconnectedStyle.Render("[circuit half-open]")
+	}
+
 	return headerStyle.Width(m.terminalWidth).Render(headerText)
 }
 
-// renderHistoryPane creates the scrolling content area with command history and responses
-func (m *AppModel) renderHistoryPane() string {
+// renderHistoryPane creates the scrolling content area with command history and responses.
+// It also returns the rows of visible collapsible section headers, relative to the first content row.
+func (m *AppModel) renderHistoryPane() (string, map[int]string) {
 	var height int
 	if m.terminalHeight > 0 {
 		actionsHeight := lipgloss.Height(m.actionsPane.View())
+		if overlay := m.renderOverlay(); overlay != "" {
+			actionsHeight = lipgloss.Height(overlay)
+		}
 		workflowHeight := lipgloss.Height(m.workflowManager.View())
-		errorHeight := lipgloss.Height(components.RenderErrorPane(m.currentError, m.contentRenderer, m.theme, m.terminalWidth))
+		errorHeight := lipgloss.Height(components.RenderErrorPane(m.currentError, m.errorDetails, m.historyPaneWidth()))
 
 		usedHeight := m.headerHeight + m.inputHeight + actionsHeight + workflowHeight + errorHeight + 2
 		height = m.terminalHeight - usedHeight
@@ -172,90 +288,215 @@ func (m *AppModel) renderHistoryPane() string {
 		height = 20 // Default height
 	}
 
-	var contentLines []string
-
-	// If an error is active, render it at the top of the history pane
-	if m.recoveryManager.IsActive() {
-		contentLines = append(contentLines, components.RenderErrorPane(m.currentError, m.contentRenderer, m.theme, m.terminalWidth))
-		contentLines = append(contentLines, "") // Add spacing
+	// Rows available for content inside the pane's vertical padding
+	viewHeight := height - 2
+	if viewHeight < 1 {
+		viewHeight = 1
 	}
+	m.maxDisplayLines = viewHeight
+
+	sectionRows := make(map[int]string)
 
 	if len(m.commandHistory) == 0 && !m.recoveryManager.IsActive() {
-		emptyMessage := "Connected and ready. Type a command to get started."
+		m.historyLines = 0
 		return historyPaneStyle.
 			Height(height).
-			Width(m.terminalWidth - 4).
-			Render(statusStyle.Render(emptyMessage))
+			Width(m.historyPaneWidth()).
+			Render(m.renderEmptyState(m.historyLineWidth(), viewHeight)), sectionRows
 	}
 
-	for _, entry := range m.commandHistory {
-		contentLines = append(contentLines, m.renderHistoryEntry(entry)...)
+	lineWidth := m.historyLineWidth()
+	lines := m.historyLineBuffer(lineWidth)
+
+	// If an error is active, render it at the top of the history pane
+	if m.recoveryManager.IsActive() {
+		errorLines := flattenHistoryLines([]historyLine{
+			{text: components.RenderErrorPane(m.currentError, m.errorDetails, m.historyPaneWidth())},
+			{}, // Add spacing
+		}, lineWidth)
+		lines = append(errorLines, lines...)
+	}
+	m.historyLines = len(lines)
+
+	// Apply scrolling offset, or follow the most recent content
+	maxOffset := m.maxScrollOffset()
+	start := maxOffset
+	if m.scrolledBack && m.scrollOffset < maxOffset {
+		start = m.scrollOffset
+	}
+	end := start + viewHeight
+	if end > len(lines) {
+		end = len(lines)
 	}
 
-	// Apply scrolling offset
-	if m.scrollOffset > 0 && m.scrollOffset < len(contentLines) {
-		endIndex := m.scrollOffset + height
-		if endIndex > len(contentLines) {
-			endIndex = len(contentLines)
+	// Record where each visible section header landed for mouse hit-testing
+	visibleLines := make([]string, 0, end-start)
+	for i, line := range lines[start:end] {
+		if line.sectionID != "" {
+			sectionRows[i] = line.sectionID
 		}
-		contentLines = contentLines[m.scrollOffset:endIndex]
-	} else if len(contentLines) > height {
-		// Show most recent content
-		contentLines = contentLines[len(contentLines)-height:]
+		visibleLines = append(visibleLines, line.text)
 	}
 
-	content := strings.Join(contentLines, "\n")
+	content := strings.Join(visibleLines, "\n")
+	paneStyle := historyPaneStyle
+
+	// Show a scrollbar and position indicator when content overflows the pane.
+	// The indicator takes the place of the bottom padding row so the pane height is unchanged.
+	if len(lines) > viewHeight {
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(lineWidth).Height(viewHeight).Render(content),
+			" ",
+			components.RenderScrollbar(viewHeight, len(lines), start),
+		)
+
+		percent := 100
+		if maxOffset > 0 {
+			percent = start * 100 / maxOffset
+		}
+		indicator := statusStyle.Render(fmt.Sprintf("[%d%%]", percent))
+		if !m.following() {
+			indicator = pausedStyle.Render("PAUSED (F to follow)") + " " + indicator
+		}
+		content += "\n" + lipgloss.PlaceHorizontal(lineWidth+2, lipgloss.Right, indicator)
+		paneStyle = paneStyle.PaddingBottom(0)
+	}
 
-	return historyPaneStyle.
+	return paneStyle.
 		Height(height).
-		Width(m.terminalWidth - 4).
-		Render(content)
+		Width(m.historyPaneWidth()).
+		Render(content), sectionRows
+}
+
+// historyLineBuffer returns every history entry flattened into physical lines at the given width.
+// The buffer is cached and only rebuilt when history content or the state affecting its rendering changes.
+func (m *AppModel) historyLineBuffer(width int) []historyLine {
+	key := historyBufferKey{
+		version:          m.historyVersion,
+		width:            width,
+		focusState:       m.focusState,
+		focusedSectionID: m.focusedSectionID,
+		focusedTreeID:    m.focusedTreeID,
+		focusedTreeNode:  m.focusedTreeNode,
+		focusedFormID:    m.focusedFormID,
+		showTimestamps:   m.showTimestamps,
+	}
+	if m.historyBuffer != nil && key == m.historyBufferKey {
+		return m.historyBuffer
+	}
+
+	var elements []historyLine
+	for _, entry := range m.commandHistory {
+		elements = append(elements, m.renderHistoryEntry(entry)...)
+	}
+
+	m.historyBuffer = flattenHistoryLines(elements, width)
+	m.historyBufferKey = key
+	return m.historyBuffer
+}
+
+// flattenHistoryLines wraps rendered elements to the given width and splits them into
+// physical lines, so that scrolling operates on real line counts. A section ID stays
+// with the first line of its element, which is the header row.
+func flattenHistoryLines(elements []historyLine, width int) []historyLine {
+	wrapStyle := lipgloss.NewStyle().Width(width)
+	lines := make([]historyLine, 0, len(elements))
+	for _, element := range elements {
+		for i, physical := range strings.Split(wrapStyle.Render(element.text), "\n") {
+			line := historyLine{text: physical}
+			if i == 0 {
+				line.sectionID = element.sectionID
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// historyLineWidth returns the width available for history text, reserving
+// the pane's horizontal padding and a column for the scrollbar
+func (m *AppModel) historyLineWidth() int {
+	width := m.historyPaneWidth() - 2 - 2
+	if width < 10 {
+		width = 10
+	}
+	return width
 }
 
 // renderHistoryEntry creates the visual representation of a single history entry
-func (m *AppModel) renderHistoryEntry(entry HistoryEntry) []string {
-	var lines []string
+func (m *AppModel) renderHistoryEntry(entry HistoryEntry) []historyLine {
+	var lines []historyLine
 
 	// Render user command with timestamp if enabled
-	commandPrefix := "YOU>"
+	commandPrefix := m.userLabel()
 	if m.showTimestamps {
 		timestamp := entry.Timestamp.Format("15:04:05")
-		commandPrefix = fmt.Sprintf("[%s] YOU>", timestamp)
+		commandPrefix = fmt.Sprintf("[%s] %s", timestamp, commandPrefix)
 	}
 
-	commandLine := userCommandStyle.Render(commandPrefix) + " " + entry.Command
-	lines = append(lines, commandLine)
+	commandLine := m.labels.userStyle.Render(commandPrefix) + " " + entry.Command
+	if entry.Cached {
+		commandLine += " " + cachedMarkerStyle.Render("(cached)")
+	}
+	lines = append(lines, historyLine{text: commandLine})
 
 	// Render application response
 	if entry.Error != nil {
 		// Error response is now handled by the main error pane, so we don't duplicate it here.
 		// We could add a simple marker if desired, but the main pane is clearer.
 	} else if entry.Response != nil {
+		// Warnings come first so they are not missed below long content
+		if len(entry.Response.Warnings) > 0 {
+			lines = append(lines, historyLine{text: renderWarnings(entry.Response.Warnings)})
+		}
+
 		// Successful response
 		responseLines := m.renderResponse(entry.Response, entry.Rendered)
 		lines = append(lines, responseLines...)
 	}
 
 	// Add spacing between entries
-	lines = append(lines, "")
+	lines = append(lines, historyLine{})
 
 	return lines
 }
 
-// renderResponse creates the visual representation of an application response
-func (m *AppModel) renderResponse(response *interfaces.CommandResponse, rendered []interfaces.RenderedContent) []string {
+// renderWarnings renders a response's warnings as a yellow block, one per line with any details beneath
+func renderWarnings(warnings []interfaces.StatusContent) string {
 	var lines []string
+	for _, warning := range warnings {
+		icon := warning.Icon
+		if icon == "" {
+			icon = "⚠"
+		}
 
-	responsePrefix := "APP>"
+		line := icon + " " + warning.Message
+		if warning.Code != "" {
+			line += fmt.Sprintf(" [%s]", warning.Code)
+		}
+		lines = append(lines, line)
+
+		if warning.Details != "" {
+			lines = append(lines, warningDetailsStyle.Render("  "+warning.Details))
+		}
+	}
+	return warningBlockStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderResponse creates the visual representation of an application response
+func (m *AppModel) renderResponse(response *interfaces.CommandResponse, rendered []interfaces.RenderedContent) []historyLine {
+	var lines []historyLine
+
+	responsePrefix := m.appLabel()
 	if m.showTimestamps {
-		responsePrefix = fmt.Sprintf("[%s] APP>", time.Now().Format("15:04:05"))
+		responsePrefix = fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), responsePrefix)
 	}
 
 	// Handle simple text responses
 	if response.Response.Type == "text" {
 		if textContent, ok := response.Response.Content.(string); ok {
-			responseLine := appResponseStyle.Render(responsePrefix) + " " + textContent
-			lines = append(lines, responseLine)
+			responseLine := m.labels.appStyle.Render(responsePrefix) + " " + textContent
+			lines = append(lines, historyLine{text: responseLine})
 			return lines
 		}
 	}
@@ -263,7 +504,7 @@ func (m *AppModel) renderResponse(response *interfaces.CommandResponse, rendered
 	// Handle structured content responses
 	if len(rendered) > 0 {
 		// Add response prefix
-		lines = append(lines, appResponseStyle.Render(responsePrefix))
+		lines = append(lines, historyLine{text: m.labels.appStyle.Render(responsePrefix)})
 
 		// Render structured content
 		for _, content := range rendered {
@@ -276,39 +517,81 @@ func (m *AppModel) renderResponse(response *interfaces.CommandResponse, rendered
 }
 
 // renderStructuredContent processes individual structured content elements
-func (m *AppModel) renderStructuredContent(content interfaces.RenderedContent) []string {
-	var lines []string
+func (m *AppModel) renderStructuredContent(content interfaces.RenderedContent) []historyLine {
+	var lines []historyLine
 
 	if content.Expanded != nil {
 		// Collapsible content
 		lines = append(lines, m.renderCollapsibleContent(content)...)
+	} else if content.TreeNodes != nil {
+		// Interactive tree content
+		lines = append(lines, m.renderTreeContent(content)...)
+	} else if content.Form != nil {
+		// Interactive form content
+		lines = append(lines, m.renderFormContent(content)...)
 	} else {
 		// Regular content
 		if content.Text != "" {
 			styledContent := contentStyle.Render(content.Text)
-			lines = append(lines, styledContent)
+			lines = append(lines, historyLine{text: styledContent})
 		}
 	}
 
 	return lines
 }
 
+// renderTreeContent renders a tree line by line, highlighting the focused node
+func (m *AppModel) renderTreeContent(content interfaces.RenderedContent) []historyLine {
+	var lines []historyLine
+
+	isFocusedTree := m.focusState == FocusTree && m.focusedTreeID == content.ID
+	for i, text := range strings.Split(content.Text, "\n") {
+		if isFocusedTree && i == m.focusedTreeNode {
+			text = treeNodeFocusedStyle.Render(text)
+		}
+		lines = append(lines, historyLine{text: contentStyle.Render(text)})
+	}
+
+	return lines
+}
+
+// sectionNumber returns the number a collapsible section is jumped to with, or 0 if it is not in the list
+func (m *AppModel) sectionNumber(sectionID string) int {
+	for i, element := range m.collapsibleElements {
+		if element.ID == sectionID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 // renderCollapsibleContent creates expandable/collapsible content sections
-func (m *AppModel) renderCollapsibleContent(content interfaces.RenderedContent) []string {
-	var lines []string
+func (m *AppModel) renderCollapsibleContent(content interfaces.RenderedContent) []historyLine {
+	var lines []historyLine
 
 	// Determine if this section is focused
 	isFocused := m.focusState == FocusExpandable && m.focusedSectionID == content.ID
 
 	// Create header with expand/collapse indicator
+	// Terminals without Unicode get the ASCII indicators the renderer uses
+	expanded := content.Expanded != nil && *content.Expanded
 	var indicator string
-	if content.Expanded != nil && *content.Expanded {
+	switch {
+	case m.contentRenderer.UnicodeSupport() && expanded:
 		indicator = "▼"
-	} else {
+	case m.contentRenderer.UnicodeSupport():
 		indicator = "▶"
+	case expanded:
+		indicator = "v"
+	default:
+		indicator = ">"
 	}
 
-	headerText := fmt.Sprintf("%s [%s] %s", indicator, "Toggle", content.Text)
+	// Sections are numbered so they can be jumped to with the number keys
+	headerText := fmt.Sprintf("%s %s", indicator, content.Text)
+	if number := m.sectionNumber(content.ID); number > 0 {
+		headerText = fmt.Sprintf("%s [%d] %s", indicator, number, content.Text)
+	}
 
 	var headerLine string
 	if isFocused {
@@ -317,14 +600,14 @@ func (m *AppModel) renderCollapsibleContent(content interfaces.RenderedContent)
 		headerLine = contentStyle.Render(collapsibleHeaderStyle.Render(headerText))
 	}
 
-	lines = append(lines, headerLine)
+	lines = append(lines, historyLine{text: headerLine, sectionID: content.ID})
 
 	// Render content if expanded
 	if content.Expanded != nil && *content.Expanded {
 		// This would contain the nested content
 		// For now, we'll show a placeholder
 		expandedContent := collapsibleContentStyle.Render("• Expanded content would appear here")
-		lines = append(lines, contentStyle.Render(expandedContent))
+		lines = append(lines, historyLine{text: contentStyle.Render(expandedContent)})
 	}
 
 	return lines
@@ -339,34 +622,28 @@ func (m *AppModel) renderInputComponent() string {
 
 	var inputBox string
 	if m.focusState == FocusInput {
-		inputBox = inputFocusedStyle.Width(inputWidth).Render(m.commandInput.View())
+		inputBox = inputFocusedStyle.Width(inputWidth).Render(m.inputView())
 	} else {
 		inputBox = inputStyle.Width(inputWidth).Render(m.commandInput.View())
 	}
 
-	// Add helpful hints below the input
-	var hints []string
-	if m.focusState == FocusInput {
-		hints = append(hints, "Ctrl+↑/↓ for history")
-		if m.actionsPane.IsVisible() {
-			hints = append(hints, "1-9 for quick actions")
-		}
-		hints = append(hints, "Tab to navigate")
-	}
-
-	result := inputBox
-	if len(hints) > 0 {
-		hintText := strings.Join(hints, " • ")
-		result += "\n" + statusStyle.Render(hintText)
-	}
-
-	return result
+	return inputBox
 }
 
 // renderStatusSection creates status messages and connection statistics
 func (m *AppModel) renderStatusSection() string {
 	var statusLines []string
 
+	// Show commands still waiting for a response
+	if running := m.runningStatus(); running != "" {
+		statusLines = append(statusLines, statusStyle.Render(running))
+	}
+
+	// Warn about a problem with the input's arguments before it is sent
+	if hint := m.inputHintStatus(); hint != "" {
+		statusLines = append(statusLines, components.RenderStatus("warning", hint))
+	}
+
 	// Render status messages, but not errors, as they are now in their own pane
 	if m.statusMessage != "" {
 		statusLines = append(statusLines, components.RenderStatus("info", m.statusMessage))
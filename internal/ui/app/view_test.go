@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// textResponse returns a response of plain text
+func textResponse(text string) *interfaces.CommandResponse {
+	response := &interfaces.CommandResponse{}
+	response.Response.Type = "text"
+	response.Response.Content = text
+	return response
+}
+
+// addMixedHeightHistory adds a one-line entry, a forty-line entry, and another one-line entry, which take
+// 3, 42, and 3 lines of the history pane with their command lines and spacing
+func addMixedHeightHistory(m *AppModel) {
+	rows := make([]string, 40)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("row %02d", i+1)
+	}
+	m.addToHistory(HistoryEntry{Command: "first", Response: textResponse("ok")})
+	m.addToHistory(HistoryEntry{Command: "tall", Response: textResponse(strings.Join(rows, "\n"))})
+	m.addToHistory(HistoryEntry{Command: "last", Response: textResponse("done")})
+}
+
+func TestHistoryPaneCountsRealLinesOfMixedHeightEntries(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	addMixedHeightHistory(m)
+
+	view, _ := m.renderHistoryPane()
+	if m.historyLines != 48 {
+		t.Fatalf("history has %d lines, want 48", m.historyLines)
+	}
+
+	// Following the latest output shows the end of the tall entry and the entry after it
+	if !strings.Contains(view, "row 40") || !strings.Contains(view, "last") {
+		t.Errorf("the followed pane does not end with the latest output:\n%s", view)
+	}
+	if strings.Contains(view, "first") {
+		t.Errorf("the followed pane shows the oldest entry although the tall one fills it:\n%s", view)
+	}
+	if offset := m.maxScrollOffset(); offset != 48-m.maxDisplayLines {
+		t.Errorf("maxScrollOffset = %d, want %d", offset, 48-m.maxDisplayLines)
+	}
+}
+
+func TestHistoryPaneWindowsByLineOffset(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	addMixedHeightHistory(m)
+	m.renderHistoryPane()
+
+	// Line 10 is the seventh row of the tall entry, after 3 lines of the first entry and its command line
+	m.scrolledBack = true
+	m.scrollOffset = 10
+	view, _ := m.renderHistoryPane()
+	if !strings.Contains(view, "row 07") || strings.Contains(view, "row 06") {
+		t.Errorf("the pane scrolled to line 10 does not start at row 07:\n%s", view)
+	}
+	if last := fmt.Sprintf("row %02d", 6+m.maxDisplayLines); !strings.Contains(view, last) {
+		t.Errorf("the pane scrolled to line 10 does not fill %d rows to %s:\n%s", m.maxDisplayLines, last, view)
+	}
+
+	m.scrollToBottom()
+	view, _ = m.renderHistoryPane()
+	if !strings.Contains(view, "last") {
+		t.Errorf("scrolling to the bottom does not show the latest entry:\n%s", view)
+	}
+}
+
+func TestHistoryBufferIsRebuiltWhenHistoryChanges(t *testing.T) {
+	m := newTestModel(t, &interfaces.Profile{Name: "demo", Host: "demo.example"})
+	addMixedHeightHistory(m)
+	m.renderHistoryPane()
+
+	m.addToHistory(HistoryEntry{Command: "more", Response: textResponse("one\ntwo\nthree")})
+	m.renderHistoryPane()
+	if m.historyLines != 48+5 {
+		t.Errorf("history has %d lines after adding a three-line entry, want %d", m.historyLines, 48+5)
+	}
+}
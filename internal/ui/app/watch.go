@@ -0,0 +1,205 @@
+// Package app implements diffs of repeated commands for Application Mode in the Universal Application Console.
+// /diff <command> runs a command past the response cache and shows its output as a line diff against the
+// output of the same command earlier in history. /watch <interval> <command> runs a command every interval,
+// like the Unix watch tool, and keeps one history entry for it, replaced in place with each run's output shown
+// as a diff against the run before, which turns the console into a lightweight monitor. The status bar shows
+// how many times the command has run and when its output last arrived. Escape, any other command, or
+// /watch stop ends the watch. Diffed output is plain text, so its collapsible sections, trees, and forms are
+// not interactive.
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// minWatchInterval is the shortest interval a watched command is run at
+const minWatchInterval = time.Second
+
+// commandWatch is a command run again and again by /watch
+type commandWatch struct {
+	command    string
+	interval   time.Duration
+	generation int       // Distinguishes this watch's ticks and results from those of earlier watches
+	seq        uint64    // Sequence number of the history entry the watch updates; 0 before the first result
+	iterations int       // Runs that have finished, successful or not
+	updated    time.Time // When the latest successful run's output arrived
+}
+
+// watchTickMsg starts the next run of a watched command
+type watchTickMsg struct {
+	generation int
+}
+
+// watchResultMsg carries the result of one run of a watched command
+type watchResultMsg struct {
+	generation int
+	response   *interfaces.CommandResponse
+	err        error
+	duration   time.Duration
+}
+
+// diffCommand handles /diff <command>, running the command and showing what changed since its last output
+func (m *AppModel) diffCommand(args []string) tea.Cmd {
+	command := strings.Join(args, " ")
+	if strings.HasPrefix(command, "/") {
+		return m.showError("Only application commands can be compared")
+	}
+
+	previous := m.latestOutputOf(command)
+	if previous == nil {
+		return m.showError(fmt.Sprintf("No earlier output of '%s' to compare with; run it first", command))
+	}
+	m.pendingDiffs[command] = previous.Response
+	return m.executeCommand(command, false)
+}
+
+// latestOutputOf returns the most recent history entry with a response to the command, or nil
+func (m *AppModel) latestOutputOf(command string) *HistoryEntry {
+	for i := len(m.commandHistory) - 1; i >= 0; i-- {
+		if m.commandHistory[i].Command == command && m.commandHistory[i].Response != nil {
+			return &m.commandHistory[i]
+		}
+	}
+	return nil
+}
+
+// applyDiff shows an entry's rendered content as a diff against the earlier output, when it has one
+func (m *AppModel) applyDiff(entry *HistoryEntry) {
+	if entry.diffBase == nil {
+		return
+	}
+	base, err := m.contentRenderer.RenderContent(entry.diffBase.Response.Content, m.theme)
+	if err == nil {
+		entry.Rendered = m.contentRenderer.RenderDiff(base, entry.Rendered, m.theme)
+	}
+}
+
+// watchCommand handles /watch <interval> <command> and /watch stop; the interval may also follow the command
+func (m *AppModel) watchCommand(args []string) tea.Cmd {
+	if len(args) == 0 || (len(args) == 1 && args[0] == "stop") {
+		return m.stopWatch()
+	}
+	if len(args) < 2 {
+		return m.showError("Usage: /watch <interval> <command>")
+	}
+
+	var command string
+	interval, err := parseTimeoutValue(args[0])
+	if err == nil {
+		command = strings.Join(args[1:], " ")
+	} else if interval, err = parseTimeoutValue(args[len(args)-1]); err == nil {
+		command = strings.Join(args[:len(args)-1], " ")
+	} else {
+		return m.showError(fmt.Sprintf("Invalid interval '%s': use seconds (5) or a duration (1m30s)", args[0]))
+	}
+	if interval < minWatchInterval {
+		interval = minWatchInterval
+	}
+
+	if strings.HasPrefix(command, "/") {
+		return m.showError("Only application commands can be watched")
+	}
+
+	m.watchGeneration++
+	m.watch = &commandWatch{command: command, interval: interval, generation: m.watchGeneration}
+	m.statusMessage = fmt.Sprintf("Watching '%s' every %s; Esc stops it", command, interval)
+	return m.runWatch()
+}
+
+// stopWatch ends the active watch; a run already in flight is ignored when it finishes
+func (m *AppModel) stopWatch() tea.Cmd {
+	if m.watch == nil {
+		return m.showError("No command is being watched")
+	}
+	m.statusMessage = fmt.Sprintf("Stopped watching '%s'", m.watch.command)
+	m.watch = nil
+	return nil
+}
+
+// isWatchCommand reports whether a submitted command is /watch, which replaces an active watch rather than ending it
+func isWatchCommand(command string) bool {
+	fields := strings.Fields(command)
+	return len(fields) > 0 && fields[0] == "/watch"
+}
+
+// watchStatus summarizes the active watch for the status bar
+func (m *AppModel) watchStatus() string {
+	status := fmt.Sprintf("◉ Watching '%s' · #%d", m.watch.command, m.watch.iterations)
+	if !m.watch.updated.IsZero() {
+		status += " · updated " + m.watch.updated.Format("15:04:05")
+	}
+	return status
+}
+
+// runWatch sends the watched command once
+func (m *AppModel) runWatch() tea.Cmd {
+	watch := m.watch
+	request := interfaces.CommandRequest{
+		Command: watch.command,
+		Context: m.withVariables(nil),
+	}
+	timeout := m.commandTimeout()
+
+	return func() tea.Msg {
+		startTime := time.Now()
+
+		ctx, done := m.beginRequest("command", watch.command, timeout)
+		defer done()
+
+		response, err := m.protocolClient.ExecuteCommand(ctx, request)
+		return watchResultMsg{generation: watch.generation, response: response, err: err, duration: time.Since(startTime)}
+	}
+}
+
+// handleWatchTick runs the watched command again while the watch is active and the session connected
+func (m *AppModel) handleWatchTick(msg watchTickMsg) tea.Cmd {
+	if m.watch == nil || msg.generation != m.watch.generation || !m.connected {
+		return nil
+	}
+	return m.runWatch()
+}
+
+// handleWatchResult updates the watch's history entry with the latest output, diffed against the run before,
+// and schedules the next run
+func (m *AppModel) handleWatchResult(msg watchResultMsg) tea.Cmd {
+	watch := m.watch
+	if watch == nil || msg.generation != watch.generation {
+		return nil
+	}
+
+	watch.iterations++
+	next := tea.Tick(watch.interval, func(time.Time) tea.Msg {
+		return watchTickMsg{generation: msg.generation}
+	})
+
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Watching '%s': the latest run failed: %v", watch.command, msg.err)
+		return next
+	}
+	watch.updated = time.Now()
+
+	entry := m.historyEntryBySequence(watch.seq)
+	if entry == nil || entry.Response == nil {
+		added := m.addToHistory(HistoryEntry{
+			Timestamp: watch.updated,
+			Command:   watch.command,
+			Response:  msg.response,
+			Duration:  msg.duration,
+		})
+		watch.seq = added.seq
+		return tea.Batch(m.renderResponseContent(added.seq, msg.response), m.trackProgressOperations(added.seq, msg.response), next)
+	}
+
+	entry.diffBase = entry.Response
+	entry.Timestamp = watch.updated
+	entry.Response = msg.response
+	entry.Duration = msg.duration
+	m.invalidateHistoryBuffer()
+
+	return tea.Batch(m.renderResponseContent(entry.seq, msg.response), m.trackProgressOperations(entry.seq, msg.response), next)
+}
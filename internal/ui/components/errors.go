@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/content"
 	"github.com/universal-console/console/internal/errors"
 	"github.com/universal-console/console/internal/interfaces"
 )
@@ -29,6 +30,10 @@ var (
 			Foreground(lipgloss.Color("#FAB387")).
 			Italic(true)
 
+	errorCodeValueStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#FAB387"))
+
 	errorDetailsStyle = lipgloss.NewStyle().
 				MarginTop(1).
 				Border(lipgloss.NormalBorder(), true, false, false, false).
@@ -41,13 +46,32 @@ var (
 				MarginTop(1)
 )
 
+// RenderErrorDetails renders an error's details block for display in the error pane. Details whose
+// content is an array of content blocks render every block. It is called once when the error is
+// received so that interactive content is registered with the renderer only once.
+func RenderErrorDetails(
+	details *interfaces.ContentBlock,
+	contentRenderer interfaces.ContentRenderer,
+	theme *interfaces.Theme,
+) []interfaces.RenderedContent {
+	if details == nil {
+		return nil
+	}
+
+	rendered, err := contentRenderer.RenderContent(content.ErrorDetailContent(details), theme)
+	if err != nil {
+		// Show the raw details rather than dropping them
+		return []interfaces.RenderedContent{{Text: fmt.Sprintf("%v", details.Content)}}
+	}
+	return rendered
+}
+
 // RenderErrorPane renders a complete error presentation, including the main message,
-// code, details, and a title for the recovery actions that will be displayed
+// code, pre-rendered details, and a title for the recovery actions that will be displayed
 // separately in the Actions Pane.
 func RenderErrorPane(
 	currentError *errors.ProcessedError,
-	contentRenderer interfaces.ContentRenderer,
-	theme *interfaces.Theme,
+	details []interfaces.RenderedContent,
 	width int,
 ) string {
 	if currentError == nil {
@@ -61,34 +85,33 @@ func RenderErrorPane(
 	builder.WriteString(errorHeaderStyle.Render(header))
 	builder.WriteRune('\n')
 
-	// Render Code, if available
+	// Render Code, if available, as a field the user can copy
 	if currentError.Code != "" {
-		code := fmt.Sprintf("   Code: %s", currentError.Code)
-		builder.WriteString(errorCodeStyle.Render(code))
+		builder.WriteString(errorCodeStyle.Render("   Code: "))
+		builder.WriteString(errorCodeValueStyle.Render(currentError.Code))
+		builder.WriteString(errorCodeStyle.Render("  (/copy-code to copy)"))
 		builder.WriteRune('\n')
 	}
 
-	// Render Details, if available
-	if currentError.Details != nil {
-		// Delegate rendering of the structured details block to the content renderer.
-		detailsContent, err := contentRenderer.RenderContent(
-			[]interfaces.ContentBlock{*currentError.Details},
-			theme,
-		)
-		if err == nil {
-			var detailsText []string
-			for _, line := range detailsContent {
-				detailsText = append(detailsText, line.Text)
-			}
-			details := errorDetailsStyle.Render(strings.Join(detailsText, "\n"))
-			builder.WriteString(details)
-			builder.WriteRune('\n')
+	// Render every block of the details
+	if len(details) > 0 {
+		var detailsText []string
+		for _, block := range details {
+			detailsText = append(detailsText, block.Text)
 		}
+		builder.WriteString(errorDetailsStyle.Render(strings.Join(detailsText, "\n")))
+		builder.WriteRune('\n')
 	}
 
 	// Render title for the recovery actions
-	if len(currentError.RecoveryActions) > 0 {
-		builder.WriteString(recoveryTitleStyle.Render("Recovery Actions:"))
+	if count := len(currentError.RecoveryActions); count == 1 {
+		builder.WriteString(recoveryTitleStyle.Render("Recovery Actions: press 1 to run, Esc to dismiss"))
+	} else if count > 1 {
+		limit := count
+		if limit > 9 {
+			limit = 9
+		}
+		builder.WriteString(recoveryTitleStyle.Render(fmt.Sprintf("Recovery Actions: press 1-%d to run, Esc to dismiss", limit)))
 	}
 
 	return errorPaneStyle.Width(width - 4).Render(builder.String())
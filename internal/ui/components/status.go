@@ -1,6 +1,6 @@
 // Package components provides shared, reusable interface elements for the
-// Universal Application Console. This file implements visual status indicators
-// and progress displays as described in section 3.2.1 of the design document,
+// Universal Application Console. This file implements visual status indicators,
+// progress displays, and scrollbars as described in section 3.2.1 of the design document,
 // enhancing user confidence with real-time feedback.
 package components
 
@@ -74,11 +74,58 @@ func RenderProgressBar(progress int, width int, fillChar, emptyChar string) stri
 	return fmt.Sprintf("[%s%s]", filled, empty)
 }
 
-// RenderSpinner returns a spinner model from the charmbracelet/bubbles library.
-// Note: This would require adding the spinner bubble as a dependency and managing its
-// state within the calling model's Update function. This is a placeholder for that pattern.
-func RenderSpinner() string {
-	// In a real implementation, you would return a spinner.Model
-	// and manage its Ticks via commands. For a static component, we return a char.
-	return "⏳"
+// spinnerFrames are the animation frames of the in-flight spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// RenderSpinner returns the spinner character for an animation frame. The caller advances the
+// frame on a timer, such as a tea.Tick, while work is in progress.
+func RenderSpinner(frame int) string {
+	if frame < 0 {
+		frame = -frame
+	}
+	return spinnerFrames[frame%len(spinnerFrames)]
+}
+
+// RenderScrollbar creates a vertical scrollbar one character wide.
+// - height: The number of rows the scrollbar spans.
+// - total: The total number of content lines.
+// - offset: The index of the first visible content line.
+// The thumb size reflects the visible fraction of content, and its position reflects the offset.
+func RenderScrollbar(height, total, offset int) string {
+	if height <= 0 {
+		return ""
+	}
+
+	trackStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#45475A"))
+	thumbStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#89B4FA"))
+
+	// Content fits entirely, so the thumb fills the track
+	if total <= height {
+		return strings.TrimSuffix(strings.Repeat(thumbStyle.Render("┃")+"\n", height), "\n")
+	}
+
+	thumbSize := height * height / total
+	if thumbSize < 1 {
+		thumbSize = 1
+	}
+
+	maxOffset := total - height
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	thumbStart := offset * (height - thumbSize) / maxOffset
+
+	rows := make([]string, height)
+	for i := range rows {
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			rows[i] = thumbStyle.Render("┃")
+		} else {
+			rows[i] = trackStyle.Render("│")
+		}
+	}
+
+	return strings.Join(rows, "\n")
 }
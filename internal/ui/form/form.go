@@ -0,0 +1,336 @@
+// Package form implements interactive forms for the Universal Application Console.
+// This file holds the state of a form rendered from a "form" content block: a text input per text
+// field, the chosen option of each select field, and the state of each checkbox. The form moves a
+// cursor between its fields and the submit button, edits the focused field, and collects the values
+// that are sent as the context of the form's action when it is submitted.
+package form
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/content"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Styling definitions for forms
+var (
+	formStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#6C7086")).
+			Padding(0, 1)
+
+	formFocusedStyle = formStyle.
+				BorderForeground(lipgloss.Color("#89B4FA"))
+
+	formTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#89B4FA"))
+
+	fieldLabelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#CDD6F4"))
+
+	fieldFocusedLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#89B4FA")).
+				Bold(true)
+
+	optionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6C7086")).
+			Padding(0, 1)
+
+	optionChosenStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#181825")).
+				Background(lipgloss.Color("#89B4FA")).
+				Padding(0, 1)
+
+	submitStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A6E3A1")).
+			Padding(0, 1)
+
+	submitFocusedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#181825")).
+				Background(lipgloss.Color("#A6E3A1")).
+				Padding(0, 1)
+
+	noteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6C7086")).
+			Italic(true)
+)
+
+// maxInputWidth is the widest a text field is drawn, however wide the terminal
+const maxInputWidth = 40
+
+// Model represents the state of one interactive form.
+type Model struct {
+	definition interfaces.RenderedForm
+	inputs     []textinput.Model // Text inputs, by field index; unused for other field types
+	chosen     []int             // Chosen option of each select field, by field index
+	checked    []bool            // State of each checkbox, by field index
+	cursor     int               // Focused field index; len(fields) is the submit button
+	focused    bool
+	submitted  bool
+}
+
+// New creates a form with the fields and initial values of a rendered form.
+func New(definition interfaces.RenderedForm) *Model {
+	fields := definition.Fields
+	m := &Model{
+		definition: definition,
+		inputs:     make([]textinput.Model, len(fields)),
+		chosen:     make([]int, len(fields)),
+		checked:    make([]bool, len(fields)),
+	}
+
+	for i, field := range fields {
+		switch field.Type {
+		case content.FormFieldSelect:
+			for j, option := range field.Options {
+				if option == field.Value {
+					m.chosen[i] = j
+				}
+			}
+		case content.FormFieldCheckbox:
+			m.checked[i] = field.Checked
+		default:
+			input := textinput.New()
+			input.Prompt = ""
+			input.Placeholder = field.Placeholder
+			input.SetValue(field.Value)
+			m.inputs[i] = input
+		}
+	}
+
+	return m
+}
+
+// Focus gives the form keyboard focus, on its first field or on the submit button.
+func (m *Model) Focus(atEnd bool) {
+	m.focused = true
+	m.cursor = 0
+	if atEnd {
+		m.cursor = len(m.definition.Fields)
+	}
+	m.syncInputFocus()
+}
+
+// Blur removes keyboard focus from the form.
+func (m *Model) Blur() {
+	m.focused = false
+	m.syncInputFocus()
+}
+
+// Next moves the cursor to the next field, returning false when it is already on the submit button.
+func (m *Model) Next() bool {
+	if m.cursor >= len(m.definition.Fields) {
+		return false
+	}
+	m.cursor++
+	m.syncInputFocus()
+	return true
+}
+
+// Previous moves the cursor to the previous field, returning false when it is already on the first field.
+func (m *Model) Previous() bool {
+	if m.cursor <= 0 {
+		return false
+	}
+	m.cursor--
+	m.syncInputFocus()
+	return true
+}
+
+// OnSubmit reports whether the cursor is on the submit button.
+func (m *Model) OnSubmit() bool {
+	return m.cursor >= len(m.definition.Fields)
+}
+
+// syncInputFocus focuses the text input under the cursor so only it shows a cursor and takes typing.
+func (m *Model) syncInputFocus() {
+	for i, field := range m.definition.Fields {
+		if field.Type == content.FormFieldSelect || field.Type == content.FormFieldCheckbox {
+			continue
+		}
+		if m.focused && i == m.cursor {
+			m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+}
+
+// Update edits the focused field: typing into text fields, ←/→ to choose an option, and space to toggle a checkbox.
+func (m *Model) Update(msg tea.KeyMsg) tea.Cmd {
+	if m.OnSubmit() {
+		return nil
+	}
+
+	i := m.cursor
+	field := m.definition.Fields[i]
+	switch field.Type {
+	case content.FormFieldSelect:
+		switch msg.String() {
+		case "left", "h":
+			m.chosen[i] = (m.chosen[i] - 1 + len(field.Options)) % len(field.Options)
+		case "right", "l", " ", "space":
+			m.chosen[i] = (m.chosen[i] + 1) % len(field.Options)
+		}
+		return nil
+
+	case content.FormFieldCheckbox:
+		switch msg.String() {
+		case " ", "space", "x":
+			m.checked[i] = !m.checked[i]
+		}
+		return nil
+
+	default:
+		var cmd tea.Cmd
+		m.inputs[i], cmd = m.inputs[i].Update(msg)
+		return cmd
+	}
+}
+
+// Validate returns an error naming the first required field that is empty.
+func (m *Model) Validate() error {
+	for i, field := range m.definition.Fields {
+		if !field.Required {
+			continue
+		}
+		switch field.Type {
+		case content.FormFieldCheckbox:
+			if !m.checked[i] {
+				return fmt.Errorf("'%s' must be checked", field.Label)
+			}
+		case content.FormFieldSelect:
+			// A select field always has an option chosen
+		default:
+			if strings.TrimSpace(m.inputs[i].Value()) == "" {
+				return fmt.Errorf("'%s' is required", field.Label)
+			}
+		}
+	}
+	return nil
+}
+
+// Values returns the field values by name: text for text and select fields, and a boolean for checkboxes.
+func (m *Model) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(m.definition.Fields))
+	for i, field := range m.definition.Fields {
+		switch field.Type {
+		case content.FormFieldSelect:
+			values[field.Name] = field.Options[m.chosen[i]]
+		case content.FormFieldCheckbox:
+			values[field.Name] = m.checked[i]
+		default:
+			values[field.Name] = m.inputs[i].Value()
+		}
+	}
+	return values
+}
+
+// Title returns the form's title.
+func (m *Model) Title() string {
+	return m.definition.Title
+}
+
+// Command returns the action command the form is submitted to.
+func (m *Model) Command() string {
+	return m.definition.Command
+}
+
+// MarkSubmitted records that the form's values were sent. The form stays editable so it can be sent again.
+func (m *Model) MarkSubmitted() {
+	m.submitted = true
+}
+
+// View renders the form with each field on its own line, at most width columns wide.
+func (m *Model) View(width int) string {
+	var lines []string
+	if m.definition.Title != "" {
+		lines = append(lines, formTitleStyle.Render("📝 "+m.definition.Title))
+	}
+
+	// Labels are padded to a common width so the values line up; checkboxes carry their label after the box
+	labelWidth := 0
+	for _, field := range m.definition.Fields {
+		if field.Type == content.FormFieldCheckbox {
+			continue
+		}
+		if w := lipgloss.Width(fieldLabel(field)); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	for i, field := range m.definition.Fields {
+		isFocused := m.focused && i == m.cursor
+		labelStyle := fieldLabelStyle
+		marker := "  "
+		if isFocused {
+			labelStyle = fieldFocusedLabelStyle
+			marker = "▶ "
+		}
+
+		if field.Type == content.FormFieldCheckbox {
+			box := "[ ]"
+			if m.checked[i] {
+				box = "[x]"
+			}
+			lines = append(lines, marker+labelStyle.Render(box+" "+fieldLabel(field)))
+			continue
+		}
+
+		label := labelStyle.Render(fmt.Sprintf("%-*s", labelWidth, fieldLabel(field)))
+		var value string
+		if field.Type == content.FormFieldSelect {
+			options := make([]string, len(field.Options))
+			for j, option := range field.Options {
+				if j == m.chosen[i] {
+					options[j] = optionChosenStyle.Render(option)
+				} else {
+					options[j] = optionStyle.Render(option)
+				}
+			}
+			value = strings.Join(options, "")
+		} else {
+			inputWidth := width - labelWidth - 10
+			if inputWidth > maxInputWidth {
+				inputWidth = maxInputWidth
+			}
+			if inputWidth < 10 {
+				inputWidth = 10
+			}
+			m.inputs[i].Width = inputWidth
+			value = m.inputs[i].View()
+		}
+		lines = append(lines, marker+label+"  "+value)
+	}
+
+	submit := submitStyle.Render("[ " + m.definition.SubmitLabel + " ]")
+	if m.focused && m.OnSubmit() {
+		submit = submitFocusedStyle.Render("[ " + m.definition.SubmitLabel + " ]")
+	}
+	if m.submitted {
+		submit += " " + noteStyle.Render("✓ sent")
+	}
+	lines = append(lines, "", "  "+submit)
+
+	style := formStyle
+	if m.focused {
+		style = formFocusedStyle
+	}
+	if width > 4 {
+		style = style.MaxWidth(width)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// fieldLabel returns a field's label, marking required fields.
+func fieldLabel(field interfaces.RenderedFormField) string {
+	if field.Required {
+		return field.Label + " *"
+	}
+	return field.Label
+}
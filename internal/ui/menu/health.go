@@ -0,0 +1,207 @@
+// Package menu implements the health details view of Console Menu Mode.
+// The application list shows one status per application, which hides why an application is degraded.
+// Pressing D on an application runs an immediate health check and shows its full breakdown: the result
+// and response time of the connectivity, handshake, and functional checks, the server's application and
+// protocol versions and features, and any recommendations from the health monitor.
+package menu
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/registry"
+	"github.com/universal-console/console/internal/ui/components"
+)
+
+// healthReportTimeout bounds the health check behind the details view
+const healthReportTimeout = 15 * time.Second
+
+// healthCheckOrder is the order checks are listed in, matching the order they run
+var healthCheckOrder = []registry.HealthCheckType{
+	registry.HealthCheckConnectivity,
+	registry.HealthCheckHandshake,
+	registry.HealthCheckProtocol,
+	registry.HealthCheckFunctional,
+}
+
+// healthReportMsg carries the result of a detailed health check.
+// This is an internal message and remains UNEXPORTED.
+type healthReportMsg struct {
+	appName string
+	report  *registry.HealthCheckResult
+	err     error
+}
+
+// openHealthReport shows the health details view for an application and starts its health check
+func (m *MenuModel) openHealthReport(appName string) tea.Cmd {
+	m.healthReportApp = appName
+	m.healthReport = nil
+	m.healthReportErr = nil
+	m.loadingReport = true
+	return m.fetchHealthReport(appName)
+}
+
+// closeHealthReport returns from the health details view to the application list
+func (m *MenuModel) closeHealthReport() {
+	m.healthReportApp = ""
+	m.healthReport = nil
+	m.healthReportErr = nil
+	m.loadingReport = false
+}
+
+// fetchHealthReport is a command that runs a detailed health check, when the registry supports one.
+func (m *MenuModel) fetchHealthReport(appName string) tea.Cmd {
+	return func() tea.Msg {
+		reporter, ok := m.registryManager.(interface {
+			CheckAppHealthDetailed(ctx context.Context, appName string) (*registry.HealthCheckResult, error)
+		})
+		if !ok {
+			return healthReportMsg{appName: appName, err: fmt.Errorf("health details are not available from this registry")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), healthReportTimeout)
+		defer cancel()
+
+		report, err := reporter.CheckAppHealthDetailed(ctx, appName)
+		return healthReportMsg{appName: appName, report: report, err: err}
+	}
+}
+
+// handleHealthReport shows a finished health check, unless the view has moved on to another application
+func (m *MenuModel) handleHealthReport(msg healthReportMsg) {
+	if msg.appName != m.healthReportApp {
+		return
+	}
+	m.loadingReport = false
+	m.healthReport = msg.report
+	m.healthReportErr = msg.err
+	if msg.report != nil {
+		overall := msg.report.Overall
+		m.appHealth[msg.appName] = &overall
+	}
+}
+
+// handleReportKeys processes key presses while the health details view is open.
+func (m *MenuModel) handleReportKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "esc", "d", "backspace":
+		m.closeHealthReport()
+	case "r":
+		if !m.loadingReport {
+			return m.openHealthReport(m.healthReportApp)
+		}
+	}
+	return nil
+}
+
+// viewHealthReport renders the health details of the selected application.
+func (m *MenuModel) viewHealthReport() string {
+	title := lipgloss.NewStyle().Bold(true).Render("Health Details: " + m.healthReportApp)
+
+	var body string
+	switch {
+	case m.loadingReport:
+		body = components.RenderStatus("running", "Running health checks...")
+	case m.healthReportErr != nil:
+		body = errorStyle.Render("Error: " + m.healthReportErr.Error())
+	case m.healthReport != nil:
+		body = renderHealthReport(m.healthReport)
+	}
+
+	return focusedBoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, "", body))
+}
+
+// renderHealthReport renders the breakdown of a health check: the overall status, each check, the server
+// information, and recommendations
+func renderHealthReport(report *registry.HealthCheckResult) string {
+	var lines []string
+
+	overall := report.Overall
+	lines = append(lines, fmt.Sprintf("Overall: %s · %v · checked at %s",
+		renderHealthStatus(overall.Status), overall.ResponseTime.Truncate(time.Millisecond), overall.LastChecked.Format("15:04:05")))
+	if overall.Error != "" {
+		lines = append(lines, errorStyle.Render("  "+overall.Error))
+	}
+
+	lines = append(lines, "", sectionStyle.Render("Checks"))
+	checked := false
+	for _, checkType := range healthCheckOrder {
+		result, ok := report.CheckResults[checkType]
+		if !ok {
+			continue
+		}
+		checked = true
+		lines = append(lines, fmt.Sprintf("  %-13s %s · %v", checkType, renderHealthStatus(result.Status), result.ResponseTime.Truncate(time.Millisecond)))
+		if result.Error != "" {
+			lines = append(lines, helpStyle.UnsetPadding().Render("                "+result.Error))
+		}
+	}
+	if !checked {
+		lines = append(lines, helpStyle.UnsetPadding().Render("  No checks completed"))
+	}
+
+	if info := report.ServerInfo; info != nil {
+		lines = append(lines, "", sectionStyle.Render("Server"))
+		lines = append(lines, fmt.Sprintf("  Application: %s %s", info.AppName, info.AppVersion))
+		lines = append(lines, fmt.Sprintf("  Protocol:    %s", info.ProtocolVersion))
+		lines = append(lines, fmt.Sprintf("  Features:    %s", formatFeatures(info.Features)))
+	}
+
+	if len(report.Recommendations) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Recommendations"))
+		for _, recommendation := range report.Recommendations {
+			lines = append(lines, "  • "+recommendation)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderHealthStatus renders a health status with its indicator, showing an unknown status as a check in progress
+func renderHealthStatus(status string) string {
+	switch status {
+	case "ready":
+		return components.RenderStatus("success", "Ready")
+	case "degraded":
+		return components.RenderStatus("warning", "Degraded")
+	case "offline":
+		return components.RenderStatus("error", "Offline")
+	case "error":
+		return components.RenderStatus("error", "Error")
+	default:
+		return components.RenderStatus("pending", "Checking...")
+	}
+}
+
+// formatFeatures lists the enabled features in lexical order, noting disabled ones separately
+func formatFeatures(features map[string]bool) string {
+	var enabled, disabled []string
+	for name, on := range features {
+		if on {
+			enabled = append(enabled, name)
+		} else {
+			disabled = append(disabled, name)
+		}
+	}
+	if len(enabled) == 0 && len(disabled) == 0 {
+		return "none reported"
+	}
+	sort.Strings(enabled)
+	sort.Strings(disabled)
+
+	text := strings.Join(enabled, ", ")
+	if text == "" {
+		text = "none enabled"
+	}
+	if len(disabled) > 0 {
+		text += fmt.Sprintf(" (disabled: %s)", strings.Join(disabled, ", "))
+	}
+	return text
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/universal-console/console/internal/interfaces"
+	"github.com/universal-console/console/internal/registry"
 	"github.com/universal-console/console/internal/ui/app"
 )
 
@@ -42,6 +43,17 @@ type MenuModel struct {
 	statusMessage     string
 	err               error
 
+	// Live health updates from the registry
+	healthEvents       <-chan interfaces.AppHealthEvent
+	cancelHealthEvents func()
+	lastHealthChange   string // Most recent status change, shown under the list
+
+	// Health details view of one application, opened with D
+	healthReportApp string
+	healthReport    *registry.HealthCheckResult
+	healthReportErr error
+	loadingReport   bool
+
 	// Terminal dimensions
 	width  int
 	height int
@@ -77,9 +89,17 @@ func NewMenuModel(
 func (m *MenuModel) Init() tea.Cmd {
 	// Start health monitoring in the background
 	m.registryManager.StartHealthMonitoring(context.Background(), 30*time.Second)
+
+	// Subscribe to health events, replacing any subscription from before a connection was made
+	if m.cancelHealthEvents != nil {
+		m.cancelHealthEvents()
+	}
+	m.healthEvents, m.cancelHealthEvents = m.registryManager.SubscribeHealth()
+
 	return tea.Batch(
-		m.reloadApps(), // Initial load of apps
-		tick(),         // Start the timer for health updates
+		m.reloadApps(),                     // Initial load of apps
+		m.updateHealth(),                   // Initial health snapshot
+		waitForHealthEvent(m.healthEvents), // Live updates as health checks complete
 	)
 }
 
@@ -107,16 +127,24 @@ type (
 		health map[string]*interfaces.AppHealth
 	}
 
-	// tickMsg is used to trigger periodic health updates.
+	// healthEventMsg carries a health event from the registry subscription it arrived on.
 	// This is an internal message and remains UNEXPORTED.
-	tickMsg struct{}
+	healthEventMsg struct {
+		event  interfaces.AppHealthEvent
+		events <-chan interfaces.AppHealthEvent
+	}
 )
 
-// tick is a command to send a tickMsg every second for health updates.
-func tick() tea.Cmd {
-	return tea.Every(time.Second, func(t time.Time) tea.Msg {
-		return tickMsg{}
-	})
+// waitForHealthEvent is a command that waits for the next health event from the registry.
+// It returns nothing once the subscription is cancelled.
+func waitForHealthEvent(events <-chan interfaces.AppHealthEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		return healthEventMsg{event: event, events: events}
+	}
 }
 
 // reloadApps is a command to fetch the latest list of registered apps.
@@ -169,6 +197,11 @@ func (m *MenuModel) attemptConnection(profileName, hostOverride string) tea.Cmd
 			}
 		}
 
+		m.protocolClient.SetRateLimit(profile.RateLimit, profile.RateBurst)
+		m.protocolClient.SetCircuitBreaker(profile.CircuitThreshold, time.Duration(profile.CircuitCooldown)*time.Second)
+		m.protocolClient.SetIdlePolicy(time.Duration(profile.KeepAlive)*time.Second, time.Duration(profile.IdleTimeout)*time.Minute)
+		m.protocolClient.SetConnectionPool(profile.PoolSize, profile.MaxConnections, profile.DisableHTTP2)
+
 		// Perform connection
 		_, err = m.protocolClient.Connect(context.Background(), profile.Host, &profile.Auth)
 		if err != nil {
@@ -183,6 +216,7 @@ func (m *MenuModel) attemptConnection(profileName, hostOverride string) tea.Cmd
 			m.contentRenderer,
 			m.configManager,
 			m.authManager,
+			m.registryManager,
 		)
 		// Return the EXPORTED message type with the EXPORTED field name.
 		return ConnectionResultMsg{Model: appModel}
@@ -3,9 +3,11 @@
 package menu
 
 import (
+	"fmt"
 	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/universal-console/console/internal/interfaces"
 )
 
 // Update handles messages and updates the model state.
@@ -24,10 +26,12 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch m.focusState {
-		case FocusList:
+		switch {
+		case m.healthReportApp != "":
+			cmd = m.handleReportKeys(msg)
+		case m.focusState == FocusList:
 			cmd = m.handleListKeys(msg)
-		case FocusInput:
+		case m.focusState == FocusInput:
 			cmd = m.handleInputKeys(msg)
 		}
 		cmds = append(cmds, cmd)
@@ -48,11 +52,17 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.appHealth[name] = health
 		}
 
-	case tickMsg:
-		if !m.isConnecting {
-			cmds = append(cmds, m.updateHealth())
+	case healthReportMsg:
+		m.handleHealthReport(msg)
+
+	case healthEventMsg:
+		// Events from a replaced subscription are stale
+		if msg.events != m.healthEvents {
+			break
 		}
-		cmds = append(cmds, tick())
+		m.handleHealthEvent(msg.event)
+		cmds = append(cmds, waitForHealthEvent(m.healthEvents))
+
 	// This case is necessary if we are not handling character input inside the KeyMsg case
 	// for the text input. We let the default bubble tea update handle non-key messages.
 	default:
@@ -65,6 +75,17 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// handleHealthEvent records an application's new health, noting status changes for display
+func (m *MenuModel) handleHealthEvent(event interfaces.AppHealthEvent) {
+	health := event.Health
+	m.appHealth[event.AppName] = &health
+
+	if event.PrevStatus != health.Status && event.PrevStatus != "unknown" {
+		m.lastHealthChange = fmt.Sprintf("%s: %s → %s at %s",
+			event.AppName, event.PrevStatus, health.Status, health.LastChecked.Format("15:04:05"))
+	}
+}
+
 // handleListKeys processes key presses when the application list is focused.
 func (m *MenuModel) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 	switch key := msg.String(); key {
@@ -85,6 +106,10 @@ func (m *MenuModel) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			m.err = nil
 			return m.attemptConnection(m.registeredApps[m.selectedIndex].Profile, "")
 		}
+	case "d":
+		if len(m.registeredApps) > 0 && m.selectedIndex < len(m.registeredApps) {
+			return m.openHealthReport(m.registeredApps[m.selectedIndex].Name)
+		}
 	case "tab":
 		m.focusState = FocusInput
 		return m.quickConnectInput.Focus()
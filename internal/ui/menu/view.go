@@ -40,6 +40,10 @@ var (
 	errorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#F38BA8")).
 			Bold(true)
+
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#CBA6F7"))
 )
 
 // View renders the UI for the menu model.
@@ -58,6 +62,14 @@ func (m *MenuModel) View() string {
 		return s.String()
 	}
 
+	// Health details replace the list and quick connect while open
+	if m.healthReportApp != "" {
+		s.WriteString(m.viewHealthReport())
+		s.WriteString("\n\n")
+		s.WriteString(helpStyle.Render("Commands: [R] Check again | [Esc] Back | [Q]uit"))
+		return s.String()
+	}
+
 	// Registered Apps List
 	s.WriteString(m.viewAppList())
 	s.WriteString("\n\n")
@@ -67,7 +79,7 @@ func (m *MenuModel) View() string {
 	s.WriteString("\n\n")
 
 	// Footer / Help
-	s.WriteString(helpStyle.Render("Commands: [Enter] Connect | [Tab] Navigate | [Q]uit"))
+	s.WriteString(helpStyle.Render("Commands: [Enter] Connect | [D] Health details | [Tab] Navigate | [Q]uit"))
 
 	// Error message
 	if m.err != nil {
@@ -93,20 +105,7 @@ func (m *MenuModel) viewAppList() string {
 				status = health.Status
 			}
 
-			// Choose the right status component
-			var statusRendered string
-			switch status {
-			case "ready":
-				statusRendered = components.RenderStatus("success", "Ready")
-			case "offline":
-				statusRendered = components.RenderStatus("error", "Offline")
-			case "error":
-				statusRendered = components.RenderStatus("error", "Error")
-			default:
-				statusRendered = components.RenderStatus("pending", "Checking...")
-			}
-
-			itemStr := fmt.Sprintf("[%d] %s (%s) - %s", i+1, app.Name, app.Profile, statusRendered)
+			itemStr := fmt.Sprintf("[%d] %s (%s) - %s", i+1, app.Name, app.Profile, renderHealthStatus(status))
 
 			if m.focusState == FocusList && i == m.selectedIndex {
 				listItems = append(listItems, focusedItemStyle.Render(itemStr))
@@ -116,6 +115,10 @@ func (m *MenuModel) viewAppList() string {
 		}
 	}
 
+	if m.lastHealthChange != "" {
+		listItems = append(listItems, "", helpStyle.UnsetPadding().Render("Last change: "+m.lastHealthChange))
+	}
+
 	listContent := lipgloss.JoinVertical(lipgloss.Left, listItems...)
 
 	style := boxStyle
@@ -0,0 +1,474 @@
+// Package setup implements the connection profile wizard for the Universal Application Console.
+// The wizard walks through a new profile one question at a time: its name, the application's host, the
+// authentication type and token, the theme, and whether actions ask for confirmation. Each answer is checked
+// with the configuration manager's ValidateProfile as soon as it is given, so a mistake is reported next to
+// the question that caused it. Before saving, the wizard connects to the host with the profile's credentials
+// to show that they work; a profile can still be saved when the application is not running yet.
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/universal-console/console/internal/config"
+	"github.com/universal-console/console/internal/interfaces"
+)
+
+// Styling definitions for the wizard
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#89B4FA"))
+
+	questionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#CDD6F4")).
+			Bold(true)
+
+	answerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A6E3A1"))
+
+	optionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6C7086")).
+			Padding(0, 1)
+
+	optionChosenStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#181825")).
+				Background(lipgloss.Color("#89B4FA")).
+				Padding(0, 1)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F38BA8"))
+
+	noteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6C7086")).
+			Italic(true)
+)
+
+// ConnectTimeout bounds the test connection made before the profile is saved
+const ConnectTimeout = 10 * time.Second
+
+// step identifies one question of the wizard
+type step int
+
+const (
+	stepName step = iota
+	stepHost
+	stepAuthType
+	stepToken
+	stepTheme
+	stepConfirmations
+	stepReview
+)
+
+// authTypes are the authentication types a profile can use, as ValidateProfile accepts them
+var authTypes = []string{"none", "bearer"}
+
+// yesNo are the choices of the confirmations question
+var yesNo = []string{"yes", "no"}
+
+// stepFields maps each question to the profile field ValidateProfile reports its problems under
+var stepFields = map[step]string{
+	stepName:     "name",
+	stepHost:     "host",
+	stepAuthType: "auth.type",
+	stepToken:    "auth.token",
+	stepTheme:    "theme",
+}
+
+// connectResultMsg carries the outcome of the test connection
+type connectResultMsg struct {
+	appName string
+	err     error
+}
+
+// Model is the state of the wizard
+type Model struct {
+	configManager  interfaces.ConfigManager
+	protocolClient interfaces.ProtocolClient
+
+	step     step
+	input    textinput.Model // Answer to the current text question
+	answers  map[step]string // Answers given so far, by question
+	options  []string        // Choices of the current selection question
+	chosen   int             // Chosen option of the current selection question
+	themes   []string
+	existing []string // Names of the profiles already saved
+
+	err       error  // Problem with the current answer, or the failed test connection
+	testing   bool   // Whether the test connection is under way
+	tested    string // Name of the application the test connection reached
+	saved     *interfaces.Profile
+	cancelled bool
+}
+
+// New creates a wizard that saves the new profile with configManager and tests it with protocolClient.
+// Host and theme, when not empty, are offered as the default answers.
+func New(configManager interfaces.ConfigManager, protocolClient interfaces.ProtocolClient, host, theme string) *Model {
+	m := &Model{
+		configManager:  configManager,
+		protocolClient: protocolClient,
+		answers: map[step]string{
+			stepHost:          host,
+			stepAuthType:      "none",
+			stepTheme:         theme,
+			stepConfirmations: "yes",
+		},
+	}
+
+	m.themes, _ = configManager.ListThemes()
+	slices.Sort(m.themes)
+	if m.answers[stepTheme] == "" && len(m.themes) > 0 {
+		m.answers[stepTheme] = m.themes[0]
+		if slices.Contains(m.themes, "github") {
+			m.answers[stepTheme] = "github"
+		}
+	}
+	m.existing, _ = configManager.ListProfiles()
+	if len(m.existing) == 0 {
+		m.answers[stepName] = "default"
+	}
+
+	m.enterStep(stepName)
+	return m
+}
+
+// Profile returns the saved profile, or nil when the wizard was cancelled
+func (m *Model) Profile() *interfaces.Profile {
+	return m.saved
+}
+
+// ConnectedApp returns the name of the application the test connection reached, or "" when the profile
+// was saved without one
+func (m *Model) ConnectedApp() string {
+	return m.tested
+}
+
+// Init implements tea.Model
+func (m *Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// profile builds the profile described by the answers so far; later questions keep their defaults
+func (m *Model) profile() *interfaces.Profile {
+	profile := &interfaces.Profile{
+		Name:          strings.TrimSpace(m.answers[stepName]),
+		Host:          strings.TrimSpace(m.answers[stepHost]),
+		Theme:         m.answers[stepTheme],
+		Confirmations: m.answers[stepConfirmations] != "no",
+		Auth:          interfaces.AuthConfig{Type: m.answers[stepAuthType]},
+	}
+	if profile.Auth.Type == "bearer" {
+		profile.Auth.Token = strings.TrimSpace(m.answers[stepToken])
+	}
+	if m.step < stepHost && profile.Host == "" {
+		profile.Host = "localhost:8080" // Not asked yet
+	}
+	return profile
+}
+
+// enterStep shows a question with its current answer
+func (m *Model) enterStep(s step) {
+	m.step = s
+	m.err = nil
+	m.options = nil
+	m.input.Blur()
+
+	switch s {
+	case stepName, stepHost, stepToken:
+		m.input = textinput.New()
+		m.input.Prompt = "> "
+		m.input.CharLimit = 4096
+		m.input.Width = 50
+		m.input.SetValue(m.answers[s])
+		switch s {
+		case stepName:
+			m.input.Placeholder = "my-app"
+		case stepHost:
+			m.input.Placeholder = "localhost:8080"
+		case stepToken:
+			m.input.EchoMode = textinput.EchoPassword
+			m.input.EchoCharacter = '•'
+		}
+		m.input.Focus()
+	case stepAuthType:
+		m.setOptions(authTypes)
+	case stepTheme:
+		m.setOptions(m.themes)
+	case stepConfirmations:
+		m.setOptions(yesNo)
+	}
+}
+
+// setOptions makes the current question a selection, choosing its current answer
+func (m *Model) setOptions(options []string) {
+	m.options = options
+	m.chosen = max(slices.Index(options, m.answers[m.step]), 0)
+}
+
+// answer records the current question's answer, checks it, and moves to the next question
+func (m *Model) answer() {
+	if m.options != nil {
+		if len(m.options) > 0 {
+			m.answers[m.step] = m.options[m.chosen]
+		}
+	} else {
+		m.answers[m.step] = strings.TrimSpace(m.input.Value())
+	}
+
+	if err := m.check(); err != nil {
+		m.err = err
+		return
+	}
+
+	next := m.step + 1
+	if next == stepToken && m.answers[stepAuthType] != "bearer" {
+		next++
+	}
+	if next == stepTheme && len(m.themes) == 0 {
+		next++
+	}
+	m.enterStep(next)
+}
+
+// check validates the profile so far and returns the problem with the current answer, if any
+func (m *Model) check() error {
+	err := m.configManager.ValidateProfile(m.profile())
+	var fieldErr *config.FieldError
+	if errors.As(err, &fieldErr) {
+		if fieldErr.Field == stepFields[m.step] {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// back returns to the previous question
+func (m *Model) back() {
+	previous := m.step - 1
+	if previous == stepTheme && len(m.themes) == 0 {
+		previous--
+	}
+	if previous == stepToken && m.answers[stepAuthType] != "bearer" {
+		previous--
+	}
+	if previous >= stepName {
+		m.enterStep(previous)
+	}
+}
+
+// testConnection connects to the profile's host with its credentials, then disconnects
+func (m *Model) testConnection() tea.Cmd {
+	profile := m.profile()
+	client := m.protocolClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
+		defer cancel()
+
+		spec, err := client.Connect(ctx, profile.Host, &profile.Auth)
+		if err != nil {
+			return connectResultMsg{err: err}
+		}
+		client.Disconnect()
+		return connectResultMsg{appName: spec.AppName}
+	}
+}
+
+// save writes the profile to the configuration file and ends the wizard
+func (m *Model) save() tea.Cmd {
+	profile := m.profile()
+	if err := m.configManager.SaveProfile(profile); err != nil {
+		m.err = fmt.Errorf("could not save the profile: %w", err)
+		return nil
+	}
+	m.saved = profile
+	return tea.Quit
+}
+
+// Update implements tea.Model
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case connectResultMsg:
+		m.testing = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.tested = msg.appName
+		return m, m.save()
+
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey answers, navigates, or cancels the wizard
+func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		m.cancelled = true
+		return tea.Quit
+	case "esc":
+		if m.step == stepName {
+			m.cancelled = true
+			return tea.Quit
+		}
+		if !m.testing {
+			m.back()
+		}
+		return nil
+	}
+
+	if m.testing {
+		return nil
+	}
+
+	if m.step == stepReview {
+		switch msg.String() {
+		case "enter":
+			m.err = nil
+			m.testing = true
+			return m.testConnection()
+		case "s":
+			return m.save()
+		}
+		return nil
+	}
+
+	if m.options != nil {
+		switch msg.String() {
+		case "left", "up", "h", "k", "shift+tab":
+			m.chosen = (m.chosen - 1 + len(m.options)) % len(m.options)
+		case "right", "down", "l", "j", "tab":
+			m.chosen = (m.chosen + 1) % len(m.options)
+		case "enter":
+			m.answer()
+		}
+		return nil
+	}
+
+	if msg.String() == "enter" {
+		m.answer()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = nil
+	return cmd
+}
+
+// questionText returns the question asked at a step
+func questionText(s step) string {
+	switch s {
+	case stepName:
+		return "Profile name"
+	case stepHost:
+		return "Application host and port"
+	case stepAuthType:
+		return "Authentication"
+	case stepToken:
+		return "Bearer token"
+	case stepTheme:
+		return "Theme"
+	case stepConfirmations:
+		return "Confirm actions before running them"
+	}
+	return ""
+}
+
+// displayAnswer returns an answer as shown in the summary, hiding the token
+func (m *Model) displayAnswer(s step) string {
+	value := m.answers[s]
+	if s == stepToken && value != "" {
+		return strings.Repeat("•", min(len(value), 8))
+	}
+	return value
+}
+
+// View implements tea.Model
+func (m *Model) View() string {
+	if m.saved != nil || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("New connection profile") + "\n\n")
+
+	// Questions already answered
+	for s := stepName; s < m.step; s++ {
+		if s == stepToken && m.answers[stepAuthType] != "bearer" || s == stepTheme && len(m.themes) == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %s: %s\n", questionText(s), answerStyle.Render(m.displayAnswer(s))))
+	}
+	if m.step > stepName {
+		b.WriteString("\n")
+	}
+
+	switch {
+	case m.step == stepReview:
+		b.WriteString(m.reviewView())
+	case m.options != nil:
+		b.WriteString(questionStyle.Render(questionText(m.step)) + "\n")
+		options := make([]string, len(m.options))
+		for i, option := range m.options {
+			options[i] = optionStyle.Render(option)
+			if i == m.chosen {
+				options[i] = optionChosenStyle.Render(option)
+			}
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, options...) + "\n")
+	default:
+		b.WriteString(questionStyle.Render(questionText(m.step)) + "\n")
+		b.WriteString(m.input.View() + "\n")
+		if m.step == stepName && slices.Contains(m.existing, strings.TrimSpace(m.input.Value())) {
+			b.WriteString(noteStyle.Render("A profile with this name exists and will be replaced") + "\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("✗ "+m.err.Error()) + "\n")
+	}
+
+	b.WriteString("\n" + noteStyle.Render(m.keyHints()))
+	return b.String()
+}
+
+// reviewView shows the test connection's progress and the ways to save
+func (m *Model) reviewView() string {
+	switch {
+	case m.testing:
+		return fmt.Sprintf("Connecting to %s…\n", m.profile().Host)
+	case m.err != nil:
+		return questionStyle.Render("The test connection failed") + "\n" +
+			"The profile can be saved anyway, for an application that is not running yet.\n"
+	}
+	return questionStyle.Render("Ready to save") + "\n" +
+		fmt.Sprintf("The profile will be saved to %s after a test connection.\n", m.configManager.GetConfigPath())
+}
+
+// keyHints lists the keys available at the current step
+func (m *Model) keyHints() string {
+	back := "esc back"
+	if m.step == stepName {
+		back = "esc cancel"
+	}
+	switch {
+	case m.step == stepReview && m.err != nil:
+		return "enter retry • s save anyway • " + back
+	case m.step == stepReview:
+		return "enter test and save • s save without testing • " + back
+	case m.options != nil:
+		return "←/→ choose • enter next • " + back
+	}
+	return "enter next • " + back
+}
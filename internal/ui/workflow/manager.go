@@ -1,7 +1,8 @@
 // Package workflow implements multi-step operation context management for the
 // Universal Application Console. This file handles workflow state, renders
-// breadcrumb navigation, and provides cancellation mechanisms for long-running
-// operations, as specified in section 3.4.1 of the design specification.
+// breadcrumb navigation, or a checklist when the workflow labels its steps,
+// and provides cancellation mechanisms for long-running operations, as
+// specified in section 3.4.1 of the design specification.
 package workflow
 
 import (
@@ -22,17 +23,23 @@ var (
 		MarginBottom(1)
 )
 
+// Checklist workflows are boxed by the renderer in the theme's workflow style.
+var checklistStyle = lipgloss.NewStyle().MarginBottom(1)
+
 // Manager handles the state and presentation of a multi-step workflow.
 type Manager struct {
+	renderer        interfaces.ContentRenderer
 	currentWorkflow *interfaces.Workflow
 	active          bool
 	width           int
 }
 
-// NewManager creates a new Workflow Manager.
-func NewManager() *Manager {
+// NewManager creates a new Workflow Manager. Workflow steps are reported to the renderer, which also
+// draws workflows that label their steps as a checklist.
+func NewManager(renderer interfaces.ContentRenderer) *Manager {
 	return &Manager{
-		active: false,
+		renderer: renderer,
+		active:   false,
 	}
 }
 
@@ -46,6 +53,9 @@ func (m *Manager) UpdateState(workflow *interfaces.Workflow) {
 
 	m.currentWorkflow = workflow
 	m.active = true
+	if m.renderer != nil {
+		m.renderer.TrackWorkflow(workflow)
+	}
 }
 
 // EndWorkflow clears the current workflow state.
@@ -76,6 +86,12 @@ func (m *Manager) View() string {
 	}
 
 	wf := m.currentWorkflow
+	if len(wf.Steps) > 0 && m.renderer != nil {
+		if checklist, err := m.renderer.RenderWorkflow(wf, nil); err == nil {
+			return checklistStyle.Render(checklist)
+		}
+	}
+
 	breadcrumbText := fmt.Sprintf("Workflow: %s (%d/%d)", wf.Title, wf.Step, wf.TotalSteps)
 
 	// Calculate available width for the progress bar